@@ -31,10 +31,18 @@ import (
 )
 
 func allModules(flags *mcpserverfx.Flags) []fx.Option {
-	return []fx.Option{
+	return append(clientModules(flags),
 		fx.WithLogger(func(logger *zap.Logger) fxevent.Logger {
 			return &fxevent.ZapLogger{Logger: logger}
 		}),
+		toolsfx.Module,
+		mcpserverfx.Module,
+	)
+}
+
+// clientModules provide the config and Chronosphere clients, without the tools and server.
+func clientModules(flags *mcpserverfx.Flags) []fx.Option {
+	return []fx.Option{
 		fx.Provide(func() (config.Provider, error) {
 			return pkgconfig.ParseFile(flags.ConfigFilePath)
 		}),
@@ -46,8 +54,7 @@ func allModules(flags *mcpserverfx.Flags) []fx.Option {
 		}),
 		clientfx.Module,
 		instrumentfx.Module,
-		toolsfx.Module,
-		mcpserverfx.Module,
+		mcpserverfx.ConfigModule,
 	}
 }
 
@@ -70,5 +77,6 @@ func New() *cobra.Command {
 	}
 
 	flags.AddFlags(cmd)
+	cmd.AddCommand(newSnapshotCmd(), newDiffConfigCmd())
 	return cmd
 }
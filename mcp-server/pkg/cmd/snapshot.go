@@ -0,0 +1,141 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/mcpserverfx"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/snapshot"
+)
+
+// runWithClients constructs the config and Chronosphere clients and calls fn with them.
+func runWithClients(flags *mcpserverfx.Flags, fn func(api *configv1.ConfigV1API, cfg *tools.Config) error) error {
+	var fnErr error
+	app := fx.New(append(clientModules(flags),
+		fx.NopLogger,
+		fx.Invoke(func(api *configv1.ConfigV1API, cfg *tools.Config) {
+			fnErr = fn(api, cfg)
+		}),
+	)...)
+	if err := app.Err(); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+func newSnapshotCmd() *cobra.Command {
+	var (
+		flags = &mcpserverfx.Flags{}
+		dir   string
+	)
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Stores a snapshot of all config entities",
+		Long:  "Stores a snapshot of all config entities in the snapshot directory, then removes snapshots beyond the configured retention.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runWithClients(flags, func(api *configv1.ConfigV1API, cfg *tools.Config) error {
+				if dir == "" {
+					dir = cfg.Snapshots.Directory
+				}
+				if dir == "" {
+					return fmt.Errorf("no snapshot directory set with --dir or in the config file")
+				}
+				s, err := snapshot.Take(cmd.Context(), api, time.Now())
+				if err != nil {
+					return err
+				}
+				if err := snapshot.Write(dir, s); err != nil {
+					return err
+				}
+				pruned, err := snapshot.Prune(dir, cfg.Snapshots.Retain)
+				if err != nil {
+					return err
+				}
+				cmd.Printf("stored snapshot %s\n", s.Name)
+				for _, name := range pruned {
+					cmd.Printf("removed snapshot %s\n", name)
+				}
+				for _, w := range s.Warnings {
+					cmd.PrintErrf("warning: %s\n", w)
+				}
+				return nil
+			})
+		},
+	}
+	flags.AddFlags(cmd)
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to store the snapshot in. Defaults to the configured snapshot directory.")
+	return cmd
+}
+
+func newDiffConfigCmd() *cobra.Command {
+	var (
+		flags       = &mcpserverfx.Flags{}
+		dir         string
+		from        string
+		to          string
+		entityTypes []string
+		jsonOutput  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "diff-config",
+		Short: "Compares config between two snapshots, or a snapshot and the live config",
+		Long: fmt.Sprintf(`Compares config between two snapshots, or a snapshot and the live config, and prints the added, removed and modified entities.
+
+Snapshots are referred to by name, %q, %q or %q for the current config.`, snapshot.Latest, snapshot.Previous, snapshot.Live),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runWithClients(flags, func(api *configv1.ConfigV1API, cfg *tools.Config) error {
+				if dir == "" {
+					dir = cfg.Snapshots.Directory
+				}
+				fromSnapshot, err := snapshot.Open(cmd.Context(), api, dir, from)
+				if err != nil {
+					return err
+				}
+				toSnapshot, err := snapshot.Open(cmd.Context(), api, dir, to)
+				if err != nil {
+					return err
+				}
+				diff := snapshot.Compare(fromSnapshot, toSnapshot, entityTypes)
+				if !jsonOutput {
+					cmd.Print(diff.Text())
+					return nil
+				}
+				b, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(b))
+				return nil
+			})
+		},
+	}
+	flags.AddFlags(cmd)
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory snapshots are stored in. Defaults to the configured snapshot directory.")
+	cmd.Flags().StringVar(&from, "from", snapshot.Latest, "Snapshot to compare from.")
+	cmd.Flags().StringVar(&to, "to", snapshot.Live, "Snapshot to compare to.")
+	cmd.Flags().StringSliceVar(&entityTypes, "entity-types", nil, "Entity types to compare. Defaults to all.")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the diff as JSON.")
+	return cmd
+}
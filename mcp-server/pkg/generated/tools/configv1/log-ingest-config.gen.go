@@ -0,0 +1,34 @@
+package configv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_ingest_config"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+)
+
+func GetLogIngestConfig(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("get_log_ingest_config",
+			mcp.WithDescription("Get log-ingest-config resource"),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			queryParams := &log_ingest_config.ReadLogIngestConfigParams{
+				Context: ctx,
+			}
+
+			resp, err := api.LogIngestConfig.ReadLogIngestConfig(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ReadLogIngestConfig: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
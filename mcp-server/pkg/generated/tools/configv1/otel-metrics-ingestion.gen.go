@@ -0,0 +1,34 @@
+package configv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/otel_metrics_ingestion"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+)
+
+func GetOtelMetricsIngestion(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("get_otel_metrics_ingestion",
+			mcp.WithDescription("Get otel-metrics-ingestion resource"),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			queryParams := &otel_metrics_ingestion.ReadOtelMetricsIngestionParams{
+				Context: ctx,
+			}
+
+			resp, err := api.OtelMetricsIngestion.ReadOtelMetricsIngestion(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ReadOtelMetricsIngestion: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
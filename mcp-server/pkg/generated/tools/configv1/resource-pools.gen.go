@@ -0,0 +1,34 @@
+package configv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/resource_pools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+)
+
+func GetResourcePools(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("get_resource_pools",
+			mcp.WithDescription("Get resource-pools resource"),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			queryParams := &resource_pools.ReadResourcePoolsParams{
+				Context: ctx,
+			}
+
+			resp, err := api.ResourcePools.ReadResourcePools(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ReadResourcePools: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
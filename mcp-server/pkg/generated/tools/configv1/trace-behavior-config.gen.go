@@ -0,0 +1,34 @@
+package configv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_behavior_config"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+)
+
+func GetTraceBehaviorConfig(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("get_trace_behavior_config",
+			mcp.WithDescription("Get trace-behavior-config resource"),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			queryParams := &trace_behavior_config.ReadTraceBehaviorConfigParams{
+				Context: ctx,
+			}
+
+			resp, err := api.TraceBehaviorConfig.ReadTraceBehaviorConfig(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ReadTraceBehaviorConfig: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
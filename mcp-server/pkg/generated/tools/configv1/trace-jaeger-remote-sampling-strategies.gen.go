@@ -0,0 +1,135 @@
+package configv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_jaeger_remote_sampling_strategy"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+func GetTraceJaegerRemoteSamplingStrategy(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("get_trace_jaeger_remote_sampling_strategy",
+			mcp.WithDescription("Get trace-jaeger-remote-sampling-strategies resource"),
+
+			mcp.WithString("slug",
+				mcp.Description(""),
+				mcp.Required(),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			slug, err := params.String(request, "slug", true, "")
+			if err != nil {
+				return nil, err
+			}
+
+			queryParams := &trace_jaeger_remote_sampling_strategy.ReadTraceJaegerRemoteSamplingStrategyParams{
+				Context: ctx,
+
+				Slug: slug,
+			}
+
+			resp, err := api.TraceJaegerRemoteSamplingStrategy.ReadTraceJaegerRemoteSamplingStrategy(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ReadTraceJaegerRemoteSamplingStrategy: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
+
+func ListTraceJaegerRemoteSamplingStrategies(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("list_trace_jaeger_remote_sampling_strategies",
+			mcp.WithDescription("List trace-jaeger-remote-sampling-strategies resources"),
+
+			mcp.WithString("name_or_service_contains",
+				mcp.Description(""),
+			),
+
+			params.WithStringArray("names",
+				mcp.Description("Filters results by name, where any TraceJaegerRemoteSamplingStrategy with a matching name in the given list (and matches all other filters) is returned."),
+			),
+
+			mcp.WithNumber("page_max_size",
+				mcp.Description("Page size preference (i.e. how many items are returned in the next page). If zero, the server will use a default. Regardless of what size is given, clients must never assume how many items will be returned."),
+			),
+
+			mcp.WithString("page_token",
+				mcp.Description("Opaque page token identifying which page to request. An empty token identifies the first page."),
+			),
+
+			params.WithStringArray("service_names",
+				mcp.Description("Filters results by service_name, where any TraceJaegerRemoteSamplingStrategy with a matching service_name in the given list (and matches all other filters) is returned."),
+			),
+
+			params.WithStringArray("slugs",
+				mcp.Description("Filters results by slug, where any TraceJaegerRemoteSamplingStrategy with a matching slug in the given list (and matches all other filters) is returned."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			nameOrServiceContains, err := params.String(request, "name_or_service_contains", false, "")
+			if err != nil {
+				return nil, err
+			}
+
+			names, err := params.StringArray(request, "names", false, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			pageMaxSize, err := params.Int(request, "page_max_size", false, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			pageToken, err := params.String(request, "page_token", false, "")
+			if err != nil {
+				return nil, err
+			}
+
+			serviceNames, err := params.StringArray(request, "service_names", false, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			slugs, err := params.StringArray(request, "slugs", false, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			queryParams := &trace_jaeger_remote_sampling_strategy.ListTraceJaegerRemoteSamplingStrategiesParams{
+				Context: ctx,
+
+				NameOrServiceContains: ptr.To(nameOrServiceContains),
+
+				Names: names,
+
+				PageMaxSize: ptr.To(int64(pageMaxSize)),
+
+				PageToken: &pageToken,
+
+				ServiceNames: serviceNames,
+
+				Slugs: slugs,
+			}
+
+			resp, err := api.TraceJaegerRemoteSamplingStrategy.ListTraceJaegerRemoteSamplingStrategies(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ListTraceJaegerRemoteSamplingStrategies: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package configv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_tail_sampling_rules"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+)
+
+func GetTraceTailSamplingRules(api *configv1.ConfigV1API, logger *zap.Logger) tools.MCPTool {
+	return tools.MCPTool{
+		Metadata: tools.NewMetadata("get_trace_tail_sampling_rules",
+			mcp.WithDescription("Get trace-tail-sampling-rules resource"),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+			queryParams := &trace_tail_sampling_rules.ReadTraceTailSamplingRulesParams{
+				Context: ctx,
+			}
+
+			resp, err := api.TraceTailSamplingRules.ReadTraceTailSamplingRules(queryParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to call ReadTraceTailSamplingRules: %s", err)
+			}
+			return &tools.Result{
+				JSONContent: resp,
+			}, nil
+		},
+	}
+}
@@ -201,6 +201,10 @@ func (t *loggingTool) mustHandle(ctx context.Context, request mcp.CallToolReques
 	}
 
 	toolResult.Content = append(toolResult.Content, mcp.NewTextContent(string(resultBytes)))
+	for _, image := range resp.Images {
+		encoded := base64.StdEncoding.EncodeToString(image)
+		toolResult.Content = append(toolResult.Content, mcp.NewImageContent(encoded, "image/png"))
+	}
 	if len(resp.Meta) > 0 {
 		toolResult.Meta = mcp.NewMetaFromMap(resp.Meta)
 	}
@@ -92,6 +92,23 @@ func TestLoggingTool_mustHandle(t *testing.T) {
 				mcp.NewImageContent(base64.StdEncoding.EncodeToString([]byte("test-image-data")), "image/png"),
 			},
 		},
+		{
+			name:            "response with JSON and images",
+			sessionAPIToken: "test-token",
+			tool: tools.MCPTool{
+				Handler: func(_ context.Context, _ mcp.CallToolRequest) (*tools.Result, error) {
+					return &tools.Result{
+						JSONContent: map[string]string{"key": "value"},
+						Images:      [][]byte{[]byte("image-1"), []byte("image-2")},
+					}, nil
+				},
+			},
+			expectedContent: []mcp.Content{
+				mcp.NewTextContent(`{"key":"value"}`),
+				mcp.NewImageContent(base64.StdEncoding.EncodeToString([]byte("image-1")), "image/png"),
+				mcp.NewImageContent(base64.StdEncoding.EncodeToString([]byte("image-2")), "image/png"),
+			},
+		},
 		{
 			name:            "response with metadata",
 			sessionAPIToken: "test-token",
@@ -28,9 +28,12 @@ import (
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
 )
 
-// Module registers the server.
+// ConfigModule provides the server config, which is also needed by commands that only use the
+// Chronosphere clients.
+var ConfigModule = fx.Provide(parseConfig)
+
+// Module registers the server. It requires ConfigModule.
 var Module = fx.Options(
-	fx.Provide(parseConfig),
 	fx.Invoke(invoke),
 )
 
@@ -16,33 +16,39 @@
 package configapi
 
 import (
+	"github.com/mark3labs/mcp-go/mcp"
 	"go.uber.org/zap"
 
 	configv1client "github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/generated/tools/configv1"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
 )
 
 var _ tools.MCPTools = (*Tools)(nil)
 
 // Tools provides tools for the Chronosphere config API.
 type Tools struct {
-	logger *zap.Logger
-	client *configv1client.ConfigV1API
-	config *tools.Config
+	logger      *zap.Logger
+	client      *configv1client.ConfigV1API
+	config      *tools.Config
+	linkBuilder *links.Builder
 }
 
 func NewTools(
 	client *configv1client.ConfigV1API,
 	logger *zap.Logger,
 	config *tools.Config,
+	linkBuilder *links.Builder,
 ) (*Tools, error) {
 	logger.Info("events tool configured")
 
 	return &Tools{
-		logger: logger,
-		client: client,
-		config: config,
+		logger:      logger,
+		client:      client,
+		config:      config,
+		linkBuilder: linkBuilder,
 	}, nil
 }
 
@@ -52,22 +58,284 @@ func (t *Tools) GroupName() string {
 
 func (t *Tools) MCPTools() []tools.MCPTool {
 	mcpTools := []tools.MCPTool{
+		configv1.GetBucket(t.client, t.logger),
+		configv1.ListBuckets(t.client, t.logger),
+		configv1.GetCollection(t.client, t.logger),
+		configv1.ListCollections(t.client, t.logger),
 		configv1.GetDashboard(t.client, t.logger),
 		configv1.ListDashboards(t.client, t.logger),
+		configv1.GetDataset(t.client, t.logger),
+		configv1.ListDatasets(t.client, t.logger),
+		configv1.GetDerivedLabel(t.client, t.logger),
+		configv1.ListDerivedLabels(t.client, t.logger),
+		configv1.GetDerivedMetric(t.client, t.logger),
+		configv1.ListDerivedMetrics(t.client, t.logger),
 		configv1.GetDropRule(t.client, t.logger),
 		configv1.ListDropRules(t.client, t.logger),
+		configv1.GetGcpMetricsIntegration(t.client, t.logger),
+		configv1.ListGcpMetricsIntegrations(t.client, t.logger),
+		{
+			Metadata: tools.NewMetadata("get_grafana_dashboard",
+				mcp.WithDescription(`Gets a Grafana dashboard, which is a dashboard kept in the Grafana JSON format. Instead of the raw dashboard JSON, a compact summary of its variables, panels and PromQL queries is returned.
+
+Response fields:
+- variables: Each variable with its type and query
+- panels: Per panel in layout order, the row it is in, title, panel type, unit and PromQL queries, leaving out hidden queries
+- panel_count, query_count: Number of panels and PromQL queries
+- error: Set if the dashboard JSON could not be parsed`),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the Grafana dashboard."),
+					mcp.Required(),
+				),
+			),
+			Handler: t.getGrafanaDashboard,
+		},
+		{
+			Metadata: tools.NewMetadata("list_grafana_dashboards",
+				mcp.WithDescription(`Lists Grafana dashboards, which are dashboards kept in the Grafana JSON format. Instead of the raw dashboard JSON, each dashboard is summarized by its number of panels and PromQL queries, and by its variables and panels if include_panels is set.
+
+Response fields:
+- grafana_dashboards: Per dashboard the slug, name, collection or bucket, panel_count and query_count, and variables and panels if include_panels is set`),
+				params.WithStringArray("slugs",
+					mcp.Description("Filters results by slug."),
+				),
+				params.WithStringArray("names",
+					mcp.Description("Filters results by name."),
+				),
+				params.WithStringArray("collection_slugs",
+					mcp.Description("Filters results by collection_slug."),
+				),
+				params.WithStringArray("bucket_slugs",
+					mcp.Description("Filters results by bucket_slug."),
+				),
+				mcp.WithBoolean("include_panels",
+					mcp.Description("Whether to include the variables and panels with their queries of each dashboard. Default is false."),
+				),
+				mcp.WithNumber("page_max_size",
+					mcp.Description("Page size preference. If zero, the server will use a default."),
+				),
+				mcp.WithString("page_token",
+					mcp.Description("Opaque page token identifying which page to request. An empty token identifies the first page."),
+				),
+			),
+			Handler: t.listGrafanaDashboards,
+		},
+		configv1.GetLogAllocationConfig(t.client, t.logger),
+		configv1.GetLogIngestConfig(t.client, t.logger),
+		{
+			Metadata: tools.NewMetadata("get_log_scale_action",
+				mcp.WithDescription(`Gets a LogScale action, which is what LogScale alerts trigger, e.g. a Slack message or PagerDuty incident.
+
+Credentials such as ingest tokens, API keys, routing keys and webhook headers are redacted, and Slack, VictorOps and webhook URLs only keep their host.
+
+Response fields:
+- type: The action type, e.g. SLACK, PAGER_DUTY or WEBHOOK
+- config: The configuration of the action type, with credentials redacted
+- redacted_fields: Paths of the fields that were redacted`),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the LogScale action."),
+					mcp.Required(),
+				),
+			),
+			Handler: t.getLogScaleAction,
+		},
+		{
+			Metadata: tools.NewMetadata("list_log_scale_actions",
+				mcp.WithDescription(`Lists LogScale actions, which are what LogScale alerts trigger, e.g. Slack messages or PagerDuty incidents.
+
+Credentials such as ingest tokens, API keys, routing keys and webhook headers are redacted, and Slack, VictorOps and webhook URLs only keep their host.
+
+Response fields:
+- type: The action type, e.g. SLACK, PAGER_DUTY or WEBHOOK
+- config: The configuration of the action type, with credentials redacted
+- redacted_fields: Paths of the fields that were redacted`),
+				params.WithStringArray("slugs",
+					mcp.Description("Filters results by slug."),
+				),
+				params.WithStringArray("names",
+					mcp.Description("Filters results by name."),
+				),
+				mcp.WithNumber("page_max_size",
+					mcp.Description("Page size preference. If zero, the server will use a default."),
+				),
+				mcp.WithString("page_token",
+					mcp.Description("Opaque page token identifying which page to request. An empty token identifies the first page."),
+				),
+			),
+			Handler: t.listLogScaleActions,
+		},
+		configv1.GetLogScaleAlert(t.client, t.logger),
+		configv1.ListLogScaleAlerts(t.client, t.logger),
 		configv1.GetMappingRule(t.client, t.logger),
 		configv1.ListMappingRules(t.client, t.logger),
 		configv1.GetMonitor(t.client, t.logger),
 		configv1.ListMonitors(t.client, t.logger),
 		configv1.GetNotificationPolicy(t.client, t.logger),
 		configv1.ListNotificationPolicies(t.client, t.logger),
+		configv1.GetOtelMetricsIngestion(t.client, t.logger),
+		{
+			Metadata: tools.NewMetadata("get_notifier",
+				mcp.WithDescription(`Gets a notifier, which is where notification policies send alerts to, e.g. a Slack channel or PagerDuty service.
+
+Credentials such as API keys, integration keys, tokens and basic auth are redacted, and webhook URLs only keep their host.
+
+Response fields:
+- type: The integration notifications are sent with, one of slack, pagerduty, email, ops_genie, victor_ops, webhook or discard
+- destination: Where notifications go, e.g. the Slack channel, email address or OpsGenie responders
+- config: The configuration of the integration, with credentials redacted
+- redacted_fields: Paths of the fields that were redacted`),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the notifier."),
+					mcp.Required(),
+				),
+			),
+			Handler: t.getNotifier,
+		},
+		{
+			Metadata: tools.NewMetadata("list_notifiers",
+				mcp.WithDescription(`Lists notifiers, which are where notification policies send alerts to, e.g. Slack channels or PagerDuty services.
+
+Credentials such as API keys, integration keys, tokens and basic auth are redacted, and webhook URLs only keep their host.
+
+Response fields:
+- type: The integration notifications are sent with, one of slack, pagerduty, email, ops_genie, victor_ops, webhook or discard
+- destination: Where notifications go, e.g. the Slack channel, email address or OpsGenie responders
+- config: The configuration of the integration, with credentials redacted
+- redacted_fields: Paths of the fields that were redacted`),
+				params.WithStringArray("slugs",
+					mcp.Description("Filters results by slug."),
+				),
+				params.WithStringArray("names",
+					mcp.Description("Filters results by name."),
+				),
+				mcp.WithNumber("page_max_size",
+					mcp.Description("Page size preference. If zero, the server will use a default."),
+				),
+				mcp.WithString("page_token",
+					mcp.Description("Opaque page token identifying which page to request. An empty token identifies the first page."),
+				),
+			),
+			Handler: t.listNotifiers,
+		},
 		configv1.GetRecordingRule(t.client, t.logger),
 		configv1.ListRecordingRules(t.client, t.logger),
+		configv1.GetResourcePools(t.client, t.logger),
 		configv1.GetRollupRule(t.client, t.logger),
 		configv1.ListRollupRules(t.client, t.logger),
+		{
+			Metadata: tools.NewMetadata("list_service_accounts",
+				mcp.WithDescription(`Lists service accounts with their permissions and the teams that own them. Use this to answer questions like "which service accounts exist, what can they access and who owns them".
+
+API tokens are always redacted. A service account is owned by the teams it is a member of.
+
+Set audit to flag risky accounts. Audits cover every account, so paging is ignored. Service accounts have no description or last used time, so the stale finding is based on updated_at only: an account still in use is flagged if it has not been changed for stale_days. Findings:
+- unrestricted: The account can use all APIs, including config writes
+- unscoped_metrics_write: The account can write metrics without a label restriction
+- no_team: The account is not a member of any team
+- stale: The account's updated_at (or created_at if never updated) is older than stale_days. This does not mean the account is unused
+
+Response fields:
+- service_accounts: Per account the slug, name, email, permissions summary, metrics_restriction, teams, created and updated time, and findings in audit mode
+- finding_counts: Number of accounts per finding, in audit mode`),
+				params.WithStringArray("slugs",
+					mcp.Description("Filters results by slug."),
+				),
+				params.WithStringArray("names",
+					mcp.Description("Filters results by name."),
+				),
+				mcp.WithBoolean("audit",
+					mcp.Description("Whether to audit every service account for risky permissions and ownership. Default is false."),
+				),
+				mcp.WithNumber("stale_days",
+					mcp.Description("In audit mode, accounts not updated for this many days are flagged as stale. Default is 180; 0 disables the check."),
+				),
+				mcp.WithNumber("page_max_size",
+					mcp.Description("Page size preference. If zero, the server will use a default."),
+				),
+				mcp.WithString("page_token",
+					mcp.Description("Opaque page token identifying which page to request. An empty token identifies the first page."),
+				),
+			),
+			Handler: t.listServiceAccounts,
+		},
+		configv1.GetService(t.client, t.logger),
 		configv1.GetSlo(t.client, t.logger),
 		configv1.ListSlos(t.client, t.logger),
+		configv1.GetTeam(t.client, t.logger),
+		configv1.ListTeams(t.client, t.logger),
+		configv1.GetTraceBehavior(t.client, t.logger),
+		configv1.ListTraceBehaviors(t.client, t.logger),
+		configv1.GetTraceBehaviorConfig(t.client, t.logger),
+		configv1.GetTraceJaegerRemoteSamplingStrategy(t.client, t.logger),
+		configv1.ListTraceJaegerRemoteSamplingStrategies(t.client, t.logger),
+		configv1.GetTraceMetricsRule(t.client, t.logger),
+		configv1.ListTraceMetricsRules(t.client, t.logger),
+		configv1.GetTraceTailSamplingRules(t.client, t.logger),
+		{
+			Metadata: tools.NewMetadata("get_ownership",
+				mcp.WithDescription(`Gets who owns a config entity. Use this to answer questions like "who owns this monitor" or "who should be contacted about this dashboard".
+
+The entity is resolved to the collection, service or bucket it belongs to, and from there to the owning team and its members. Notification policies and teams are resolved to their team directly.
+
+Response fields:
+- owner: The collection, service or bucket the entity belongs to
+- notification_policy_slug: The notification policy of the entity, or else of its owner
+- team: The owning team with the emails of its members
+- warnings: Parts of the chain that could not be resolved, e.g. an owner without a team`),
+				mcp.WithString("entity_type",
+					mcp.Description("Type of the config entity."),
+					mcp.Required(),
+					mcp.Enum(t.ownershipEntityTypes()...),
+				),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the config entity."),
+					mcp.Required(),
+				),
+			),
+			Handler: t.getOwnership,
+		},
+		{
+			Metadata: tools.NewMetadata("resolve_dataset_and_pool",
+				mcp.WithDescription(`Resolves which datasets and metrics resource pool a service or label set falls into. Use this to answer questions like "which dataset are the logs of this service in" or "which resource pool do these metrics count towards".
+
+Dataset match criteria and resource pool filters are evaluated locally. Log dataset queries of the form field = "value" joined by AND can be evaluated, other queries are reported as undetermined. Trace datasets are evaluated as a root span of the service with the labels as span attributes.
+
+Response fields:
+- labels: The labels evaluated, including the service label
+- datasets: The datasets that match, or may match depending on their undetermined_conditions
+- resource_pool: The first resource pool with a filter matching the labels, or else the default pool, with the priority of the labels within the pool
+- warnings: Datasets without match criteria, or missing resource pools config`),
+				mcp.WithString("service",
+					mcp.Description("Optional. Service name. Used as the service label unless labels contains one."),
+				),
+				mcp.WithObject("labels",
+					mcp.Description(`Optional. Labels of the metrics, logs or spans, e.g. {"service": "payments", "env": "prod"}. Either service or labels must be set.`),
+					mcp.AdditionalProperties(map[string]any{"type": "string"}),
+				),
+			),
+			Handler: t.resolveDatasetAndPool,
+		},
+		{
+			Metadata: tools.NewMetadata("check_ingestion_config",
+				mcp.WithDescription(`Checks how metrics ingestion is configured. Use this when metrics from a source go missing, e.g. to check whether a GCP metric is ingested or which label an OpenTelemetry resource attribute becomes.
+
+Reports the OTel metrics ingestion resource attribute settings and the GCP metric prefixes enabled by GCP metrics integrations. The metric name may be a GCP metric type, e.g. compute.googleapis.com/instance/cpu/utilization, or its Prometheus-style name. Drop rules are not checked, use explain_series_rules for those.
+
+Response fields:
+- otel_metrics_ingestion: How resource attributes are copied to labels, with unset modes replaced by their defaults
+- gcp_metric_prefixes: The GCP metric prefixes ingested, with their integration and project
+- metric: The GCP metric prefixes matching the metric name and whether it is ingested
+- resource_attributes: For each resource attribute, the label it maps to and whether it is copied, excluded, ignored or undetermined
+- warnings: E.g. that no OTel metrics ingestion config exists`),
+				mcp.WithString("metric_name",
+					mcp.Description("Optional. Metric name to check."),
+				),
+				params.WithStringArray("resource_attributes",
+					mcp.Description("Optional. OTel resource attribute keys to check, e.g. k8s.namespace.name."),
+				),
+			),
+			Handler: t.checkIngestionConfig,
+		},
 	}
 	if t.config.EnableClassicDashboards {
 		mcpTools = append(mcpTools,
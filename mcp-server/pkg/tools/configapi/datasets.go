@@ -0,0 +1,262 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/resource_pools"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/tracefilter"
+)
+
+// Priorities of a series within its resource pool.
+const (
+	priorityHigh    = "high"
+	priorityLow     = "low"
+	priorityDefault = "default"
+)
+
+// logClausePattern matches a single `field = "value"` or `field != "value"` clause of a log
+// query, optionally followed by AND.
+var logClausePattern = regexp.MustCompile(`^\s*([A-Za-z_][\w.]*)\s*(!=|=)\s*("(?:[^"\\]|\\.)*")\s*(?i:(AND)\s+|$)`)
+
+// DatasetResolution is the datasets and resource pool a label set falls into.
+type DatasetResolution struct {
+	Labels map[string]string `json:"labels"`
+	// Datasets are the datasets whose match criteria match, or may match, the labels.
+	Datasets     []*DatasetMatch    `json:"datasets"`
+	ResourcePool *ResourcePoolMatch `json:"resource_pool,omitempty"`
+	Warnings     []string           `json:"warnings,omitempty"`
+}
+
+// DatasetMatch describes whether a dataset matches a label set.
+type DatasetMatch struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type"`
+	Matched bool   `json:"matched"`
+	// UndeterminedConditions are conditions which can not be evaluated against the labels.
+	UndeterminedConditions []string `json:"undetermined_conditions,omitempty"`
+}
+
+// ResourcePoolMatch is the resource pool a label set falls into.
+type ResourcePoolMatch struct {
+	Name          string `json:"name"`
+	Default       bool   `json:"default"`
+	MatchedFilter string `json:"matched_filter,omitempty"`
+	// PercentOfLicense is the share of the license allocated to the pool, if set.
+	PercentOfLicense float64 `json:"percent_of_license,omitempty"`
+	// Priority is high, low or default.
+	Priority              string `json:"priority"`
+	MatchedPriorityFilter string `json:"matched_priority_filter,omitempty"`
+}
+
+func (t *Tools) resolveDatasetAndPool(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	service, err := params.String(request, "service", false, "")
+	if err != nil {
+		return nil, err
+	}
+	labels, err := params.Object[map[string]string](request, "labels", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if _, ok := labels["service"]; !ok && service != "" {
+		labels["service"] = service
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("either service or labels must be set")
+	}
+
+	datasets, err := configlist.Datasets(ctx, t.client, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	result := &DatasetResolution{Labels: labels}
+	result.Datasets, result.Warnings = matchDatasets(datasets, labels)
+
+	var pools *models.Configv1ResourcePools
+	resp, err := t.client.ResourcePools.ReadResourcePools(&resource_pools.ReadResourcePoolsParams{
+		Context: ctx,
+	})
+	var notFound *resource_pools.ReadResourcePoolsNotFound
+	switch {
+	case errors.As(err, &notFound):
+		// No resource pools are configured.
+	case err != nil:
+		return nil, fmt.Errorf("failed to read resource pools: %s", err)
+	default:
+		pools = resp.Payload.ResourcePools
+	}
+	if pools == nil {
+		result.Warnings = append(result.Warnings, "no resource pools are configured")
+	} else {
+		pool, err := matchResourcePool(pools, labels)
+		if err != nil {
+			return nil, err
+		}
+		result.ResourcePool = pool
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// matchDatasets returns the datasets whose match criteria match, or may match, the labels.
+// Trace datasets are evaluated as a root span of the service label with the labels as
+// attributes.
+func matchDatasets(datasets []*models.Configv1Dataset, labels map[string]string) ([]*DatasetMatch, []string) {
+	matches := []*DatasetMatch{}
+	var warnings []string
+	for _, d := range datasets {
+		if d == nil || d.Configuration == nil {
+			continue
+		}
+		var unmatched, undetermined []string
+		switch {
+		case d.Configuration.TraceDataset != nil:
+			result := tracefilter.Evaluate(d.Configuration.TraceDataset.MatchCriteria, &tracefilter.Span{
+				Service:    labels["service"],
+				Attributes: labels,
+				IsRootSpan: true,
+			})
+			unmatched, undetermined = result.Unmatched, result.Undetermined
+		case d.Configuration.LogDataset != nil && d.Configuration.LogDataset.MatchCriteria != nil:
+			unmatched, undetermined = evaluateLogQuery(d.Configuration.LogDataset.MatchCriteria.Query, labels)
+		default:
+			warnings = append(warnings, fmt.Sprintf("dataset %s has no match criteria", d.Slug))
+			continue
+		}
+		if len(unmatched) > 0 {
+			continue
+		}
+		matches = append(matches, &DatasetMatch{
+			Slug:                   d.Slug,
+			Name:                   d.Name,
+			Type:                   string(d.Configuration.Type),
+			Matched:                len(undetermined) == 0,
+			UndeterminedConditions: undetermined,
+		})
+	}
+	return matches, warnings
+}
+
+// evaluateLogQuery evaluates a log query made of `field = "value"` and `field != "value"`
+// clauses joined by AND against the labels. Queries of any other form are undetermined, unless
+// a clause before the part that can not be evaluated is already unmatched.
+func evaluateLogQuery(query string, labels map[string]string) (unmatched, undetermined []string) {
+	rest := strings.TrimSpace(query)
+	if rest == "" {
+		return nil, nil
+	}
+	undeterminable := []string{fmt.Sprintf("%s: can not be evaluated locally", query)}
+	for rest != "" {
+		m := logClausePattern.FindStringSubmatch(rest)
+		if m == nil {
+			return unmatched, undeterminable
+		}
+		value, err := strconv.Unquote(m[3])
+		if err != nil {
+			return unmatched, undeterminable
+		}
+		v, ok := labels[m[1]]
+		if (m[2] == "=") != (ok && v == value) {
+			unmatched = append(unmatched, fmt.Sprintf("%s %s %s", m[1], m[2], m[3]))
+		}
+		rest = rest[len(m[0]):]
+	}
+	return unmatched, nil
+}
+
+// matchResourcePool returns the first resource pool with a filter matching the labels, or
+// else the default pool, along with the priority of the labels within the pool.
+func matchResourcePool(pools *models.Configv1ResourcePools, labels map[string]string) (*ResourcePoolMatch, error) {
+	for _, pool := range pools.Pools {
+		if pool == nil {
+			continue
+		}
+		fs, err := filters.FromModels(pool.Filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filters of resource pool %s: %s", pool.Name, err)
+		}
+		for _, f := range fs {
+			if !f.Matches(labels) {
+				continue
+			}
+			match := &ResourcePoolMatch{Name: pool.Name, MatchedFilter: f.String()}
+			if pool.Allocation != nil {
+				match.PercentOfLicense = pool.Allocation.PercentOfLicense
+			}
+			if err := matchPriority(match, pool.Priorities, labels); err != nil {
+				return nil, err
+			}
+			return match, nil
+		}
+	}
+
+	match := &ResourcePoolMatch{Name: "default", Default: true}
+	if d := pools.DefaultPool; d != nil {
+		if d.Allocation != nil {
+			match.PercentOfLicense = d.Allocation.PercentOfLicense
+		}
+		if err := matchPriority(match, d.Priorities, labels); err != nil {
+			return nil, err
+		}
+	} else {
+		match.Priority = priorityDefault
+	}
+	return match, nil
+}
+
+// matchPriority sets the priority of the labels within a pool. Series matching any high
+// priority filter are high priority, else series matching any low priority filter are low.
+func matchPriority(match *ResourcePoolMatch, priorities *models.ResourcePoolsPriorities, labels map[string]string) error {
+	match.Priority = priorityDefault
+	if priorities == nil {
+		return nil
+	}
+	for _, p := range []struct {
+		priority string
+		filters  []*models.Configv1LabelFilter
+	}{
+		{priorityHigh, priorities.HighPriorityFilters},
+		{priorityLow, priorities.LowPriorityFilters},
+	} {
+		fs, err := filters.FromModels(p.filters)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s priority filters of resource pool %s: %s", p.priority, match.Name, err)
+		}
+		for _, f := range fs {
+			if f.Matches(labels) {
+				match.Priority = p.priority
+				match.MatchedPriorityFilter = f.String()
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestEvaluateLogQuery(t *testing.T) {
+	labels := map[string]string{"service": "payments", "env": "prod"}
+	tests := []struct {
+		name                 string
+		query                string
+		expectedUnmatched    []string
+		expectedUndetermined []string
+	}{
+		{name: "empty"},
+		{name: "equal", query: `service = "payments"`},
+		{name: "and", query: `service = "payments" AND env != "dev"`},
+		{name: "lowercase and", query: `service="payments" and env="prod"`},
+		{
+			name:              "unmatched",
+			query:             `service = "payments" AND env = "dev"`,
+			expectedUnmatched: []string{`env = "dev"`},
+		},
+		{
+			name:              "missing label",
+			query:             `region = "us-east1" AND cluster != "test"`,
+			expectedUnmatched: []string{`region = "us-east1"`},
+		},
+		{
+			name:                 "unsupported",
+			query:                `service = "payments" OR env = "dev"`,
+			expectedUndetermined: []string{`service = "payments" OR env = "dev": can not be evaluated locally`},
+		},
+		{
+			name:                 "unmatched before unsupported",
+			query:                `env = "dev" AND message contains "error"`,
+			expectedUnmatched:    []string{`env = "dev"`},
+			expectedUndetermined: []string{`env = "dev" AND message contains "error": can not be evaluated locally`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unmatched, undetermined := evaluateLogQuery(tt.query, labels)
+			require.Equal(t, tt.expectedUnmatched, unmatched)
+			require.Equal(t, tt.expectedUndetermined, undetermined)
+		})
+	}
+}
+
+func TestMatchDatasets(t *testing.T) {
+	datasets := []*models.Configv1Dataset{
+		{
+			Slug: "payments-logs",
+			Configuration: &models.DatasetDatasetConfiguration{
+				Type:       models.DatasetDatasetTypeLOGS,
+				LogDataset: &models.Configv1LogDataset{MatchCriteria: &models.Configv1LogSearchFilter{Query: `service = "payments"`}},
+			},
+		},
+		{
+			Slug: "checkout-logs",
+			Configuration: &models.DatasetDatasetConfiguration{
+				Type:       models.DatasetDatasetTypeLOGS,
+				LogDataset: &models.Configv1LogDataset{MatchCriteria: &models.Configv1LogSearchFilter{Query: `service = "checkout"`}},
+			},
+		},
+		{
+			Slug: "payments-traces",
+			Name: "Payments traces",
+			Configuration: &models.DatasetDatasetConfiguration{
+				Type: models.DatasetDatasetTypeTRACES,
+				TraceDataset: &models.Configv1TraceDataset{MatchCriteria: &models.Configv1TraceSearchFilter{
+					Span: []*models.TraceSearchFilterSpanFilter{{
+						Service: &models.TraceSearchFilterStringFilter{Match: models.StringFilterStringFilterMatchTypeEXACT, Value: "payments"},
+					}},
+				}},
+			},
+		},
+		{Slug: "empty", Configuration: &models.DatasetDatasetConfiguration{Type: models.DatasetDatasetTypeLOGS}},
+	}
+
+	// The service is only given as a label, as when the service parameter is not set.
+	matches, warnings := matchDatasets(datasets, map[string]string{"service": "payments"})
+	require.Equal(t, []*DatasetMatch{
+		{Slug: "payments-logs", Type: "LOGS", Matched: true},
+		{Slug: "payments-traces", Name: "Payments traces", Type: "TRACES", Matched: true},
+	}, matches)
+	require.Equal(t, []string{"dataset empty has no match criteria"}, warnings)
+
+	matches, _ = matchDatasets(datasets, map[string]string{"service": "checkout"})
+	require.Equal(t, []*DatasetMatch{{Slug: "checkout-logs", Type: "LOGS", Matched: true}}, matches)
+}
+
+func TestMatchResourcePool(t *testing.T) {
+	pools := &models.Configv1ResourcePools{
+		DefaultPool: &models.ResourcePoolsDefaultPool{
+			Allocation: &models.Configv1ResourcePoolsAllocation{PercentOfLicense: 40},
+		},
+		Pools: []*models.ResourcePoolsPool{
+			{
+				Name:       "payments",
+				Filters:    []*models.Configv1LabelFilter{{Name: "team", ValueGlob: "billing"}, {Name: "service", ValueGlob: "pay*"}},
+				Allocation: &models.Configv1ResourcePoolsAllocation{PercentOfLicense: 60},
+				Priorities: &models.ResourcePoolsPriorities{
+					HighPriorityFilters: []*models.Configv1LabelFilter{{Name: "env", ValueGlob: "prod"}},
+					LowPriorityFilters:  []*models.Configv1LabelFilter{{Name: "env", ValueGlob: "*"}},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected *ResourcePoolMatch
+	}{
+		{
+			name:   "high priority",
+			labels: map[string]string{"service": "payments", "env": "prod"},
+			expected: &ResourcePoolMatch{
+				Name:                  "payments",
+				MatchedFilter:         "service:pay*",
+				PercentOfLicense:      60,
+				Priority:              "high",
+				MatchedPriorityFilter: "env:prod",
+			},
+		},
+		{
+			name:   "low priority",
+			labels: map[string]string{"team": "billing", "env": "dev"},
+			expected: &ResourcePoolMatch{
+				Name:                  "payments",
+				MatchedFilter:         "team:billing",
+				PercentOfLicense:      60,
+				Priority:              "low",
+				MatchedPriorityFilter: "env:*",
+			},
+		},
+		{
+			name:     "default pool",
+			labels:   map[string]string{"service": "checkout"},
+			expected: &ResourcePoolMatch{Name: "default", Default: true, PercentOfLicense: 40, Priority: "default"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := matchResourcePool(pools, tt.labels)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, match)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/grafana_dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// GrafanaDashboardSummary is a Grafana dashboard with its panels and queries instead of its
+// raw dashboard JSON.
+type GrafanaDashboardSummary struct {
+	Slug           string                    `json:"slug"`
+	Name           string                    `json:"name,omitempty"`
+	CollectionSlug string                    `json:"collection_slug,omitempty"`
+	BucketSlug     string                    `json:"bucket_slug,omitempty"`
+	Description    string                    `json:"description,omitempty"`
+	Variables      []*GrafanaVariableSummary `json:"variables,omitempty"`
+	PanelCount     int                       `json:"panel_count"`
+	QueryCount     int                       `json:"query_count"`
+	Panels         []*GrafanaPanelSummary    `json:"panels,omitempty"`
+	Error          string                    `json:"error,omitempty"`
+}
+
+// GrafanaVariableSummary is a dashboard variable.
+type GrafanaVariableSummary struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Query string `json:"query,omitempty"`
+}
+
+// GrafanaPanelSummary is a panel with its PromQL queries.
+type GrafanaPanelSummary struct {
+	Group   string   `json:"group,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Type    string   `json:"type"`
+	Unit    string   `json:"unit,omitempty"`
+	Queries []string `json:"queries,omitempty"`
+}
+
+// GrafanaDashboardList is a page of Grafana dashboards.
+type GrafanaDashboardList struct {
+	GrafanaDashboards []*GrafanaDashboardSummary `json:"grafana_dashboards"`
+	NextToken         string                     `json:"next_token,omitempty"`
+}
+
+func (t *Tools) getGrafanaDashboard(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.GrafanaDashboard.ReadGrafanaDashboard(&grafana_dashboard.ReadGrafanaDashboardParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ReadGrafanaDashboard: %s", err)
+	}
+	if resp.Payload.GrafanaDashboard == nil {
+		return nil, fmt.Errorf("grafana dashboard %q not found", slug)
+	}
+	return &tools.Result{JSONContent: summarizeGrafanaDashboard(resp.Payload.GrafanaDashboard, true)}, nil
+}
+
+func (t *Tools) listGrafanaDashboards(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slugs, err := params.StringArray(request, "slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	names, err := params.StringArray(request, "names", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	collectionSlugs, err := params.StringArray(request, "collection_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	bucketSlugs, err := params.StringArray(request, "bucket_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	includePanels, err := params.Bool(request, "include_panels", false, false)
+	if err != nil {
+		return nil, err
+	}
+	pageMaxSize, err := params.Int(request, "page_max_size", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	pageToken, err := params.String(request, "page_token", false, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.GrafanaDashboard.ListGrafanaDashboards(&grafana_dashboard.ListGrafanaDashboardsParams{
+		Context:              ctx,
+		Slugs:                slugs,
+		Names:                names,
+		CollectionSlugs:      collectionSlugs,
+		BucketSlugs:          bucketSlugs,
+		IncludeDashboardJSON: ptr.To(true),
+		PageMaxSize:          ptr.To(int64(pageMaxSize)),
+		PageToken:            &pageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListGrafanaDashboards: %s", err)
+	}
+
+	result := &GrafanaDashboardList{GrafanaDashboards: make([]*GrafanaDashboardSummary, 0, len(resp.Payload.GrafanaDashboards))}
+	for _, d := range resp.Payload.GrafanaDashboards {
+		result.GrafanaDashboards = append(result.GrafanaDashboards, summarizeGrafanaDashboard(d, includePanels))
+	}
+	if resp.Payload.Page != nil {
+		result.NextToken = resp.Payload.Page.NextToken
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// summarizeGrafanaDashboard returns the variables, panels and PromQL queries of the dashboard.
+// Panels and variables are only included if includePanels is set. Dashboards whose JSON can
+// not be parsed are returned with the error.
+func summarizeGrafanaDashboard(d *models.Configv1GrafanaDashboard, includePanels bool) *GrafanaDashboardSummary {
+	s := &GrafanaDashboardSummary{
+		Slug:           d.Slug,
+		Name:           d.Name,
+		CollectionSlug: d.CollectionSlug,
+		BucketSlug:     d.BucketSlug,
+	}
+	if d.Collection != nil && d.Collection.Slug != "" {
+		s.CollectionSlug = d.Collection.Slug
+	}
+	if d.DashboardJSON == "" {
+		return s
+	}
+	g, err := dashboards.ParseGrafana(d.DashboardJSON)
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.Description = g.Description
+	for _, group := range g.Groups() {
+		for _, p := range group.Panels {
+			queries := p.PromQL()
+			s.PanelCount++
+			s.QueryCount += len(queries)
+			if includePanels {
+				s.Panels = append(s.Panels, &GrafanaPanelSummary{
+					Group:   group.Title,
+					Title:   p.Title,
+					Type:    p.Type,
+					Unit:    p.Unit(),
+					Queries: queries,
+				})
+			}
+		}
+	}
+	if includePanels {
+		for _, v := range g.Templating.List {
+			if v == nil {
+				continue
+			}
+			s.Variables = append(s.Variables, &GrafanaVariableSummary{Name: v.Name, Type: v.Type, Query: v.QueryString()})
+		}
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestSummarizeGrafanaDashboard(t *testing.T) {
+	d := &models.Configv1GrafanaDashboard{
+		Slug:       "gateway",
+		Name:       "Gateway",
+		Collection: &models.Configv1CollectionReference{Slug: "platform"},
+		DashboardJSON: `{
+			"title": "Gateway",
+			"templating": {"list": [{"name": "env", "type": "query", "query": "label_values(env)"}]},
+			"panels": [
+				{"type": "stat", "title": "Uptime", "fieldConfig": {"defaults": {"unit": "s"}},
+				 "targets": [{"expr": "time() - process_start_time_seconds"}]},
+				{"type": "row", "title": "Traffic", "collapsed": true, "panels": [
+					{"type": "graph", "title": "Requests", "yaxes": [{"format": "reqps"}],
+					 "targets": [{"expr": "sum(rate(http_requests_total{env=\"$env\"}[5m]))"}, {"expr": "up", "hide": true}]}
+				]},
+				{"type": "text", "title": "Notes", "options": {"content": "Owned by platform"}}
+			]
+		}`,
+	}
+
+	require.Equal(t, &GrafanaDashboardSummary{
+		Slug:           "gateway",
+		Name:           "Gateway",
+		CollectionSlug: "platform",
+		Variables:      []*GrafanaVariableSummary{{Name: "env", Type: "query", Query: "label_values(env)"}},
+		PanelCount:     3,
+		QueryCount:     2,
+		Panels: []*GrafanaPanelSummary{
+			{Title: "Uptime", Type: "stat", Unit: "s", Queries: []string{"time() - process_start_time_seconds"}},
+			{Group: "Traffic", Title: "Requests", Type: "graph", Unit: "reqps", Queries: []string{`sum(rate(http_requests_total{env="$env"}[5m]))`}},
+			{Group: "Traffic", Title: "Notes", Type: "text"},
+		},
+	}, summarizeGrafanaDashboard(d, true))
+
+	require.Equal(t, &GrafanaDashboardSummary{
+		Slug:           "gateway",
+		Name:           "Gateway",
+		CollectionSlug: "platform",
+		PanelCount:     3,
+		QueryCount:     2,
+	}, summarizeGrafanaDashboard(d, false))
+
+	d.DashboardJSON = "{"
+	require.NotEmpty(t, summarizeGrafanaDashboard(d, true).Error)
+}
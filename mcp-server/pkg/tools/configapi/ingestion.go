@@ -0,0 +1,268 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/otel_metrics_ingestion"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+// Whether a resource attribute is copied to a label.
+const (
+	attributeCopied       = "copied"
+	attributeExcluded     = "excluded"
+	attributeIgnored      = "ignored"
+	attributeUndetermined = "undetermined"
+)
+
+// gcpMetricNamePrefix starts the Prometheus-style names of metrics ingested by GCP metrics
+// integrations, which are stackdriver_<monitored resource type>_<metric type>, e.g.
+// stackdriver_gce_instance_compute_googleapis_com_instance_cpu_utilization.
+const gcpMetricNamePrefix = "stackdriver_"
+
+// invalidLabelChars are the characters which are not valid in a Prometheus label or metric name.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// IngestionCheck is the metrics ingestion config and whether a metric or resource attributes
+// would be ingested under it.
+type IngestionCheck struct {
+	OtelMetricsIngestion *OtelIngestionSummary `json:"otel_metrics_ingestion"`
+	// GcpMetricPrefixes are the GCP metric prefixes enabled across all GCP metrics integrations.
+	GcpMetricPrefixes  []*GcpMetricPrefix            `json:"gcp_metric_prefixes"`
+	Metric             *MetricIngestion              `json:"metric,omitempty"`
+	ResourceAttributes []*ResourceAttributeIngestion `json:"resource_attributes,omitempty"`
+	Warnings           []string                      `json:"warnings,omitempty"`
+}
+
+// OtelIngestionSummary is how OpenTelemetry resource attributes are mapped to labels.
+type OtelIngestionSummary struct {
+	// Configured is false when no OTel metrics ingestion config exists and the defaults apply.
+	Configured         bool     `json:"configured"`
+	FlattenMode        string   `json:"flatten_mode"`
+	FilterMode         string   `json:"filter_mode"`
+	ExcludeKeys        []string `json:"exclude_keys,omitempty"`
+	GenerateTargetInfo bool     `json:"generate_target_info"`
+}
+
+// GcpMetricPrefix is a GCP metric prefix ingested for a project.
+type GcpMetricPrefix struct {
+	IntegrationSlug string `json:"integration_slug"`
+	IntegrationName string `json:"integration_name,omitempty"`
+	ProjectID       string `json:"project_id,omitempty"`
+	Prefix          string `json:"prefix"`
+}
+
+// MetricIngestion describes whether a metric is ingested.
+type MetricIngestion struct {
+	Name string `json:"name"`
+	// GcpPrefixMatches are the enabled GCP metric prefixes matching the metric.
+	GcpPrefixMatches []*GcpMetricPrefix `json:"gcp_prefix_matches,omitempty"`
+	Explanation      string             `json:"explanation"`
+}
+
+// ResourceAttributeIngestion describes whether an OTel resource attribute is copied to a label.
+type ResourceAttributeIngestion struct {
+	Key string `json:"key"`
+	// Label is the label the attribute is copied to.
+	Label string `json:"label"`
+	// Status is copied, excluded, ignored or undetermined.
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+func (t *Tools) checkIngestionConfig(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	metricName, err := params.String(request, "metric_name", false, "")
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := params.StringArray(request, "resource_attributes", false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var otel *models.Configv1OtelMetricsIngestion
+	resp, err := t.client.OtelMetricsIngestion.ReadOtelMetricsIngestion(&otel_metrics_ingestion.ReadOtelMetricsIngestionParams{
+		Context: ctx,
+	})
+	var notFound *otel_metrics_ingestion.ReadOtelMetricsIngestionNotFound
+	switch {
+	case errors.As(err, &notFound):
+		// No OTel metrics ingestion config exists, so the defaults apply.
+	case err != nil:
+		return nil, fmt.Errorf("failed to read otel metrics ingestion: %s", err)
+	default:
+		otel = resp.Payload.OtelMetricsIngestion
+	}
+	integrations, err := configlist.GcpMetricsIntegrations(ctx, t.client, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{JSONContent: checkIngestion(otel, integrations, metricName, attributes)}, nil
+}
+
+// checkIngestion summarizes the ingestion config and checks the metric and resource
+// attributes against it.
+func checkIngestion(
+	otel *models.Configv1OtelMetricsIngestion,
+	integrations []*models.Configv1GcpMetricsIntegration,
+	metricName string,
+	attributes []string,
+) *IngestionCheck {
+	check := &IngestionCheck{
+		OtelMetricsIngestion: summarizeOtelIngestion(otel),
+		GcpMetricPrefixes:    gcpMetricPrefixes(integrations),
+	}
+	if !check.OtelMetricsIngestion.Configured {
+		check.Warnings = append(check.Warnings, "no OTel metrics ingestion config exists, so the default flatten and filter modes apply")
+	}
+	if metricName != "" {
+		check.Metric = checkMetric(metricName, check.GcpMetricPrefixes)
+	}
+	for _, key := range attributes {
+		check.ResourceAttributes = append(check.ResourceAttributes, checkResourceAttribute(check.OtelMetricsIngestion, key))
+	}
+	return check
+}
+
+// summarizeOtelIngestion returns the OTel ingestion config with unset modes replaced by their
+// defaults, MERGE and APPEND_DEFAULT_EXCLUDE_KEYS.
+func summarizeOtelIngestion(otel *models.Configv1OtelMetricsIngestion) *OtelIngestionSummary {
+	summary := &OtelIngestionSummary{
+		FlattenMode: string(models.ResourceAttributesFlattenModeMERGE),
+		FilterMode:  string(models.ResourceAttributesFilterModeAPPENDDEFAULTEXCLUDEKEYS),
+	}
+	if otel == nil {
+		return summary
+	}
+	summary.Configured = true
+	if ra := otel.ResourceAttributes; ra != nil {
+		if ra.FlattenMode != "" {
+			summary.FlattenMode = string(ra.FlattenMode)
+		}
+		if ra.FilterMode != "" {
+			summary.FilterMode = string(ra.FilterMode)
+		}
+		summary.ExcludeKeys = ra.ExcludeKeys
+		summary.GenerateTargetInfo = ra.GenerateTargetInfo
+	}
+	return summary
+}
+
+func gcpMetricPrefixes(integrations []*models.Configv1GcpMetricsIntegration) []*GcpMetricPrefix {
+	prefixes := []*GcpMetricPrefix{}
+	for _, integration := range integrations {
+		if integration == nil {
+			continue
+		}
+		for _, group := range integration.MetricGroups {
+			if group == nil {
+				continue
+			}
+			for _, prefix := range group.Prefixes {
+				prefixes = append(prefixes, &GcpMetricPrefix{
+					IntegrationSlug: integration.Slug,
+					IntegrationName: integration.Name,
+					ProjectID:       group.ProjectID,
+					Prefix:          prefix,
+				})
+			}
+		}
+	}
+	return prefixes
+}
+
+// checkMetric matches the metric against the GCP metric prefixes. The metric may be a GCP
+// metric type, e.g. compute.googleapis.com/instance/cpu/utilization, or its Prometheus-style
+// name in which the metric type follows the monitored resource type, with invalid characters
+// replaced by underscores.
+func checkMetric(name string, prefixes []*GcpMetricPrefix) *MetricIngestion {
+	m := &MetricIngestion{Name: name}
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p.Prefix) || matchesPrometheusName(name, p.Prefix) {
+			m.GcpPrefixMatches = append(m.GcpPrefixMatches, p)
+		}
+	}
+	switch {
+	case len(m.GcpPrefixMatches) > 0:
+		m.Explanation = "the metric matches an enabled GCP metric prefix, so it is ingested by the GCP metrics integration"
+	case strings.Contains(name, ".googleapis.com/"):
+		m.Explanation = "the metric is a GCP metric but no enabled GCP metric prefix matches it, so it is not ingested"
+	default:
+		m.Explanation = "no enabled GCP metric prefix matches the metric. OTel metrics ingestion does not filter by metric name, " +
+			"so metrics sent over OTLP are ingested unless a drop rule drops them, which explain_series_rules checks"
+	}
+	return m
+}
+
+// matchesPrometheusName returns whether the Prometheus-style name of a GCP metric has a metric
+// type starting with the prefix. Monitored resource types may contain underscores, so the
+// metric type may start after any underscore following the resource type's first word.
+func matchesPrometheusName(name, prefix string) bool {
+	rest, ok := strings.CutPrefix(name, gcpMetricNamePrefix)
+	if !ok {
+		return false
+	}
+	want := labelName(prefix)
+	for i := 1; i < len(rest); i++ {
+		if rest[i] == '_' && strings.HasPrefix(rest[i+1:], want) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResourceAttribute returns whether the resource attribute is copied to a label.
+func checkResourceAttribute(otel *OtelIngestionSummary, key string) *ResourceAttributeIngestion {
+	a := &ResourceAttributeIngestion{Key: key, Label: labelName(key)}
+	switch {
+	case otel.FlattenMode == string(models.ResourceAttributesFlattenModeIGNORE):
+		a.Status = attributeIgnored
+		a.Reason = "flatten_mode is IGNORE, so resource attributes are not copied to labels"
+		if otel.GenerateTargetInfo && !slices.Contains(otel.ExcludeKeys, key) {
+			a.Reason += ", but the attribute is a label of the target_info series"
+		}
+	case slices.Contains(otel.ExcludeKeys, key):
+		a.Status = attributeExcluded
+		a.Reason = "the attribute is in exclude_keys"
+	case otel.FilterMode == string(models.ResourceAttributesFilterModeCUSTOMEXCLUDEKEYS):
+		a.Status = attributeCopied
+		a.Reason = fmt.Sprintf("filter_mode is CUSTOM_EXCLUDE_KEYS and the attribute is not in exclude_keys, so it is copied with flatten_mode %s", otel.FlattenMode)
+	default:
+		a.Status = attributeUndetermined
+		a.Reason = fmt.Sprintf("filter_mode is APPEND_DEFAULT_EXCLUDE_KEYS, so the attribute is copied with flatten_mode %s unless it is one of the default exclude keys", otel.FlattenMode)
+	}
+	return a
+}
+
+// labelName returns the name with characters invalid in a label replaced by underscores.
+func labelName(name string) string {
+	label := invalidLabelChars.ReplaceAllString(name, "_")
+	if label != "" && label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+	return label
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestCheckIngestion(t *testing.T) {
+	integrations := []*models.Configv1GcpMetricsIntegration{
+		{
+			Slug: "prod",
+			MetricGroups: []*models.GcpMetricsIntegrationMetricGroup{
+				{ProjectID: "prod-project", Prefixes: []string{"compute.googleapis.com/instance/cpu"}},
+			},
+		},
+	}
+	computePrefix := &GcpMetricPrefix{IntegrationSlug: "prod", ProjectID: "prod-project", Prefix: "compute.googleapis.com/instance/cpu"}
+
+	tests := []struct {
+		name               string
+		otel               *models.Configv1OtelMetricsIngestion
+		metricName         string
+		attributes         []string
+		expectedMetric     *MetricIngestion
+		expectedAttributes []*ResourceAttributeIngestion
+		expectedWarnings   []string
+	}{
+		{
+			name:       "gcp metric type",
+			otel:       &models.Configv1OtelMetricsIngestion{},
+			metricName: "compute.googleapis.com/instance/cpu/utilization",
+			expectedMetric: &MetricIngestion{
+				Name:             "compute.googleapis.com/instance/cpu/utilization",
+				GcpPrefixMatches: []*GcpMetricPrefix{computePrefix},
+				Explanation:      "the metric matches an enabled GCP metric prefix, so it is ingested by the GCP metrics integration",
+			},
+		},
+		{
+			name:       "prometheus style gcp metric",
+			otel:       &models.Configv1OtelMetricsIngestion{},
+			metricName: "stackdriver_gce_instance_compute_googleapis_com_instance_cpu_utilization",
+			expectedMetric: &MetricIngestion{
+				Name:             "stackdriver_gce_instance_compute_googleapis_com_instance_cpu_utilization",
+				GcpPrefixMatches: []*GcpMetricPrefix{computePrefix},
+				Explanation:      "the metric matches an enabled GCP metric prefix, so it is ingested by the GCP metrics integration",
+			},
+		},
+		{
+			name:       "prometheus style metric containing a prefix",
+			otel:       &models.Configv1OtelMetricsIngestion{},
+			metricName: "myapp_compute_googleapis_com_instance_cpu_utilization",
+			expectedMetric: &MetricIngestion{
+				Name: "myapp_compute_googleapis_com_instance_cpu_utilization",
+				Explanation: "no enabled GCP metric prefix matches the metric. OTel metrics ingestion does not filter by metric name, " +
+					"so metrics sent over OTLP are ingested unless a drop rule drops them, which explain_series_rules checks",
+			},
+		},
+		{
+			name:       "prometheus style gcp metric of another service",
+			otel:       &models.Configv1OtelMetricsIngestion{},
+			metricName: "stackdriver_gce_instance_xcompute_googleapis_com_instance_cpu_utilization",
+			expectedMetric: &MetricIngestion{
+				Name: "stackdriver_gce_instance_xcompute_googleapis_com_instance_cpu_utilization",
+				Explanation: "no enabled GCP metric prefix matches the metric. OTel metrics ingestion does not filter by metric name, " +
+					"so metrics sent over OTLP are ingested unless a drop rule drops them, which explain_series_rules checks",
+			},
+		},
+		{
+			name:       "gcp metric not enabled",
+			otel:       &models.Configv1OtelMetricsIngestion{},
+			metricName: "storage.googleapis.com/api/request_count",
+			expectedMetric: &MetricIngestion{
+				Name:        "storage.googleapis.com/api/request_count",
+				Explanation: "the metric is a GCP metric but no enabled GCP metric prefix matches it, so it is not ingested",
+			},
+		},
+		{
+			name: "custom exclude keys",
+			otel: &models.Configv1OtelMetricsIngestion{ResourceAttributes: &models.OtelMetricsIngestionResourceAttributes{
+				FilterMode:  models.ResourceAttributesFilterModeCUSTOMEXCLUDEKEYS,
+				FlattenMode: models.ResourceAttributesFlattenModeOVERWRITE,
+				ExcludeKeys: []string{"host.id"},
+			}},
+			attributes: []string{"k8s.namespace.name", "host.id"},
+			expectedAttributes: []*ResourceAttributeIngestion{
+				{
+					Key:    "k8s.namespace.name",
+					Label:  "k8s_namespace_name",
+					Status: attributeCopied,
+					Reason: "filter_mode is CUSTOM_EXCLUDE_KEYS and the attribute is not in exclude_keys, so it is copied with flatten_mode OVERWRITE",
+				},
+				{Key: "host.id", Label: "host_id", Status: attributeExcluded, Reason: "the attribute is in exclude_keys"},
+			},
+		},
+		{
+			name: "ignored",
+			otel: &models.Configv1OtelMetricsIngestion{ResourceAttributes: &models.OtelMetricsIngestionResourceAttributes{
+				FlattenMode:        models.ResourceAttributesFlattenModeIGNORE,
+				GenerateTargetInfo: true,
+			}},
+			attributes: []string{"service.version"},
+			expectedAttributes: []*ResourceAttributeIngestion{{
+				Key:    "service.version",
+				Label:  "service_version",
+				Status: attributeIgnored,
+				Reason: "flatten_mode is IGNORE, so resource attributes are not copied to labels, but the attribute is a label of the target_info series",
+			}},
+		},
+		{
+			name:       "not configured",
+			attributes: []string{"cloud.region"},
+			expectedAttributes: []*ResourceAttributeIngestion{{
+				Key:    "cloud.region",
+				Label:  "cloud_region",
+				Status: attributeUndetermined,
+				Reason: "filter_mode is APPEND_DEFAULT_EXCLUDE_KEYS, so the attribute is copied with flatten_mode MERGE unless it is one of the default exclude keys",
+			}},
+			expectedWarnings: []string{"no OTel metrics ingestion config exists, so the default flatten and filter modes apply"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkIngestion(tt.otel, integrations, tt.metricName, tt.attributes)
+			require.Equal(t, []*GcpMetricPrefix{computePrefix}, check.GcpMetricPrefixes)
+			require.Equal(t, tt.expectedMetric, check.Metric)
+			require.Equal(t, tt.expectedAttributes, check.ResourceAttributes)
+			require.Equal(t, tt.expectedWarnings, check.Warnings)
+		})
+	}
+}
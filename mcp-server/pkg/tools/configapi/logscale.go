@@ -0,0 +1,143 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_scale_action"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/redact"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// logScaleActionFields are the fields holding the configuration of each action type.
+var logScaleActionFields = map[models.LogScaleActionActionType]string{
+	models.LogScaleActionActionTypeEMAIL:            "email_action",
+	models.LogScaleActionActionTypeHUMIOREPO:        "humio_action",
+	models.LogScaleActionActionTypeOPSGENIE:         "ops_genie_action",
+	models.LogScaleActionActionTypePAGERDUTY:        "pager_duty_action",
+	models.LogScaleActionActionTypeSLACK:            "slack_action",
+	models.LogScaleActionActionTypeSLACKPOSTMESSAGE: "slack_post_message_action",
+	models.LogScaleActionActionTypeVICTOROPS:        "victor_ops_action",
+	models.LogScaleActionActionTypeWEBHOOK:          "webhook_action",
+	models.LogScaleActionActionTypeUPLOADFILE:       "upload_file_action",
+}
+
+// LogScaleAction is a LogScale action with its credentials redacted.
+type LogScaleAction struct {
+	Slug       string `json:"slug"`
+	Name       string `json:"name,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	// Type is the action type, e.g. SLACK.
+	Type string `json:"type"`
+	// Config is the configuration of the action type with credentials redacted.
+	Config         any      `json:"config,omitempty"`
+	RedactedFields []string `json:"redacted_fields,omitempty"`
+}
+
+// LogScaleActionList is a page of LogScale actions.
+type LogScaleActionList struct {
+	LogScaleActions []*LogScaleAction `json:"log_scale_actions"`
+	NextToken       string            `json:"next_token,omitempty"`
+}
+
+func (t *Tools) getLogScaleAction(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.LogScaleAction.ReadLogScaleAction(&log_scale_action.ReadLogScaleActionParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ReadLogScaleAction: %s", err)
+	}
+	if resp.Payload.LogScaleAction == nil {
+		return nil, fmt.Errorf("log scale action %q not found", slug)
+	}
+	a, err := newLogScaleAction(resp.Payload.LogScaleAction)
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{JSONContent: a}, nil
+}
+
+func (t *Tools) listLogScaleActions(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slugs, err := params.StringArray(request, "slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	names, err := params.StringArray(request, "names", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	pageMaxSize, err := params.Int(request, "page_max_size", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	pageToken, err := params.String(request, "page_token", false, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.LogScaleAction.ListLogScaleActions(&log_scale_action.ListLogScaleActionsParams{
+		Context:     ctx,
+		Slugs:       slugs,
+		Names:       names,
+		PageMaxSize: ptr.To(int64(pageMaxSize)),
+		PageToken:   &pageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListLogScaleActions: %s", err)
+	}
+
+	result := &LogScaleActionList{LogScaleActions: make([]*LogScaleAction, 0, len(resp.Payload.LogScaleActions))}
+	for _, m := range resp.Payload.LogScaleActions {
+		a, err := newLogScaleAction(m)
+		if err != nil {
+			return nil, err
+		}
+		result.LogScaleActions = append(result.LogScaleActions, a)
+	}
+	if resp.Payload.Page != nil {
+		result.NextToken = resp.Payload.Page.NextToken
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// newLogScaleAction returns the LogScale action with its credentials redacted.
+func newLogScaleAction(m *models.Configv1LogScaleAction) (*LogScaleAction, error) {
+	redacted, redactedFields, err := redact.LogScaleActions.Redact(m)
+	if err != nil {
+		return nil, err
+	}
+	a := &LogScaleAction{
+		Slug:           m.Slug,
+		Name:           m.Name,
+		Repository:     m.Repository,
+		Type:           string(m.ActionType),
+		RedactedFields: redactedFields,
+	}
+	if fields, ok := redacted.(map[string]any); ok {
+		a.Config = fields[logScaleActionFields[m.ActionType]]
+	}
+	return a, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestNewLogScaleAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   *models.Configv1LogScaleAction
+		expected *LogScaleAction
+	}{
+		{
+			name: "webhook",
+			action: &models.Configv1LogScaleAction{
+				Slug:       "hook",
+				Repository: "payments",
+				ActionType: models.LogScaleActionActionTypeWEBHOOK,
+				WebhookAction: &models.LogScaleActionWebhookAction{
+					URL:     "https://example.com/hooks/s3cr3t",
+					Method:  models.WebhookActionHTTPMethodPOST,
+					Headers: map[string]string{"Authorization": "Bearer s3cr3t"},
+				},
+			},
+			expected: &LogScaleAction{
+				Slug:       "hook",
+				Repository: "payments",
+				Type:       "WEBHOOK",
+				Config: map[string]any{
+					"url":     "https://example.com/REDACTED",
+					"method":  "POST",
+					"headers": "REDACTED",
+				},
+				RedactedFields: []string{"webhook_action.headers", "webhook_action.url"},
+			},
+		},
+		{
+			name: "pagerduty",
+			action: &models.Configv1LogScaleAction{
+				Slug:            "oncall",
+				Name:            "On call",
+				ActionType:      models.LogScaleActionActionTypePAGERDUTY,
+				PagerDutyAction: &models.LogScaleActionPagerDutyAction{RoutingKey: "s3cr3t-key", Severity: "critical"},
+			},
+			expected: &LogScaleAction{
+				Slug:           "oncall",
+				Name:           "On call",
+				Type:           "PAGER_DUTY",
+				Config:         map[string]any{"routing_key": "REDACTED", "severity": "critical"},
+				RedactedFields: []string{"pager_duty_action.routing_key"},
+			},
+		},
+		{
+			name: "email",
+			action: &models.Configv1LogScaleAction{
+				Slug:        "mail",
+				ActionType:  models.LogScaleActionActionTypeEMAIL,
+				EmailAction: &models.LogScaleActionEmailAction{Recipients: []string{"oncall@example.com"}},
+			},
+			expected: &LogScaleAction{
+				Slug:   "mail",
+				Type:   "EMAIL",
+				Config: map[string]any{"recipients": []any{"oncall@example.com"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newLogScaleAction(tt.action)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, a)
+
+			b, err := json.Marshal(a)
+			require.NoError(t, err)
+			require.NotContains(t, string(b), "s3cr3t")
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notifier"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/redact"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// Notifier integration types.
+const (
+	notifierSlack     = "slack"
+	notifierPagerduty = "pagerduty"
+	notifierEmail     = "email"
+	notifierOpsGenie  = "ops_genie"
+	notifierVictorOps = "victor_ops"
+	notifierWebhook   = "webhook"
+	notifierDiscard   = "discard"
+)
+
+// Notifier is a notifier with its credentials redacted.
+type Notifier struct {
+	Slug string `json:"slug"`
+	Name string `json:"name,omitempty"`
+	// Type is the integration notifications are sent with, e.g. slack.
+	Type string `json:"type"`
+	// Destination summarizes where notifications go, e.g. the Slack channel.
+	Destination  string `json:"destination,omitempty"`
+	SkipResolved bool   `json:"skip_resolved,omitempty"`
+	// Config is the configuration of the integration with credentials redacted.
+	Config         any      `json:"config,omitempty"`
+	RedactedFields []string `json:"redacted_fields,omitempty"`
+}
+
+// NotifierList is a page of notifiers.
+type NotifierList struct {
+	Notifiers []*Notifier `json:"notifiers"`
+	NextToken string      `json:"next_token,omitempty"`
+}
+
+func (t *Tools) getNotifier(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Notifier.ReadNotifier(&notifier.ReadNotifierParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ReadNotifier: %s", err)
+	}
+	if resp.Payload.Notifier == nil {
+		return nil, fmt.Errorf("notifier %q not found", slug)
+	}
+	n, err := newNotifier(resp.Payload.Notifier)
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{JSONContent: n}, nil
+}
+
+func (t *Tools) listNotifiers(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slugs, err := params.StringArray(request, "slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	names, err := params.StringArray(request, "names", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	pageMaxSize, err := params.Int(request, "page_max_size", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	pageToken, err := params.String(request, "page_token", false, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Notifier.ListNotifiers(&notifier.ListNotifiersParams{
+		Context:     ctx,
+		Slugs:       slugs,
+		Names:       names,
+		PageMaxSize: ptr.To(int64(pageMaxSize)),
+		PageToken:   &pageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ListNotifiers: %s", err)
+	}
+
+	result := &NotifierList{Notifiers: make([]*Notifier, 0, len(resp.Payload.Notifiers))}
+	for _, m := range resp.Payload.Notifiers {
+		n, err := newNotifier(m)
+		if err != nil {
+			return nil, err
+		}
+		result.Notifiers = append(result.Notifiers, n)
+	}
+	if resp.Payload.Page != nil {
+		result.NextToken = resp.Payload.Page.NextToken
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// newNotifier returns the notifier with its credentials redacted.
+func newNotifier(m *models.Configv1Notifier) (*Notifier, error) {
+	redacted, redactedFields, err := redact.Notifiers.Redact(m)
+	if err != nil {
+		return nil, err
+	}
+	n := &Notifier{
+		Slug:           m.Slug,
+		Name:           m.Name,
+		SkipResolved:   m.SkipResolved,
+		RedactedFields: redactedFields,
+	}
+	n.Type, n.Destination = notifierDestination(m)
+	if fields, ok := redacted.(map[string]any); ok && n.Type != notifierDiscard {
+		n.Config = fields[n.Type]
+	}
+	return n, nil
+}
+
+// notifierDestination returns the integration type of a notifier and a summary of where it
+// sends notifications. Only non-sensitive fields are used.
+func notifierDestination(m *models.Configv1Notifier) (string, string) {
+	switch {
+	case m.Slack != nil:
+		if m.Slack.Channel != "" {
+			return notifierSlack, m.Slack.Channel
+		}
+		return notifierSlack, "default channel of the Slack webhook"
+	case m.Pagerduty != nil:
+		if m.Pagerduty.ServiceKey != "" {
+			return notifierPagerduty, "PagerDuty service with a Prometheus integration key"
+		}
+		return notifierPagerduty, "PagerDuty service with an Events API v2 integration key"
+	case m.Email != nil:
+		return notifierEmail, m.Email.To
+	case m.OpsGenie != nil:
+		var responders []string
+		for _, r := range m.OpsGenie.Responders {
+			if r == nil {
+				continue
+			}
+			name := r.Name
+			if name == "" {
+				name = r.Username
+			}
+			if name == "" {
+				name = r.ID
+			}
+			responders = append(responders, strings.ToLower(string(r.ResponderType))+":"+name)
+		}
+		return notifierOpsGenie, strings.Join(responders, ", ")
+	case m.VictorOps != nil:
+		if m.VictorOps.RoutingKey == "" {
+			return notifierVictorOps, ""
+		}
+		return notifierVictorOps, "routing key " + m.VictorOps.RoutingKey
+	case m.Webhook != nil:
+		return notifierWebhook, redact.URL(m.Webhook.URL)
+	case m.Discard:
+		return notifierDiscard, "notifications are discarded"
+	}
+	return "", ""
+}
@@ -0,0 +1,300 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/bucket"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/classic_dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/collection"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/mapping_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/monitor"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notification_policy"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/recording_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/rollup_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/s_l_o"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/service"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/team"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configowner"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+// Entity types ownership can be resolved for.
+const (
+	entityMonitor            = "monitor"
+	entityDashboard          = "dashboard"
+	entityClassicDashboard   = "classic_dashboard"
+	entitySLO                = "slo"
+	entityRecordingRule      = "recording_rule"
+	entityRollupRule         = "rollup_rule"
+	entityMappingRule        = "mapping_rule"
+	entityNotificationPolicy = "notification_policy"
+	entityCollection         = "collection"
+	entityService            = "service"
+	entityBucket             = "bucket"
+	entityTeam               = "team"
+)
+
+// ownershipEntityTypes are the entity types get_ownership supports, excluding classic
+// dashboards which are only supported when enabled.
+var ownershipEntityTypes = []string{
+	entityMonitor,
+	entityDashboard,
+	entitySLO,
+	entityRecordingRule,
+	entityRollupRule,
+	entityMappingRule,
+	entityNotificationPolicy,
+	entityCollection,
+	entityService,
+	entityBucket,
+	entityTeam,
+}
+
+// Ownership is who owns a config entity.
+type Ownership struct {
+	EntityType string `json:"entity_type"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name,omitempty"`
+	Link       string `json:"link,omitempty"`
+	// Owner is the collection, service or bucket the entity belongs to.
+	Owner *Owner `json:"owner,omitempty"`
+	// NotificationPolicySlug is the notification policy of the entity, falling back to the
+	// one of its owner.
+	NotificationPolicySlug string   `json:"notification_policy_slug,omitempty"`
+	Team                   *Team    `json:"team,omitempty"`
+	Warnings               []string `json:"warnings,omitempty"`
+}
+
+// Owner is a collection, service or bucket owning config.
+type Owner struct {
+	Kind        string `json:"kind"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"link,omitempty"`
+}
+
+// Team is the team owning config, with its members.
+type Team struct {
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Members     []string `json:"members"`
+	Link        string   `json:"link,omitempty"`
+}
+
+// ownedEntity is the ownership fields of a config entity.
+type ownedEntity struct {
+	name        string
+	description string
+	owner       configowner.Owner
+	teamSlug    string
+	policySlug  string
+}
+
+func (t *Tools) getOwnership(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	entityType, err := params.String(request, "entity_type", true, "")
+	if err != nil {
+		return nil, err
+	}
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	if supported := t.ownershipEntityTypes(); !slices.Contains(supported, entityType) {
+		return nil, fmt.Errorf("unknown entity type %q, must be one of %v", entityType, supported)
+	}
+
+	e, err := t.readOwnedEntity(ctx, entityType, slug)
+	if err != nil {
+		return nil, err
+	}
+	result := &Ownership{
+		EntityType:             entityType,
+		Slug:                   slug,
+		Name:                   e.name,
+		Link:                   t.linkBuilder.ConfigEntity(entityType, slug),
+		NotificationPolicySlug: e.policySlug,
+	}
+
+	teamSlug := e.teamSlug
+	if e.owner.Slug != "" {
+		owner, err := t.readOwnedEntity(ctx, e.owner.Kind, e.owner.Slug)
+		result.Owner = &Owner{
+			Kind: e.owner.Kind,
+			Slug: e.owner.Slug,
+			Link: t.linkBuilder.ConfigEntity(e.owner.Kind, e.owner.Slug),
+		}
+		if err != nil {
+			result.Warnings = append(result.Warnings, err.Error())
+		} else {
+			result.Owner.Name = owner.name
+			result.Owner.Description = owner.description
+			if teamSlug == "" {
+				teamSlug = owner.teamSlug
+			}
+			if result.NotificationPolicySlug == "" {
+				result.NotificationPolicySlug = owner.policySlug
+			}
+		}
+	}
+
+	if teamSlug != "" {
+		result.Team, err = t.readTeam(ctx, teamSlug)
+		if err != nil {
+			result.Warnings = append(result.Warnings, err.Error())
+			result.Team = &Team{Slug: teamSlug, Link: t.linkBuilder.ConfigEntity(entityTeam, teamSlug)}
+		}
+	} else {
+		result.Warnings = append(result.Warnings, "no owning team is set")
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+func (t *Tools) ownershipEntityTypes() []string {
+	if t.config.EnableClassicDashboards {
+		return append(slices.Clone(ownershipEntityTypes), entityClassicDashboard)
+	}
+	return ownershipEntityTypes
+}
+
+func (t *Tools) readTeam(ctx context.Context, slug string) (*Team, error) {
+	resp, err := t.client.Team.ReadTeam(&team.ReadTeamParams{Context: ctx, Slug: slug})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read team %q: %s", slug, err)
+	}
+	tm := resp.Payload.Team
+	if tm == nil {
+		return nil, fmt.Errorf("team %q not found", slug)
+	}
+	members := tm.UserEmails
+	if members == nil {
+		members = []string{}
+	}
+	return &Team{
+		Slug:        tm.Slug,
+		Name:        tm.Name,
+		Description: tm.Description,
+		Members:     members,
+		Link:        t.linkBuilder.ConfigEntity(entityTeam, tm.Slug),
+	}, nil
+}
+
+// readOwnedEntity reads the ownership fields of a config entity. The team of a team is
+// itself.
+func (t *Tools) readOwnedEntity(ctx context.Context, entityType, slug string) (*ownedEntity, error) {
+	var (
+		e   *ownedEntity
+		err error
+	)
+	switch entityType {
+	case entityMonitor:
+		var resp *monitor.ReadMonitorOK
+		if resp, err = t.client.Monitor.ReadMonitor(&monitor.ReadMonitorParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Monitor != nil {
+			m := resp.Payload.Monitor
+			e = withOwner(configowner.Of(m.Collection, m.CollectionSlug, m.BucketSlug), m.Name)
+			e.policySlug = m.NotificationPolicySlug
+		}
+	case entityDashboard:
+		var resp *dashboard.ReadDashboardOK
+		if resp, err = t.client.Dashboard.ReadDashboard(&dashboard.ReadDashboardParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Dashboard != nil {
+			d := resp.Payload.Dashboard
+			e = withOwner(configowner.Of(d.Collection, d.CollectionSlug, ""), d.Name)
+		}
+	case entityClassicDashboard:
+		var resp *classic_dashboard.ReadClassicDashboardOK
+		if resp, err = t.client.ClassicDashboard.ReadClassicDashboard(&classic_dashboard.ReadClassicDashboardParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.ClassicDashboard != nil {
+			d := resp.Payload.ClassicDashboard
+			e = withOwner(configowner.Of(d.Collection, d.CollectionSlug, d.BucketSlug), d.Name)
+		}
+	case entitySLO:
+		var resp *s_l_o.ReadSLOOK
+		if resp, err = t.client.Slo.ReadSLO(&s_l_o.ReadSLOParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Slo != nil {
+			s := resp.Payload.Slo
+			e = withOwner(configowner.Of(s.CollectionRef, "", ""), s.Name)
+			e.policySlug = s.NotificationPolicySlug
+		}
+	case entityRecordingRule:
+		var resp *recording_rule.ReadRecordingRuleOK
+		if resp, err = t.client.RecordingRule.ReadRecordingRule(&recording_rule.ReadRecordingRuleParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.RecordingRule != nil {
+			r := resp.Payload.RecordingRule
+			e = withOwner(configowner.Of(nil, "", r.BucketSlug), r.Name)
+		}
+	case entityRollupRule:
+		var resp *rollup_rule.ReadRollupRuleOK
+		if resp, err = t.client.RollupRule.ReadRollupRule(&rollup_rule.ReadRollupRuleParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.RollupRule != nil {
+			r := resp.Payload.RollupRule
+			e = withOwner(configowner.Of(nil, "", r.BucketSlug), r.Name)
+		}
+	case entityMappingRule:
+		var resp *mapping_rule.ReadMappingRuleOK
+		if resp, err = t.client.MappingRule.ReadMappingRule(&mapping_rule.ReadMappingRuleParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.MappingRule != nil {
+			r := resp.Payload.MappingRule
+			e = withOwner(configowner.Of(nil, "", r.BucketSlug), r.Name)
+		}
+	case entityNotificationPolicy:
+		var resp *notification_policy.ReadNotificationPolicyOK
+		if resp, err = t.client.NotificationPolicy.ReadNotificationPolicy(&notification_policy.ReadNotificationPolicyParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.NotificationPolicy != nil {
+			p := resp.Payload.NotificationPolicy
+			e = withOwner(configowner.Of(nil, "", p.BucketSlug), p.Name)
+			e.teamSlug = p.TeamSlug
+		}
+	case entityCollection:
+		var resp *collection.ReadCollectionOK
+		if resp, err = t.client.Collection.ReadCollection(&collection.ReadCollectionParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Collection != nil {
+			c := resp.Payload.Collection
+			e = &ownedEntity{name: c.Name, description: c.Description, teamSlug: c.TeamSlug, policySlug: c.NotificationPolicySlug}
+		}
+	case entityService:
+		var resp *service.ReadServiceOK
+		if resp, err = t.client.Service.ReadService(&service.ReadServiceParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Service != nil {
+			s := resp.Payload.Service
+			e = &ownedEntity{name: s.Name, description: s.Description, teamSlug: s.TeamSlug, policySlug: s.NotificationPolicySlug}
+		}
+	case entityBucket:
+		var resp *bucket.ReadBucketOK
+		if resp, err = t.client.Bucket.ReadBucket(&bucket.ReadBucketParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Bucket != nil {
+			b := resp.Payload.Bucket
+			e = &ownedEntity{name: b.Name, description: b.Description, teamSlug: b.TeamSlug, policySlug: b.NotificationPolicySlug}
+		}
+	case entityTeam:
+		var resp *team.ReadTeamOK
+		if resp, err = t.client.Team.ReadTeam(&team.ReadTeamParams{Context: ctx, Slug: slug}); err == nil && resp.Payload.Team != nil {
+			e = &ownedEntity{name: resp.Payload.Team.Name, teamSlug: slug}
+		}
+	default:
+		return nil, fmt.Errorf("unknown entity type %q", entityType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %q: %s", entityType, slug, err)
+	}
+	if e == nil {
+		return nil, fmt.Errorf("%s %q not found", entityType, slug)
+	}
+	return e, nil
+}
+
+// withOwner returns the ownership fields of an entity with the given name and owner.
+func withOwner(owner configowner.Owner, name string) *ownedEntity {
+	return &ownedEntity{name: name, owner: owner}
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	configv1client "github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
+)
+
+func TestGetOwnership(t *testing.T) {
+	responses := map[string]string{
+		"/api/v1/config/monitors/cpu":                 `{"monitor": {"slug": "cpu", "name": "CPU", "collection": {"slug": "checkout", "type": "SIMPLE"}}}`,
+		"/api/v1/config/monitors/orphan":              `{"monitor": {"slug": "orphan", "name": "Orphan", "bucket_slug": "gone"}}`,
+		"/api/v1/config/slos/latency":                 `{"slo": {"slug": "latency", "name": "Latency", "collection_ref": {"slug": "api", "type": "SERVICE"}, "notification_policy_slug": "slo-policy"}}`,
+		"/api/v1/config/collections/checkout":         `{"collection": {"slug": "checkout", "name": "Checkout", "team_slug": "payments", "notification_policy_slug": "payments-policy"}}`,
+		"/api/v1/config/services/api":                 `{"service": {"slug": "api", "name": "API", "team_slug": "payments", "notification_policy_slug": "api-policy"}}`,
+		"/api/v1/config/teams/payments":               `{"team": {"slug": "payments", "name": "Payments", "user_emails": ["a@example.com", "b@example.com"]}}`,
+		"/api/v1/config/notification-policies/oncall": `{"notification_policy": {"slug": "oncall", "name": "On call", "team_slug": "payments", "bucket_slug": "infra"}}`,
+		"/api/v1/config/buckets/infra":                `{"bucket": {"slug": "infra", "name": "Infra", "team_slug": "platform"}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			body = `{"code": 5, "message": "not found"}`
+		}
+		_, err := w.Write([]byte(body))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	transport := configv1client.DefaultTransportConfig().
+		WithHost(server.URL[7:]).
+		WithSchemes([]string{"http"})
+	ct, err := NewTools(
+		configv1client.NewHTTPClientWithConfig(nil, transport),
+		zaptest.NewLogger(t),
+		&tools.Config{},
+		links.NewBuilder("https://test.chronosphere.io"),
+	)
+	require.NoError(t, err)
+
+	payments := &Team{
+		Slug:    "payments",
+		Name:    "Payments",
+		Members: []string{"a@example.com", "b@example.com"},
+		Link:    "https://test.chronosphere.io/teams/payments?",
+	}
+	tests := []struct {
+		name       string
+		entityType string
+		slug       string
+		expected   *Ownership
+		err        string
+	}{
+		{
+			name:       "monitor in collection",
+			entityType: "monitor",
+			slug:       "cpu",
+			expected: &Ownership{
+				EntityType: "monitor",
+				Slug:       "cpu",
+				Name:       "CPU",
+				Link:       "https://test.chronosphere.io/monitors/cpu?",
+				Owner: &Owner{
+					Kind: "collection",
+					Slug: "checkout",
+					Name: "Checkout",
+					Link: "https://test.chronosphere.io/collections/checkout?",
+				},
+				NotificationPolicySlug: "payments-policy",
+				Team:                   payments,
+			},
+		},
+		{
+			name:       "slo in service keeps its own policy",
+			entityType: "slo",
+			slug:       "latency",
+			expected: &Ownership{
+				EntityType:             "slo",
+				Slug:                   "latency",
+				Name:                   "Latency",
+				Link:                   "https://test.chronosphere.io/slos/latency?",
+				Owner:                  &Owner{Kind: "service", Slug: "api", Name: "API"},
+				NotificationPolicySlug: "slo-policy",
+				Team:                   payments,
+			},
+		},
+		{
+			name:       "notification policy in bucket keeps its own team",
+			entityType: "notification_policy",
+			slug:       "oncall",
+			expected: &Ownership{
+				EntityType: "notification_policy",
+				Slug:       "oncall",
+				Name:       "On call",
+				Link:       "https://test.chronosphere.io/alerts/notification-policies/oncall?",
+				Owner:      &Owner{Kind: "bucket", Slug: "infra", Name: "Infra"},
+				Team:       payments,
+			},
+		},
+		{
+			name:       "missing owner",
+			entityType: "monitor",
+			slug:       "orphan",
+			expected: &Ownership{
+				EntityType: "monitor",
+				Slug:       "orphan",
+				Name:       "Orphan",
+				Link:       "https://test.chronosphere.io/monitors/orphan?",
+				Owner:      &Owner{Kind: "bucket", Slug: "gone"},
+				Warnings: []string{
+					`failed to read bucket "gone": `,
+					"no owning team is set",
+				},
+			},
+		},
+		{
+			name:       "team",
+			entityType: "team",
+			slug:       "payments",
+			expected: &Ownership{
+				EntityType: "team",
+				Slug:       "payments",
+				Name:       "Payments",
+				Link:       "https://test.chronosphere.io/teams/payments?",
+				Team:       payments,
+			},
+		},
+		{
+			name:       "classic dashboards disabled",
+			entityType: "classic_dashboard",
+			slug:       "old",
+			err:        `unknown entity type "classic_dashboard"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ct.getOwnership(t.Context(), mcp.CallToolRequest{Params: mcp.CallToolParams{
+				Arguments: map[string]any{"entity_type": tt.entityType, "slug": tt.slug},
+			}})
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			ownership := result.JSONContent.(*Ownership)
+			// Warnings are compared by prefix since they include client errors.
+			require.Len(t, ownership.Warnings, len(tt.expected.Warnings))
+			for i, w := range tt.expected.Warnings {
+				require.True(t, strings.HasPrefix(ownership.Warnings[i], w), ownership.Warnings[i])
+			}
+			ownership.Warnings = tt.expected.Warnings
+			require.Equal(t, tt.expected, ownership)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/service_account"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/redact"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// Audit findings of service accounts.
+const (
+	findingUnrestricted         = "unrestricted"
+	findingUnscopedMetricsWrite = "unscoped_metrics_write"
+	findingNoTeam               = "no_team"
+	findingStale                = "stale"
+)
+
+// ServiceAccount is a service account with its token redacted and the teams it is a member of.
+type ServiceAccount struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	// Permissions summarizes what the account can access, e.g. "metrics READ_WRITE".
+	Permissions        string                                   `json:"permissions"`
+	Unrestricted       bool                                     `json:"unrestricted"`
+	MetricsRestriction *models.ServiceAccountMetricsRestriction `json:"metrics_restriction,omitempty"`
+	// Teams are the slugs of the teams the account is a member of, which own it.
+	Teams          []string  `json:"teams"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	RedactedFields []string  `json:"redacted_fields,omitempty"`
+	// Findings are the audit findings of the account, only set in audit mode.
+	Findings []*AuditFinding `json:"findings,omitempty"`
+}
+
+// AuditFinding is a risk flagged for a service account.
+type AuditFinding struct {
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+}
+
+// ServiceAccountList is a page of service accounts, or every service account in audit mode.
+type ServiceAccountList struct {
+	ServiceAccounts []*ServiceAccount `json:"service_accounts"`
+	NextToken       string            `json:"next_token,omitempty"`
+	// FindingCounts is the number of accounts per finding, only set in audit mode.
+	FindingCounts map[string]int `json:"finding_counts,omitempty"`
+	Warnings      []string       `json:"warnings,omitempty"`
+}
+
+func (t *Tools) listServiceAccounts(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slugs, err := params.StringArray(request, "slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	names, err := params.StringArray(request, "names", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	audit, err := params.Bool(request, "audit", false, false)
+	if err != nil {
+		return nil, err
+	}
+	staleDays, err := params.Int(request, "stale_days", false, 180)
+	if err != nil {
+		return nil, err
+	}
+	pageMaxSize, err := params.Int(request, "page_max_size", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	pageToken, err := params.String(request, "page_token", false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		accounts []*models.Configv1ServiceAccount
+		result   = &ServiceAccountList{}
+	)
+	if audit {
+		// Audits cover every account, so all pages are listed.
+		accounts, err = configlist.ServiceAccounts(ctx, t.client, configlist.Filter{Slugs: slugs})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		resp, err := t.client.ServiceAccount.ListServiceAccounts(&service_account.ListServiceAccountsParams{
+			Context:     ctx,
+			Slugs:       slugs,
+			Names:       names,
+			PageMaxSize: ptr.To(int64(pageMaxSize)),
+			PageToken:   &pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to call ListServiceAccounts: %s", err)
+		}
+		accounts = resp.Payload.ServiceAccounts
+		if resp.Payload.Page != nil {
+			result.NextToken = resp.Payload.Page.NextToken
+		}
+	}
+
+	teams, err := configlist.Teams(ctx, t.client, configlist.Filter{})
+	teamsKnown := err == nil
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to list teams, so team membership is unknown: %s", err))
+	}
+	members := teamsByMember(teams)
+
+	result.ServiceAccounts = make([]*ServiceAccount, 0, len(accounts))
+	for _, m := range accounts {
+		if m == nil || (audit && len(names) > 0 && !slices.Contains(names, m.Name)) {
+			continue
+		}
+		a, err := newServiceAccount(m, members)
+		if err != nil {
+			return nil, err
+		}
+		result.ServiceAccounts = append(result.ServiceAccounts, a)
+	}
+	if audit {
+		result.FindingCounts = auditServiceAccounts(result.ServiceAccounts, time.Now(), time.Duration(staleDays)*24*time.Hour, teamsKnown)
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// teamsByMember returns the slugs of the teams each member email belongs to.
+func teamsByMember(teams []*models.Configv1Team) map[string][]string {
+	members := make(map[string][]string)
+	for _, tm := range teams {
+		if tm == nil {
+			continue
+		}
+		for _, email := range tm.UserEmails {
+			members[strings.ToLower(email)] = append(members[strings.ToLower(email)], tm.Slug)
+		}
+	}
+	for _, slugs := range members {
+		sort.Strings(slugs)
+	}
+	return members
+}
+
+// newServiceAccount returns the service account with its token redacted. The server only
+// returns the token when an account is created, but it is redacted regardless.
+func newServiceAccount(m *models.Configv1ServiceAccount, members map[string][]string) (*ServiceAccount, error) {
+	_, redactedFields, err := redact.Credentials.Redact(m)
+	if err != nil {
+		return nil, err
+	}
+	a := &ServiceAccount{
+		Slug:               m.Slug,
+		Name:               m.Name,
+		Email:              m.Email,
+		Permissions:        serviceAccountPermissions(m),
+		Unrestricted:       m.Unrestricted,
+		MetricsRestriction: m.MetricsRestriction,
+		Teams:              members[strings.ToLower(m.Email)],
+		CreatedAt:          time.Time(m.CreatedAt),
+		UpdatedAt:          time.Time(m.UpdatedAt),
+		RedactedFields:     redactedFields,
+	}
+	if a.Teams == nil {
+		a.Teams = []string{}
+	}
+	return a, nil
+}
+
+// serviceAccountPermissions summarizes what the service account can access.
+func serviceAccountPermissions(m *models.Configv1ServiceAccount) string {
+	switch {
+	case m.Unrestricted:
+		return "all APIs, within the permissions of its teams"
+	case m.MetricsRestriction != nil:
+		p := "metrics " + string(m.MetricsRestriction.Permission)
+		if len(m.MetricsRestriction.Labels) > 0 {
+			var labels []string
+			for _, k := range slices.Sorted(maps.Keys(m.MetricsRestriction.Labels)) {
+				labels = append(labels, fmt.Sprintf("%s=%q", k, m.MetricsRestriction.Labels[k]))
+			}
+			p += " with labels " + strings.Join(labels, ", ")
+		}
+		return p
+	}
+	return "none"
+}
+
+// auditServiceAccounts sets the findings of each account and returns the number of accounts
+// per finding. Accounts without a team are only flagged if team membership is known.
+func auditServiceAccounts(accounts []*ServiceAccount, now time.Time, staleAfter time.Duration, teamsKnown bool) map[string]int {
+	counts := make(map[string]int)
+	for _, a := range accounts {
+		if a.Unrestricted {
+			a.Findings = append(a.Findings, &AuditFinding{
+				Kind:   findingUnrestricted,
+				Reason: "the account can use all APIs, including config writes, within the permissions of its teams",
+			})
+		}
+		if r := a.MetricsRestriction; r != nil && len(r.Labels) == 0 &&
+			(r.Permission == models.MetricsRestrictionPermissionWRITE || r.Permission == models.MetricsRestrictionPermissionREADWRITE) {
+			a.Findings = append(a.Findings, &AuditFinding{
+				Kind:   findingUnscopedMetricsWrite,
+				Reason: "the account can write any metrics since its write permission is not restricted by labels",
+			})
+		}
+		if teamsKnown && len(a.Teams) == 0 {
+			a.Findings = append(a.Findings, &AuditFinding{
+				Kind:   findingNoTeam,
+				Reason: "the account is not a member of any team, so no team owns it",
+			})
+		}
+		lastChanged := a.UpdatedAt
+		if lastChanged.IsZero() {
+			lastChanged = a.CreatedAt
+		}
+		if staleAfter > 0 && !lastChanged.IsZero() && now.Sub(lastChanged) > staleAfter {
+			reason := fmt.Sprintf("the account has not been updated since %s; service accounts have no description or last used time, "+
+				"so this is based on updated_at and the account may still be in use", lastChanged.Format(time.DateOnly))
+			a.Findings = append(a.Findings, &AuditFinding{Kind: findingStale, Reason: reason})
+		}
+		for _, f := range a.Findings {
+			counts[f.Kind]++
+		}
+	}
+	return counts
+}
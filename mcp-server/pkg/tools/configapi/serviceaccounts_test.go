@@ -0,0 +1,94 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestNewServiceAccount(t *testing.T) {
+	members := teamsByMember([]*models.Configv1Team{
+		{Slug: "platform", UserEmails: []string{"alice@example.com", "Deployer@service-account.example.com"}},
+		{Slug: "infra", UserEmails: []string{"deployer@service-account.example.com"}},
+	})
+	a, err := newServiceAccount(&models.Configv1ServiceAccount{
+		Slug:  "deployer",
+		Name:  "Deployer",
+		Email: "deployer@service-account.example.com",
+		Token: "s3cr3t",
+		MetricsRestriction: &models.ServiceAccountMetricsRestriction{
+			Permission: models.MetricsRestrictionPermissionWRITE,
+			Labels:     map[string]string{"env": "prod", "app": "deployer"},
+		},
+	}, members)
+	require.NoError(t, err)
+	require.Equal(t, []string{"infra", "platform"}, a.Teams)
+	require.Equal(t, `metrics WRITE with labels app="deployer", env="prod"`, a.Permissions)
+	require.Equal(t, []string{"token"}, a.RedactedFields)
+
+	b, err := json.Marshal(a)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "s3cr3t")
+}
+
+func TestAuditServiceAccounts(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	recent := strfmt.DateTime(now.Add(-24 * time.Hour))
+	old := strfmt.DateTime(now.Add(-365 * 24 * time.Hour))
+	members := map[string][]string{"ci@example.com": {"platform"}, "reader@example.com": {"platform"}}
+
+	var accounts []*ServiceAccount
+	for _, m := range []*models.Configv1ServiceAccount{
+		{Slug: "admin", Email: "admin@example.com", Unrestricted: true, UpdatedAt: recent},
+		{Slug: "ci", Email: "ci@example.com", UpdatedAt: old, MetricsRestriction: &models.ServiceAccountMetricsRestriction{
+			Permission: models.MetricsRestrictionPermissionREADWRITE,
+		}},
+		{Slug: "reader", Email: "reader@example.com", CreatedAt: recent, MetricsRestriction: &models.ServiceAccountMetricsRestriction{
+			Permission: models.MetricsRestrictionPermissionWRITE,
+			Labels:     map[string]string{"service": "reader"},
+		}},
+	} {
+		a, err := newServiceAccount(m, members)
+		require.NoError(t, err)
+		accounts = append(accounts, a)
+	}
+
+	counts := auditServiceAccounts(accounts, now, 180*24*time.Hour, true)
+	require.Equal(t, map[string]int{
+		findingUnrestricted:         1,
+		findingNoTeam:               1,
+		findingUnscopedMetricsWrite: 1,
+		findingStale:                1,
+	}, counts)
+	kinds := func(a *ServiceAccount) []string {
+		var out []string
+		for _, f := range a.Findings {
+			out = append(out, f.Kind)
+		}
+		return out
+	}
+	require.Equal(t, []string{findingUnrestricted, findingNoTeam}, kinds(accounts[0]))
+	require.Equal(t, []string{findingUnscopedMetricsWrite, findingStale}, kinds(accounts[1]))
+	require.Empty(t, accounts[2].Findings)
+	require.Equal(t, "the account has not been updated since 2024-06-01; service accounts have no description or last used time, "+
+		"so this is based on updated_at and the account may still be in use", accounts[1].Findings[1].Reason)
+}
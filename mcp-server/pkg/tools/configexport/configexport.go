@@ -0,0 +1,95 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configexport provides tools for exporting config as code.
+package configexport
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
+)
+
+var _ tools.MCPTools = (*Tools)(nil)
+
+// Tools provides MCP tools for exporting config.
+type Tools struct {
+	logger      *zap.Logger
+	configAPI   *configv1.ConfigV1API
+	linkBuilder *links.Builder
+}
+
+// NewTools creates new config export tools.
+func NewTools(
+	configAPI *configv1.ConfigV1API,
+	logger *zap.Logger,
+	linkBuilder *links.Builder,
+) (*Tools, error) {
+	logger.Info("config export tool configured")
+
+	return &Tools{
+		logger:      logger,
+		configAPI:   configAPI,
+		linkBuilder: linkBuilder,
+	}, nil
+}
+
+func (t *Tools) GroupName() string {
+	return "configexport"
+}
+
+func (t *Tools) MCPTools() []tools.MCPTool {
+	return []tools.MCPTool{
+		{
+			Metadata: tools.NewMetadata("export_config",
+				mcp.WithDescription(`Exports config entities as code, either as chronoctl YAML that can be applied with "chronoctl apply" or as Terraform resources for the Chronosphere provider. Use this to codify config that was created in the UI.
+
+Entities are selected by slugs, by owning team or by collection; at least one of these must be set. Teams, collections, buckets and notification policies referenced by the exported entities are included by default, so references between entities are expressed as references (resource attributes in Terraform, slugs in chronoctl YAML) rather than pointing at config outside of the export. References to entities that are not exported, such as services and notifiers, are kept as slugs and reported as warnings.
+
+Terraform export supports teams, collections, buckets, notification policies, dashboards, monitors and recording rules. Drop rules, rollup rules, mapping rules and SLOs can only be exported as chronoctl YAML; in a Terraform export they are skipped and reported as warnings. chronoctl YAML export supports every entity type. Terraform resource names are derived from slugs; slugs that would share a name get a numeric suffix, e.g. _2.
+
+Response fields:
+- content: The exported YAML or HCL
+- entities: The exported entities, including the dependencies that were added
+- warnings: References that could not be expressed as references and fields that could not be exported`),
+				mcp.WithString("entity_type",
+					mcp.Required(),
+					mcp.Description("Type of the entities to export."),
+					mcp.Enum(entityTypes...),
+				),
+				params.WithStringArray("slugs",
+					mcp.Description("Slugs of the entities to export."),
+				),
+				mcp.WithString("team_slug",
+					mcp.Description("Export the entities owned by this team."),
+				),
+				mcp.WithString("collection_slug",
+					mcp.Description("Export the entities belonging to this collection. Only applies to monitors, dashboards and SLOs."),
+				),
+				mcp.WithString("format",
+					mcp.Description("Export format. Defaults to yaml."),
+					mcp.Enum(formatYAML, formatTerraform),
+				),
+				mcp.WithBoolean("include_dependencies",
+					mcp.Description("Include the teams, collections, buckets and notification policies referenced by the exported entities. Defaults to true."),
+				),
+			),
+			Handler: t.exportConfig,
+		},
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configexport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+const (
+	formatYAML      = "yaml"
+	formatTerraform = "terraform"
+)
+
+const (
+	entityTeam               = "team"
+	entityBucket             = "bucket"
+	entityNotificationPolicy = "notification_policy"
+	entityCollection         = "collection"
+	entityDashboard          = "dashboard"
+	entityMonitor            = "monitor"
+	entitySLO                = "slo"
+	entityRecordingRule      = "recording_rule"
+	entityDropRule           = "drop_rule"
+	entityRollupRule         = "rollup_rule"
+	entityMappingRule        = "mapping_rule"
+)
+
+// entityTypes are the exportable entity types, in the order they are exported so that
+// entities come after the entities they reference.
+var entityTypes = []string{
+	entityTeam,
+	entityBucket,
+	entityNotificationPolicy,
+	entityCollection,
+	entityDashboard,
+	entityMonitor,
+	entitySLO,
+	entityRecordingRule,
+	entityDropRule,
+	entityRollupRule,
+	entityMappingRule,
+}
+
+// maxDependencyDepth bounds how many levels of references are followed.
+const maxDependencyDepth = 5
+
+// ExportedConfig is config exported as code.
+type ExportedConfig struct {
+	Format   string            `json:"format"`
+	Content  string            `json:"content"`
+	Entities []*ExportedEntity `json:"entities"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// ExportedEntity is an exported config entity.
+type ExportedEntity struct {
+	EntityType string `json:"entity_type"`
+	Slug       string `json:"slug"`
+	// Dependency is set if the entity was exported because another exported entity references it.
+	Dependency bool `json:"dependency,omitempty"`
+}
+
+// entity is a config entity to export. spec is the config API model of the entity.
+type entity struct {
+	entityType string
+	slug       string
+	spec       any
+	dependency bool
+}
+
+// reference is a reference from one entity to another.
+type reference struct {
+	entityType string
+	slug       string
+}
+
+type lister func(ctx context.Context, api *configv1.ConfigV1API, f configlist.Filter) ([]*entity, error)
+
+// listers list the entities of each type.
+var listers = map[string]lister{
+	entityTeam:               listAs(entityTeam, configlist.Teams, func(e *models.Configv1Team) string { return e.Slug }),
+	entityBucket:             listAs(entityBucket, configlist.Buckets, func(e *models.Configv1Bucket) string { return e.Slug }),
+	entityNotificationPolicy: listAs(entityNotificationPolicy, configlist.NotificationPolicies, func(e *models.Configv1NotificationPolicy) string { return e.Slug }),
+	entityCollection:         listAs(entityCollection, configlist.Collections, func(e *models.Configv1Collection) string { return e.Slug }),
+	entityDashboard:          listAs(entityDashboard, configlist.Dashboards, func(e *models.Configv1Dashboard) string { return e.Slug }),
+	entityMonitor:            listAs(entityMonitor, configlist.Monitors, func(e *models.Configv1Monitor) string { return e.Slug }),
+	entitySLO:                listAs(entitySLO, configlist.SLOs, func(e *models.Configv1SLO) string { return e.Slug }),
+	entityRecordingRule:      listAs(entityRecordingRule, configlist.RecordingRules, func(e *models.Configv1RecordingRule) string { return e.Slug }),
+	entityDropRule:           listAs(entityDropRule, configlist.DropRules, func(e *models.Configv1DropRule) string { return e.Slug }),
+	entityRollupRule:         listAs(entityRollupRule, configlist.RollupRules, func(e *models.Configv1RollupRule) string { return e.Slug }),
+	entityMappingRule:        listAs(entityMappingRule, configlist.MappingRules, func(e *models.Configv1MappingRule) string { return e.Slug }),
+}
+
+func listAs[T any](
+	entityType string,
+	list func(context.Context, *configv1.ConfigV1API, configlist.Filter) ([]T, error),
+	slug func(T) string,
+) lister {
+	return func(ctx context.Context, api *configv1.ConfigV1API, f configlist.Filter) ([]*entity, error) {
+		items, err := list(ctx, api, f)
+		if err != nil {
+			return nil, err
+		}
+		entities := make([]*entity, 0, len(items))
+		for _, item := range items {
+			entities = append(entities, &entity{entityType: entityType, slug: slug(item), spec: item})
+		}
+		return entities, nil
+	}
+}
+
+func (t *Tools) exportConfig(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	entityType, err := params.String(request, "entity_type", true, "")
+	if err != nil {
+		return nil, err
+	}
+	slugs, err := params.StringArray(request, "slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	teamSlug, err := params.String(request, "team_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	collectionSlug, err := params.String(request, "collection_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	format, err := params.String(request, "format", false, formatYAML)
+	if err != nil {
+		return nil, err
+	}
+	includeDependencies, err := params.Bool(request, "include_dependencies", false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := listers[entityType]; !ok {
+		return nil, fmt.Errorf("unknown entity type %q, must be one of %v", entityType, entityTypes)
+	}
+	if format != formatYAML && format != formatTerraform {
+		return nil, fmt.Errorf("unknown format %q, must be %s or %s", format, formatYAML, formatTerraform)
+	}
+	if format == formatTerraform && !slices.Contains(terraformEntityTypes, entityType) {
+		return nil, fmt.Errorf("%s entities can not be exported to terraform, use the %s format instead", entityType, formatYAML)
+	}
+	if len(slugs) == 0 && teamSlug == "" && collectionSlug == "" {
+		return nil, fmt.Errorf("at least one of slugs, team_slug or collection_slug must be set")
+	}
+
+	entities, err := t.selectEntities(ctx, entityType, slugs, teamSlug, collectionSlug)
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("no %s entities matched", entityType)
+	}
+
+	var warnings []string
+	if len(slugs) > 0 && teamSlug == "" && collectionSlug == "" {
+		warnings = append(warnings, missingWarnings(entityType, slugs, entities)...)
+	}
+	if includeDependencies {
+		entities = append(entities, t.dependencies(ctx, entities, &warnings)...)
+	}
+	sortEntities(entities)
+
+	result := &ExportedConfig{Format: format}
+	switch format {
+	case formatYAML:
+		result.Content, err = encodeYAML(entities)
+	case formatTerraform:
+		var tfWarnings []string
+		result.Content, tfWarnings = encodeTerraform(entities)
+		warnings = append(warnings, tfWarnings...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entities {
+		result.Entities = append(result.Entities, &ExportedEntity{EntityType: e.entityType, Slug: e.slug, Dependency: e.dependency})
+	}
+	result.Warnings = warnings
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// selectEntities lists the entities of the type matching the slugs, team and collection.
+func (t *Tools) selectEntities(ctx context.Context, entityType string, slugs []string, teamSlug, collectionSlug string) ([]*entity, error) {
+	f := configlist.Filter{Slugs: slugs}
+	var keep func(*entity) bool
+
+	if collectionSlug != "" {
+		switch entityType {
+		case entityMonitor, entityDashboard, entitySLO:
+			f.CollectionSlugs = []string{collectionSlug}
+		case entityCollection:
+			keep = matchSlug(collectionSlug)
+		default:
+			return nil, fmt.Errorf("collection_slug can not be used to select %s entities", entityType)
+		}
+	}
+	if teamSlug != "" {
+		switch entityType {
+		case entityMonitor, entityNotificationPolicy, entityCollection:
+			f.TeamSlugs = []string{teamSlug}
+		case entityDashboard, entitySLO:
+			if collectionSlug == "" {
+				collections, err := configlist.Collections(ctx, t.configAPI, configlist.Filter{TeamSlugs: []string{teamSlug}})
+				if err != nil {
+					return nil, err
+				}
+				if len(collections) == 0 {
+					return nil, nil
+				}
+				for _, c := range collections {
+					f.CollectionSlugs = append(f.CollectionSlugs, c.Slug)
+				}
+			}
+		case entityBucket:
+			keep = func(e *entity) bool {
+				return e.spec.(*models.Configv1Bucket).TeamSlug == teamSlug
+			}
+		case entityTeam:
+			keep = matchSlug(teamSlug)
+		default:
+			return nil, fmt.Errorf("team_slug can not be used to select %s entities", entityType)
+		}
+	}
+
+	entities, err := listers[entityType](ctx, t.configAPI, f)
+	if err != nil {
+		return nil, err
+	}
+	if keep != nil {
+		entities = slices.DeleteFunc(entities, func(e *entity) bool { return !keep(e) })
+	}
+	return entities, nil
+}
+
+func matchSlug(slug string) func(*entity) bool {
+	return func(e *entity) bool { return e.slug == slug }
+}
+
+// dependencies returns the entities referenced by the entities that are not already exported,
+// following references of dependencies too.
+func (t *Tools) dependencies(ctx context.Context, entities []*entity, warnings *[]string) []*entity {
+	seen := make(map[reference]bool, len(entities))
+	for _, e := range entities {
+		seen[reference{e.entityType, e.slug}] = true
+	}
+
+	var deps []*entity
+	pending := entities
+	for depth := 0; depth < maxDependencyDepth && len(pending) > 0; depth++ {
+		wanted := make(map[string][]string)
+		for _, e := range pending {
+			for _, ref := range references(e) {
+				if !seen[ref] {
+					seen[ref] = true
+					wanted[ref.entityType] = append(wanted[ref.entityType], ref.slug)
+				}
+			}
+		}
+
+		pending = nil
+		for _, entityType := range entityTypes {
+			slugs := wanted[entityType]
+			if len(slugs) == 0 {
+				continue
+			}
+			found, err := listers[entityType](ctx, t.configAPI, configlist.Filter{Slugs: slugs})
+			if err != nil {
+				*warnings = append(*warnings, fmt.Sprintf("failed to list referenced %s entities: %s", entityType, err))
+				continue
+			}
+			*warnings = append(*warnings, missingWarnings(entityType, slugs, found)...)
+			for _, e := range found {
+				e.dependency = true
+			}
+			pending = append(pending, found...)
+		}
+		deps = append(deps, pending...)
+	}
+	return deps
+}
+
+func missingWarnings(entityType string, slugs []string, found []*entity) []string {
+	var warnings []string
+	for _, slug := range slugs {
+		if !slices.ContainsFunc(found, func(e *entity) bool { return e.entityType == entityType && e.slug == slug }) {
+			warnings = append(warnings, fmt.Sprintf("%s %s not found", entityType, slug))
+		}
+	}
+	return warnings
+}
+
+// references returns the teams, buckets, notification policies and collections referenced
+// by the entity.
+func references(e *entity) []reference {
+	var refs []reference
+	add := func(entityType, slug string) {
+		if slug != "" {
+			refs = append(refs, reference{entityType, slug})
+		}
+	}
+	switch spec := e.spec.(type) {
+	case *models.Configv1Bucket:
+		add(entityTeam, spec.TeamSlug)
+		add(entityNotificationPolicy, spec.NotificationPolicySlug)
+	case *models.Configv1NotificationPolicy:
+		add(entityTeam, spec.TeamSlug)
+		add(entityBucket, spec.BucketSlug)
+	case *models.Configv1Collection:
+		add(entityTeam, spec.TeamSlug)
+		add(entityNotificationPolicy, spec.NotificationPolicySlug)
+	case *models.Configv1Dashboard:
+		add(entityCollection, collectionSlug(spec.Collection, spec.CollectionSlug))
+	case *models.Configv1Monitor:
+		add(entityCollection, collectionSlug(spec.Collection, spec.CollectionSlug))
+		add(entityBucket, spec.BucketSlug)
+		add(entityNotificationPolicy, spec.NotificationPolicySlug)
+	case *models.Configv1SLO:
+		add(entityCollection, collectionSlug(spec.CollectionRef, ""))
+		add(entityNotificationPolicy, spec.NotificationPolicySlug)
+	case *models.Configv1RecordingRule:
+		add(entityBucket, spec.BucketSlug)
+	case *models.Configv1RollupRule:
+		add(entityBucket, spec.BucketSlug)
+	case *models.Configv1MappingRule:
+		add(entityBucket, spec.BucketSlug)
+	}
+	return refs
+}
+
+// collectionSlug returns the collection an entity belongs to, or an empty string if it
+// belongs to a service, which can not be exported.
+func collectionSlug(ref *models.Configv1CollectionReference, slug string) string {
+	if ref != nil && ref.Slug != "" {
+		if ref.Type == models.Configv1CollectionReferenceTypeSERVICE {
+			return ""
+		}
+		return ref.Slug
+	}
+	return slug
+}
+
+// sortEntities orders entities by type, so that entities follow those they reference, then slug.
+func sortEntities(entities []*entity) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		ti, tj := slices.Index(entityTypes, entities[i].entityType), slices.Index(entityTypes, entities[j].entityType)
+		if ti != tj {
+			return ti < tj
+		}
+		return entities[i].slug < entities[j].slug
+	})
+}
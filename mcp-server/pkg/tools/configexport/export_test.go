@@ -0,0 +1,213 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configexport
+
+import (
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func testEntities() []*entity {
+	entities := []*entity{
+		{entityType: entityMonitor, slug: "api-errors", spec: &models.Configv1Monitor{
+			Slug:                   "api-errors",
+			Name:                   "API errors",
+			CollectionSlug:         "api",
+			NotificationPolicySlug: "api-oncall",
+			IntervalSecs:           60,
+			Labels:                 map[string]string{"env": "prod"},
+			PrometheusQuery:        `sum(rate(http_requests_total{code=~"5.."}[5m]))`,
+			SeriesConditions: &models.MonitorSeriesConditions{
+				Defaults: &models.SeriesConditionsSeverityConditions{
+					Critical: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+						{Op: models.ConditionOpGT, Value: 1.5, SustainSecs: 300},
+					}},
+				},
+			},
+			CreatedAt: strfmt.DateTime{},
+		}},
+		{entityType: entityCollection, slug: "api", dependency: true, spec: &models.Configv1Collection{
+			Slug: "api", Name: "API", TeamSlug: "platform",
+		}},
+		{entityType: entityTeam, slug: "platform", dependency: true, spec: &models.Configv1Team{
+			Slug: "platform", Name: "Platform", UserEmails: []string{"a@example.com"},
+		}},
+	}
+	sortEntities(entities)
+	return entities
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   *entity
+		expected []reference
+	}{
+		{
+			name: "monitor",
+			entity: &entity{spec: &models.Configv1Monitor{
+				CollectionSlug: "api", NotificationPolicySlug: "api-oncall",
+			}},
+			expected: []reference{{entityCollection, "api"}, {entityNotificationPolicy, "api-oncall"}},
+		},
+		{
+			name: "service monitor",
+			entity: &entity{spec: &models.Configv1Monitor{
+				Collection: &models.Configv1CollectionReference{Slug: "checkout", Type: models.Configv1CollectionReferenceTypeSERVICE},
+			}},
+		},
+		{
+			name:     "notification policy",
+			entity:   &entity{spec: &models.Configv1NotificationPolicy{TeamSlug: "platform", BucketSlug: "legacy"}},
+			expected: []reference{{entityTeam, "platform"}, {entityBucket, "legacy"}},
+		},
+		{
+			name:     "rollup rule",
+			entity:   &entity{spec: &models.Configv1RollupRule{BucketSlug: "platform"}},
+			expected: []reference{{entityBucket, "platform"}},
+		},
+		{
+			name:   "drop rule",
+			entity: &entity{spec: &models.Configv1DropRule{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, references(tt.entity))
+		})
+	}
+}
+
+func TestEncodeYAML(t *testing.T) {
+	content, err := encodeYAML(testEntities())
+	require.NoError(t, err)
+	require.Equal(t, `api_version: v1/config
+kind: Team
+spec:
+  name: Platform
+  slug: platform
+  user_emails:
+    - a@example.com
+---
+api_version: v1/config
+kind: Collection
+spec:
+  name: API
+  slug: api
+  team_slug: platform
+---
+api_version: v1/config
+kind: Monitor
+spec:
+  collection_slug: api
+  interval_secs: 60
+  labels:
+    env: prod
+  name: API errors
+  notification_policy_slug: api-oncall
+  prometheus_query: sum(rate(http_requests_total{code=~"5.."}[5m]))
+  series_conditions:
+    defaults:
+      critical:
+        conditions:
+          - op: GT
+            sustain_secs: 300
+            value: 1.5
+  slug: api-errors
+`, content)
+}
+
+func TestEncodeTerraform(t *testing.T) {
+	content, warnings := encodeTerraform(testEntities())
+	require.Equal(t, `resource "chronosphere_team" "platform" {
+  name        = "Platform"
+  slug        = "platform"
+  user_emails = ["a@example.com"]
+}
+
+resource "chronosphere_collection" "api" {
+  name    = "API"
+  slug    = "api"
+  team_id = chronosphere_team.platform.id
+}
+
+resource "chronosphere_monitor" "api-errors" {
+  name                   = "API errors"
+  slug                   = "api-errors"
+  collection_id          = chronosphere_collection.api.id
+  notification_policy_id = "api-oncall"
+  interval               = "1m"
+  labels                 = {
+    "env" = "prod"
+  }
+  query {
+    prometheus_expr = "sum(rate(http_requests_total{code=~\"5..\"}[5m]))"
+  }
+  series_conditions {
+    condition {
+      severity = "critical"
+      op       = "GT"
+      value    = 1.5
+      sustain  = "5m"
+    }
+  }
+}
+`, content)
+	require.Equal(t, []string{
+		"chronosphere_monitor.api-errors references notification_policy api-oncall which is not exported, its slug is used instead",
+	}, warnings)
+}
+
+func TestHCLString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "api", expected: `"api"`},
+		{name: "escapes", input: `say "hi"\n` + "\t", expected: `"say \"hi\"\\n\t"`},
+		{name: "interpolation", input: "${var} %{if}", expected: `"$${var} %%{if}"`},
+		{name: "multiple lines", input: "a\nb\n", expected: "<<EOT\na\nb\nEOT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, hclString(tt.input)(""))
+		})
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	require.Equal(t, "api-errors", resourceName("api-errors"))
+	require.Equal(t, "_5xx_rate", resourceName("5xx.rate"))
+}
+
+func TestResourceNames(t *testing.T) {
+	names := resourceNames([]*entity{
+		{entityType: entityMonitor, slug: "a.b"},
+		{entityType: entityMonitor, slug: "a_b"},
+		{entityType: entityMonitor, slug: "a-b"},
+		{entityType: entityDashboard, slug: "a_b"},
+	})
+	require.Equal(t, map[reference]string{
+		{entityMonitor, "a.b"}:   "a_b",
+		{entityMonitor, "a_b"}:   "a_b_2",
+		{entityMonitor, "a-b"}:   "a-b",
+		{entityDashboard, "a_b"}: "a_b",
+	}, names)
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configexport
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// hclIndent is the indentation of nested HCL bodies, matching terraform fmt.
+const hclIndent = "  "
+
+// hclValue renders an HCL expression. indent is the indentation of the line the expression
+// starts on, used by expressions spanning multiple lines.
+type hclValue func(indent string) string
+
+// hclBlock is an HCL block, such as a resource, with attributes and nested blocks in the order
+// they were added.
+type hclBlock struct {
+	typ    string
+	labels []string
+	items  []hclItem
+}
+
+// hclItem is either an attribute or a nested block.
+type hclItem struct {
+	name  string
+	value hclValue
+	block *hclBlock
+}
+
+func newHCLBlock(typ string, labels ...string) *hclBlock {
+	return &hclBlock{typ: typ, labels: labels}
+}
+
+// attr adds an attribute.
+func (b *hclBlock) attr(name string, value hclValue) {
+	b.items = append(b.items, hclItem{name: name, value: value})
+}
+
+// stringAttr adds a string attribute, unless the value is empty.
+func (b *hclBlock) stringAttr(name, value string) {
+	if value != "" {
+		b.attr(name, hclString(value))
+	}
+}
+
+// block adds and returns a nested block.
+func (b *hclBlock) block(typ string, labels ...string) *hclBlock {
+	nested := newHCLBlock(typ, labels...)
+	b.items = append(b.items, hclItem{block: nested})
+	return nested
+}
+
+// String renders the block.
+func (b *hclBlock) String() string {
+	var sb strings.Builder
+	b.write(&sb, "")
+	return sb.String()
+}
+
+func (b *hclBlock) write(sb *strings.Builder, indent string) {
+	sb.WriteString(indent)
+	sb.WriteString(b.typ)
+	for _, l := range b.labels {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Quote(l))
+	}
+	sb.WriteString(" {\n")
+
+	inner := indent + hclIndent
+	for i := 0; i < len(b.items); {
+		if nested := b.items[i].block; nested != nil {
+			nested.write(sb, inner)
+			i++
+			continue
+		}
+		// Align the equals signs of consecutive attributes like terraform fmt.
+		j, width := i, 0
+		for ; j < len(b.items) && b.items[j].block == nil; j++ {
+			width = max(width, len(b.items[j].name))
+		}
+		for _, item := range b.items[i:j] {
+			sb.WriteString(inner)
+			sb.WriteString(item.name)
+			sb.WriteString(strings.Repeat(" ", width-len(item.name)))
+			sb.WriteString(" = ")
+			sb.WriteString(item.value(inner))
+			sb.WriteString("\n")
+		}
+		i = j
+	}
+	sb.WriteString(indent)
+	sb.WriteString("}\n")
+}
+
+// hclString returns a string literal. Strings spanning multiple lines are written as heredocs.
+func hclString(s string) hclValue {
+	return func(string) string {
+		escaped := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+		if strings.Contains(s, "\n") {
+			return "<<EOT\n" + strings.TrimSuffix(escaped, "\n") + "\nEOT"
+		}
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", `\r`, "\t", `\t`).Replace(escaped) + `"`
+	}
+}
+
+// hclExpr returns an expression written as is, such as a reference to another resource.
+func hclExpr(expr string) hclValue {
+	return func(string) string { return expr }
+}
+
+func hclBool(b bool) hclValue {
+	return hclExpr(strconv.FormatBool(b))
+}
+
+func hclNumber(n float64) hclValue {
+	return hclExpr(strconv.FormatFloat(n, 'g', -1, 64))
+}
+
+// hclStrings returns a list of string literals.
+func hclStrings(ss []string) hclValue {
+	values := make([]hclValue, 0, len(ss))
+	for _, s := range ss {
+		values = append(values, hclString(s))
+	}
+	return hclList(values)
+}
+
+func hclList(values []hclValue) hclValue {
+	return func(indent string) string {
+		rendered := make([]string, 0, len(values))
+		for _, v := range values {
+			rendered = append(rendered, v(indent))
+		}
+		return "[" + strings.Join(rendered, ", ") + "]"
+	}
+}
+
+// hclMap returns an object with the keys and string values of the map, sorted by key.
+func hclMap(m map[string]string) hclValue {
+	return func(indent string) string {
+		if len(m) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(m))
+		width := 0
+		for k := range m {
+			keys = append(keys, k)
+			width = max(width, len(strconv.Quote(k)))
+		}
+		sort.Strings(keys)
+
+		var sb strings.Builder
+		sb.WriteString("{\n")
+		for _, k := range keys {
+			key := strconv.Quote(k)
+			sb.WriteString(indent + hclIndent)
+			sb.WriteString(key)
+			sb.WriteString(strings.Repeat(" ", width-len(key)))
+			sb.WriteString(" = ")
+			sb.WriteString(hclString(m[k])(indent + hclIndent))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(indent)
+		sb.WriteString("}")
+		return sb.String()
+	}
+}
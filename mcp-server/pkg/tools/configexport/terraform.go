@@ -0,0 +1,431 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+// terraformResourceTypes are the Chronosphere provider resource types of the entity types
+// that can be exported to Terraform.
+var terraformResourceTypes = map[string]string{
+	entityTeam:               "chronosphere_team",
+	entityBucket:             "chronosphere_bucket",
+	entityNotificationPolicy: "chronosphere_notification_policy",
+	entityCollection:         "chronosphere_collection",
+	entityDashboard:          "chronosphere_dashboard",
+	entityMonitor:            "chronosphere_monitor",
+	entityRecordingRule:      "chronosphere_recording_rule",
+}
+
+// terraformEntityTypes are the entity types that can be exported to Terraform.
+var terraformEntityTypes = []string{
+	entityTeam,
+	entityBucket,
+	entityNotificationPolicy,
+	entityCollection,
+	entityDashboard,
+	entityMonitor,
+	entityRecordingRule,
+}
+
+// terraformMatcherTypes are the Terraform names of label matcher types.
+var terraformMatcherTypes = map[models.Configv1LabelMatcherMatcherType]string{
+	models.Configv1LabelMatcherMatcherTypeEXACT: "EXACT_MATCHER",
+	models.Configv1LabelMatcherMatcherTypeREGEX: "REGEXP_MATCHER",
+}
+
+var invalidIdentifierRe = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// terraformEncoder converts entities to Terraform resources.
+type terraformEncoder struct {
+	// names holds the resource names of the entities being exported, which can be referenced
+	// by resource address.
+	names    map[reference]string
+	warnings []string
+}
+
+// encodeTerraform returns the entities as Terraform resources, and warnings for references
+// to entities that are not exported.
+func encodeTerraform(entities []*entity) (string, []string) {
+	enc := &terraformEncoder{names: resourceNames(entities)}
+
+	var blocks []string
+	for _, e := range entities {
+		var b *hclBlock
+		switch spec := e.spec.(type) {
+		case *models.Configv1Team:
+			b = enc.team(spec)
+		case *models.Configv1Bucket:
+			b = enc.bucket(spec)
+		case *models.Configv1NotificationPolicy:
+			b = enc.notificationPolicy(spec)
+		case *models.Configv1Collection:
+			b = enc.collection(spec)
+		case *models.Configv1Dashboard:
+			b = enc.dashboard(spec)
+		case *models.Configv1Monitor:
+			b = enc.monitor(spec)
+		case *models.Configv1RecordingRule:
+			b = enc.recordingRule(spec)
+		default:
+			enc.warnings = append(enc.warnings, fmt.Sprintf("%s %s can not be exported to terraform", e.entityType, e.slug))
+			continue
+		}
+		blocks = append(blocks, b.String())
+	}
+	return strings.Join(blocks, "\n"), enc.warnings
+}
+
+// resource returns a resource block for the entity, named after its slug.
+func (enc *terraformEncoder) resource(entityType, slug string) *hclBlock {
+	return newHCLBlock("resource", terraformResourceTypes[entityType], enc.names[reference{entityType, slug}])
+}
+
+// resourceNames returns unique resource names for the entities. Slugs that map to the same
+// resource name, such as "a.b" and "a_b", are told apart by a numeric suffix in export order.
+func resourceNames(entities []*entity) map[reference]string {
+	names := make(map[reference]string, len(entities))
+	used := make(map[string]bool, len(entities))
+	for _, e := range entities {
+		base := resourceName(e.slug)
+		name := base
+		for i := 2; used[e.entityType+"."+name]; i++ {
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+		used[e.entityType+"."+name] = true
+		names[reference{e.entityType, e.slug}] = name
+	}
+	return names
+}
+
+// resourceName returns a valid Terraform resource name for the slug.
+func resourceName(slug string) string {
+	name := invalidIdentifierRe.ReplaceAllString(slug, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+// ref returns a reference to the ID of an entity, or its slug if it is not exported.
+func (enc *terraformEncoder) ref(from *hclBlock, entityType, slug string) hclValue {
+	if name, ok := enc.names[reference{entityType, slug}]; ok {
+		return hclExpr(terraformResourceTypes[entityType] + "." + name + ".id")
+	}
+	enc.warnings = append(enc.warnings, fmt.Sprintf("%s.%s references %s %s which is not exported, its slug is used instead",
+		from.labels[0], from.labels[1], entityType, slug))
+	return hclString(slug)
+}
+
+// refAttr adds an attribute referencing an entity, unless the slug is empty.
+func (enc *terraformEncoder) refAttr(b *hclBlock, name, entityType, slug string) {
+	if slug != "" {
+		b.attr(name, enc.ref(b, entityType, slug))
+	}
+}
+
+func (enc *terraformEncoder) team(t *models.Configv1Team) *hclBlock {
+	b := enc.resource(entityTeam, t.Slug)
+	b.stringAttr("name", t.Name)
+	b.stringAttr("slug", t.Slug)
+	b.stringAttr("description", t.Description)
+	if len(t.UserEmails) > 0 {
+		b.attr("user_emails", hclStrings(t.UserEmails))
+	}
+	return b
+}
+
+func (enc *terraformEncoder) bucket(bucket *models.Configv1Bucket) *hclBlock {
+	b := enc.resource(entityBucket, bucket.Slug)
+	b.stringAttr("name", bucket.Name)
+	b.stringAttr("slug", bucket.Slug)
+	b.stringAttr("description", bucket.Description)
+	enc.refAttr(b, "team_id", entityTeam, bucket.TeamSlug)
+	enc.refAttr(b, "notification_policy_id", entityNotificationPolicy, bucket.NotificationPolicySlug)
+	if len(bucket.Labels) > 0 {
+		b.attr("labels", hclMap(bucket.Labels))
+	}
+	return b
+}
+
+func (enc *terraformEncoder) collection(c *models.Configv1Collection) *hclBlock {
+	b := enc.resource(entityCollection, c.Slug)
+	b.stringAttr("name", c.Name)
+	b.stringAttr("slug", c.Slug)
+	b.stringAttr("description", c.Description)
+	enc.refAttr(b, "team_id", entityTeam, c.TeamSlug)
+	enc.refAttr(b, "notification_policy_id", entityNotificationPolicy, c.NotificationPolicySlug)
+	return b
+}
+
+func (enc *terraformEncoder) notificationPolicy(p *models.Configv1NotificationPolicy) *hclBlock {
+	b := enc.resource(entityNotificationPolicy, p.Slug)
+	b.stringAttr("name", p.Name)
+	b.stringAttr("slug", p.Slug)
+	enc.refAttr(b, "team_id", entityTeam, p.TeamSlug)
+	if p.BucketSlug != "" {
+		enc.warnings = append(enc.warnings, fmt.Sprintf("%s.%s belongs to bucket %s, which is not supported by terraform and was dropped",
+			b.labels[0], b.labels[1], p.BucketSlug))
+	}
+	if p.Routes == nil {
+		return b
+	}
+	enc.routes(b, b, p.Routes.Defaults)
+	for _, o := range p.Routes.Overrides {
+		if o == nil {
+			continue
+		}
+		ob := b.block("override")
+		for _, m := range o.AlertLabelMatchers {
+			labelMatcher(ob, "alert_label_matcher", m)
+		}
+		enc.routes(b, ob, o.Notifiers)
+	}
+	return b
+}
+
+// routes adds a route block per severity.
+func (enc *terraformEncoder) routes(res, b *hclBlock, notifiers *models.RoutesSeverityNotifiers) {
+	if notifiers == nil {
+		return
+	}
+	for _, s := range []struct {
+		severity string
+		list     *models.RoutesNotifierList
+	}{
+		{"warn", notifiers.Warn},
+		{"critical", notifiers.Critical},
+	} {
+		if s.list == nil {
+			continue
+		}
+		rb := b.block("route")
+		rb.attr("severity", hclString(s.severity))
+		rb.attr("notifiers", hclStrings(s.list.NotifierSlugs))
+		for _, slug := range s.list.NotifierSlugs {
+			warning := fmt.Sprintf("%s.%s references notifier %s by slug", res.labels[0], res.labels[1], slug)
+			if !slices.Contains(enc.warnings, warning) {
+				enc.warnings = append(enc.warnings, warning)
+			}
+		}
+		if s.list.RepeatIntervalSecs > 0 {
+			rb.attr("repeat_interval", hclString(duration(s.list.RepeatIntervalSecs)))
+		}
+		if s.list.GroupBy != nil && len(s.list.GroupBy.LabelNames) > 0 {
+			rb.block("group_by").attr("label_names", hclStrings(s.list.GroupBy.LabelNames))
+		}
+	}
+}
+
+func (enc *terraformEncoder) dashboard(d *models.Configv1Dashboard) *hclBlock {
+	b := enc.resource(entityDashboard, d.Slug)
+	b.stringAttr("name", d.Name)
+	b.stringAttr("slug", d.Slug)
+	enc.collectionAttr(b, d.Collection, d.CollectionSlug)
+	if d.DashboardJSON != "" {
+		b.attr("dashboard_json", hclString(indentJSON(d.DashboardJSON)))
+	}
+	return b
+}
+
+// collectionAttr adds the collection_id of an entity belonging to a collection or service.
+func (enc *terraformEncoder) collectionAttr(b *hclBlock, ref *models.Configv1CollectionReference, slug string) {
+	if ref != nil && ref.Type == models.Configv1CollectionReferenceTypeSERVICE && ref.Slug != "" {
+		enc.warnings = append(enc.warnings, fmt.Sprintf("%s.%s belongs to service %s, its slug is used as collection_id",
+			b.labels[0], b.labels[1], ref.Slug))
+		b.attr("collection_id", hclString(ref.Slug))
+		return
+	}
+	enc.refAttr(b, "collection_id", entityCollection, collectionSlug(ref, slug))
+}
+
+func (enc *terraformEncoder) monitor(m *models.Configv1Monitor) *hclBlock {
+	b := enc.resource(entityMonitor, m.Slug)
+	b.stringAttr("name", m.Name)
+	b.stringAttr("slug", m.Slug)
+	enc.collectionAttr(b, m.Collection, m.CollectionSlug)
+	enc.refAttr(b, "bucket_id", entityBucket, m.BucketSlug)
+	enc.refAttr(b, "notification_policy_id", entityNotificationPolicy, m.NotificationPolicySlug)
+	if m.IntervalSecs > 0 {
+		b.attr("interval", hclString(duration(m.IntervalSecs)))
+	}
+	if len(m.Labels) > 0 {
+		b.attr("labels", hclMap(m.Labels))
+	}
+	if len(m.Annotations) > 0 {
+		b.attr("annotations", hclMap(m.Annotations))
+	}
+
+	qb := b.block("query")
+	qb.stringAttr("prometheus_expr", m.PrometheusQuery)
+	qb.stringAttr("graphite_expr", m.GraphiteQuery)
+	qb.stringAttr("logging_expr", m.LoggingQuery)
+
+	if g := m.SignalGrouping; g != nil && (len(g.LabelNames) > 0 || g.SignalPerSeries) {
+		gb := b.block("signal_grouping")
+		if len(g.LabelNames) > 0 {
+			gb.attr("label_names", hclStrings(g.LabelNames))
+		}
+		if g.SignalPerSeries {
+			gb.attr("signal_per_series", hclBool(true))
+		}
+	}
+
+	if sc := m.SeriesConditions; sc != nil {
+		sb := b.block("series_conditions")
+		conditions(sb, sc.Defaults)
+		for _, o := range sc.Overrides {
+			if o == nil {
+				continue
+			}
+			ob := sb.block("override")
+			for _, lm := range o.LabelMatchers {
+				labelMatcher(ob, "label_matcher", lm)
+			}
+			conditions(ob, o.SeverityConditions)
+		}
+	}
+
+	if s := m.Schedule; s != nil && s.WeeklySchedule != nil {
+		sb := b.block("schedule")
+		sb.stringAttr("timezone", s.Timezone)
+		w := s.WeeklySchedule
+		for _, day := range []struct {
+			name string
+			day  *models.ScheduleScheduleDay
+		}{
+			{"monday", w.Monday},
+			{"tuesday", w.Tuesday},
+			{"wednesday", w.Wednesday},
+			{"thursday", w.Thursday},
+			{"friday", w.Friday},
+			{"saturday", w.Saturday},
+			{"sunday", w.Sunday},
+		} {
+			if day.day == nil {
+				continue
+			}
+			switch day.day.Active {
+			case models.ScheduleDayActiveALLDAY:
+				scheduleRange(sb, day.name, "00:00", "24:00")
+			case models.ScheduleDayActiveONLYDURINGRANGES:
+				for _, r := range day.day.Ranges {
+					if r != nil {
+						scheduleRange(sb, day.name, r.StartHhMm, r.EndHhMm)
+					}
+				}
+			}
+		}
+	}
+	return b
+}
+
+// conditions adds a condition block per severity condition.
+func conditions(b *hclBlock, sc *models.SeriesConditionsSeverityConditions) {
+	if sc == nil {
+		return
+	}
+	for _, s := range []struct {
+		severity   string
+		conditions *models.SeriesConditionsConditions
+	}{
+		{"warn", sc.Warn},
+		{"critical", sc.Critical},
+	} {
+		if s.conditions == nil {
+			continue
+		}
+		for _, c := range s.conditions.Conditions {
+			if c == nil {
+				continue
+			}
+			cb := b.block("condition")
+			cb.attr("severity", hclString(s.severity))
+			cb.attr("op", hclString(string(c.Op)))
+			cb.attr("value", hclNumber(c.Value))
+			if c.SustainSecs > 0 {
+				cb.attr("sustain", hclString(duration(c.SustainSecs)))
+			}
+			if c.ResolveSustainSecs > 0 {
+				cb.attr("resolve_sustain", hclString(duration(c.ResolveSustainSecs)))
+			}
+		}
+	}
+}
+
+func labelMatcher(b *hclBlock, typ string, m *models.Configv1LabelMatcher) {
+	if m == nil {
+		return
+	}
+	mb := b.block(typ)
+	mb.attr("name", hclString(m.Name))
+	mb.attr("type", hclString(terraformMatcherTypes[m.Type]))
+	mb.attr("value", hclString(m.Value))
+}
+
+func scheduleRange(b *hclBlock, day, start, end string) {
+	rb := b.block("range")
+	rb.attr("day", hclString(day))
+	rb.attr("start", hclString(start))
+	rb.attr("end", hclString(end))
+}
+
+func (enc *terraformEncoder) recordingRule(r *models.Configv1RecordingRule) *hclBlock {
+	b := enc.resource(entityRecordingRule, r.Slug)
+	b.stringAttr("name", r.Name)
+	b.stringAttr("slug", r.Slug)
+	enc.refAttr(b, "bucket_id", entityBucket, r.BucketSlug)
+	b.stringAttr("execution_group", r.ExecutionGroup)
+	if r.IntervalSecs > 0 {
+		b.attr("interval", hclString(duration(r.IntervalSecs)))
+	}
+	b.stringAttr("metric_name", r.MetricName)
+	b.stringAttr("expr", r.PrometheusExpr)
+	if r.LabelPolicy != nil && len(r.LabelPolicy.Add) > 0 {
+		b.attr("labels", hclMap(r.LabelPolicy.Add))
+	}
+	return b
+}
+
+// duration formats seconds as a duration in the largest whole unit, e.g. 1h, 5m or 90s.
+func duration(secs int32) string {
+	switch {
+	case secs%3600 == 0:
+		return strconv.Itoa(int(secs/3600)) + "h"
+	case secs%60 == 0:
+		return strconv.Itoa(int(secs/60)) + "m"
+	default:
+		return strconv.Itoa(int(secs)) + "s"
+	}
+}
+
+// indentJSON pretty prints JSON so it can be reviewed and diffed, returning it unchanged if
+// it is not valid JSON.
+func indentJSON(s string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "  "); err != nil {
+		return s
+	}
+	return buf.String()
+}
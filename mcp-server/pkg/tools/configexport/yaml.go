@@ -0,0 +1,66 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configexport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chronosphereio/chronoctl-core/src/types"
+
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/snapshot"
+)
+
+// chronoctlAPIVersion is the api_version of config entities in chronoctl YAML.
+const chronoctlAPIVersion = "v1/config"
+
+// chronoctlKinds are the chronoctl kinds of each entity type.
+var chronoctlKinds = map[string]string{
+	entityTeam:               "Team",
+	entityBucket:             "Bucket",
+	entityNotificationPolicy: "NotificationPolicy",
+	entityCollection:         "Collection",
+	entityDashboard:          "Dashboard",
+	entityMonitor:            "Monitor",
+	entitySLO:                "SLO",
+	entityRecordingRule:      "RecordingRule",
+	entityDropRule:           "DropRule",
+	entityRollupRule:         "RollupRule",
+	entityMappingRule:        "MappingRule",
+}
+
+// encodeYAML returns the entities as a multi-document chronoctl YAML file.
+func encodeYAML(entities []*entity) (string, error) {
+	var sb strings.Builder
+	for i, e := range entities {
+		spec, err := snapshot.Fields(e.spec)
+		if err != nil {
+			return "", fmt.Errorf("failed to export %s %s: %s", e.entityType, e.slug, err)
+		}
+		doc, err := types.EncodeYAML(map[string]any{
+			"api_version": chronoctlAPIVersion,
+			"kind":        chronoctlKinds[e.entityType],
+			"spec":        spec,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to export %s %s: %s", e.entityType, e.slug, err)
+		}
+		if i > 0 {
+			sb.WriteString("---\n")
+		}
+		sb.Write(doc)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configsearch provides tools for searching across all config entities.
+package configsearch
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
+)
+
+var _ tools.MCPTools = (*Tools)(nil)
+
+// Tools provides MCP tools for searching config.
+type Tools struct {
+	logger      *zap.Logger
+	configAPI   *configv1.ConfigV1API
+	linkBuilder *links.Builder
+	index       *indexCache
+}
+
+// NewTools creates new config search tools.
+func NewTools(
+	configAPI *configv1.ConfigV1API,
+	logger *zap.Logger,
+	linkBuilder *links.Builder,
+	lc fx.Lifecycle,
+) (*Tools, error) {
+	logger.Info("config search tool configured")
+
+	t := &Tools{
+		logger:      logger,
+		configAPI:   configAPI,
+		linkBuilder: linkBuilder,
+	}
+	t.index = newIndexCache(logger, t.buildIndex)
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			t.index.stop(ctx)
+			return nil
+		},
+	})
+	return t, nil
+}
+
+func (t *Tools) GroupName() string {
+	return "configsearch"
+}
+
+func (t *Tools) MCPTools() []tools.MCPTool {
+	return []tools.MCPTool{
+		{
+			Metadata: tools.NewMetadata("find_metric_references",
+				mcp.WithDescription(`Finds every config entity that references a metric or label. Use this before renaming or dropping a metric or label to see what would break.
+
+Monitors, dashboards, Grafana dashboards, recording rules, SLOs, drop rules, rollup rules and mapping rules are searched. PromQL queries are parsed, so a query references a metric if any of its selectors could select it, including regular expressions on __name__, and a label if it is used in a selector, grouping or vector matching clause. Rule filters reference a metric through their __name__ filter and a label by filtering on it. Recording and rollup rules also reference the metric they produce and the labels of their label policy. Monitors also reference a label through their signal grouping and the label matchers of their series condition overrides.
+
+If both metric and label are given, only entities referencing the metric and the label are returned.
+
+Response fields:
+- references: Per entity the entity type, slug, name, owner (collection, service or bucket), owning team, link, the fields referencing the metric or label and the matching queries or filters
+- counts: Number of references per entity type
+- warnings: Entities that could not be searched, e.g. dashboards whose JSON could not be parsed`),
+				mcp.WithString("metric",
+					mcp.Description("Metric name to find references to, e.g. http_requests_total."),
+				),
+				mcp.WithString("label",
+					mcp.Description("Label name to find references to, e.g. pod."),
+				),
+				params.WithStringArray("entity_types",
+					mcp.Description("Entity types to search. Defaults to all of monitor, dashboard, grafana_dashboard, recording_rule, slo, drop_rule, rollup_rule and mapping_rule."),
+				),
+			),
+			Handler: t.findMetricReferences,
+		},
+		{
+			Metadata: tools.NewMetadata("search_config",
+				mcp.WithDescription(`Searches config entities by free text, labels and owning team. Use this to find config when the exact slug is not known, e.g. "checkout latency" or every monitor owned by a team.
+
+Monitors, dashboards, Grafana dashboards, SLOs, recording rules, drop rules, rollup rules, mapping rules, notification policies, collections and teams are searched. Every word of the query must match the name, slug, labels, owning team or content (queries, filters, annotations and descriptions) of an entity. Results are ranked by where the words matched, with name matches ranked highest.
+
+The index is built on first use and refreshed in the background every few minutes, so recently changed config may not be found until the next refresh. Set refresh to rebuild the index before searching.
+
+Response fields:
+- results: Per entity the entity type, slug, name, owning team, owner (collection, service or bucket), labels, score, the fields that matched and a link
+- total: Number of matching entities, before applying the limit
+- indexed_at: When the index was last built`),
+				mcp.WithString("query",
+					mcp.Description("Free text to search for, e.g. checkout latency."),
+				),
+				params.WithStringArray("entity_types",
+					mcp.Description("Entity types to search. Defaults to all of monitor, dashboard, grafana_dashboard, recording_rule, slo, drop_rule, rollup_rule, mapping_rule, notification_policy, collection and team."),
+				),
+				params.WithStringArray("team_slugs",
+					mcp.Description("Only return entities owned by one of these teams."),
+				),
+				params.WithStringArray("labels",
+					mcp.Description("Only return entities with labels matching all of these filters, in the form name:value_glob, e.g. env:prod*."),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description("Maximum number of results to return. Defaults to 20."),
+				),
+				mcp.WithBoolean("refresh",
+					mcp.Description("Rebuild the index before searching. Defaults to false."),
+				),
+			),
+			Handler: t.searchConfig,
+		},
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsearch
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/authcontext"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configowner"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+)
+
+const (
+	// indexRefreshInterval is how often indexes are rebuilt in the background.
+	indexRefreshInterval = 5 * time.Minute
+	// indexIdleTimeout is how long an index is kept refreshing without being searched.
+	indexIdleTimeout = time.Hour
+	// indexBuildTimeout bounds a single index build.
+	indexBuildTimeout = 2 * time.Minute
+)
+
+// Score weights of a search term matching a document field.
+const (
+	scoreExactName = 10
+	scoreName      = 3
+	scoreSlug      = 2
+	scoreLabel     = 2
+	scoreTeam      = 2
+	scoreText      = 1
+)
+
+// document is an indexed config entity.
+type document struct {
+	entityType  string
+	slug        string
+	name        string
+	description string
+	teamSlug    string
+	owner       configowner.Owner
+	labels      map[string]string
+	// text holds queries, filters and other content of the entity.
+	text []string
+
+	nameTokens  []string
+	slugLower   string
+	labelTokens []string
+	textLower   string
+}
+
+func newDocument(d *document) *document {
+	d.nameTokens = tokenize(d.name)
+	d.slugLower = strings.ToLower(d.slug)
+	for k, v := range d.labels {
+		d.labelTokens = append(d.labelTokens, tokenize(k)...)
+		d.labelTokens = append(d.labelTokens, tokenize(v)...)
+	}
+	d.textLower = strings.ToLower(d.description + "\n" + strings.Join(d.text, "\n"))
+	return d
+}
+
+// searchQuery is a parsed search.
+type searchQuery struct {
+	terms       []string
+	phrase      string
+	entityTypes []string
+	teamSlugs   []string
+	labels      filters.Filters
+}
+
+// match is a document matching a search, with its score and the fields that matched.
+type match struct {
+	doc    *document
+	score  int
+	fields []string
+}
+
+// search returns the documents matching the query, best first. Every search term must match
+// a field of a document; documents are then ranked by where the terms matched.
+func search(docs []*document, q searchQuery) []*match {
+	var matches []*match
+	for _, d := range docs {
+		if len(q.entityTypes) > 0 && !slices.Contains(q.entityTypes, d.entityType) {
+			continue
+		}
+		if len(q.teamSlugs) > 0 && !slices.Contains(q.teamSlugs, d.teamSlug) {
+			continue
+		}
+		if len(q.labels) > 0 && !q.labels.Matches(d.labels) {
+			continue
+		}
+		m, ok := scoreDocument(d, q)
+		if ok {
+			matches = append(matches, m)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].doc.entityType != matches[j].doc.entityType {
+			return matches[i].doc.entityType < matches[j].doc.entityType
+		}
+		return matches[i].doc.slug < matches[j].doc.slug
+	})
+	return matches
+}
+
+func scoreDocument(d *document, q searchQuery) (*match, bool) {
+	m := &match{doc: d}
+	addField := func(field string, score int) {
+		m.score += score
+		if !slices.Contains(m.fields, field) {
+			m.fields = append(m.fields, field)
+		}
+	}
+	if q.phrase != "" && (strings.EqualFold(d.name, q.phrase) || strings.EqualFold(d.slug, q.phrase)) {
+		addField("name", scoreExactName)
+	}
+	for _, term := range q.terms {
+		matched := false
+		if slices.Contains(d.nameTokens, term) {
+			addField("name", scoreName)
+			matched = true
+		} else if strings.Contains(strings.ToLower(d.name), term) {
+			addField("name", scoreName-1)
+			matched = true
+		}
+		if strings.Contains(d.slugLower, term) {
+			addField("slug", scoreSlug)
+			matched = true
+		}
+		if slices.Contains(d.labelTokens, term) {
+			addField("labels", scoreLabel)
+			matched = true
+		}
+		if d.teamSlug != "" && strings.Contains(d.teamSlug, term) {
+			addField("team", scoreTeam)
+			matched = true
+		}
+		if strings.Contains(d.textLower, term) {
+			addField("content", scoreText)
+			matched = true
+		}
+		if !matched {
+			return nil, false
+		}
+	}
+	return m, true
+}
+
+// tokenize splits text into lower case words, splitting on anything but letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// configIndex is an index of config entities, replaced as a whole on refresh.
+type configIndex struct {
+	mu       sync.RWMutex
+	docs     []*document
+	warnings []string
+	builtAt  time.Time
+	lastUsed time.Time
+}
+
+func (i *configIndex) set(docs []*document, warnings []string, now time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.docs, i.warnings, i.builtAt = docs, warnings, now
+}
+
+// snapshot returns the current documents and marks the index as used.
+func (i *configIndex) snapshot(now time.Time) ([]*document, []string, time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.lastUsed = now
+	return i.docs, i.warnings, i.builtAt
+}
+
+func (i *configIndex) idleSince(now time.Time) time.Duration {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return now.Sub(i.lastUsed)
+}
+
+// buildFunc lists the config entities to index, returning warnings for entity types that
+// could not be listed.
+type buildFunc func(ctx context.Context) ([]*document, []string, error)
+
+// indexCache holds one index per set of session credentials, since users forwarding their
+// own API tokens may not see the same config. Indexes are built on first use and refreshed
+// in the background until they have not been used for indexIdleTimeout or the cache is
+// stopped.
+type indexCache struct {
+	logger *zap.Logger
+	build  buildFunc
+	now    func() time.Time
+
+	refreshInterval time.Duration
+	idleTimeout     time.Duration
+
+	// ctx is cancelled when the cache is stopped, ending the refresh loops.
+	ctx       context.Context
+	cancel    context.CancelFunc
+	refreshes sync.WaitGroup
+
+	mu      sync.Mutex
+	entries map[authcontext.SessionCredentials]*indexEntry
+}
+
+type indexEntry struct {
+	ready chan struct{}
+	index *configIndex
+	err   error
+}
+
+func newIndexCache(logger *zap.Logger, build buildFunc) *indexCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &indexCache{
+		logger:          logger,
+		build:           build,
+		now:             time.Now,
+		refreshInterval: indexRefreshInterval,
+		idleTimeout:     indexIdleTimeout,
+		ctx:             ctx,
+		cancel:          cancel,
+		entries:         make(map[authcontext.SessionCredentials]*indexEntry),
+	}
+}
+
+// stop ends the refresh loops and waits for them to return, or for ctx to be done.
+func (c *indexCache) stop(ctx context.Context) {
+	c.cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.refreshes.Wait()
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// get returns the index for the credentials of the context, building it if needed. If
+// refresh is set, the index is rebuilt before returning.
+func (c *indexCache) get(ctx context.Context, refresh bool) (*configIndex, error) {
+	key := authcontext.FetchSessionAPIToken(ctx)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &indexEntry{ready: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		entry.index, entry.err = c.buildIndex(ctx, &configIndex{})
+		if entry.err != nil {
+			// Forget the failed build so the next search retries.
+			c.mu.Lock()
+			delete(c.entries, key)
+			c.mu.Unlock()
+		} else {
+			// The refresh loop outlives the request, so it runs until the cache is stopped
+			// with the credentials of the request.
+			c.refreshes.Add(1)
+			go func() {
+				defer c.refreshes.Done()
+				c.refreshLoop(authcontext.SetSessionCredentials(c.ctx, key), key, entry.index)
+			}()
+		}
+		close(entry.ready)
+		return entry.index, entry.err
+	}
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	if refresh {
+		return c.buildIndex(ctx, entry.index)
+	}
+	return entry.index, nil
+}
+
+func (c *indexCache) buildIndex(ctx context.Context, idx *configIndex) (*configIndex, error) {
+	ctx, cancel := context.WithTimeout(ctx, indexBuildTimeout)
+	defer cancel()
+	docs, warnings, err := c.build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx.set(docs, warnings, c.now())
+	return idx, nil
+}
+
+func (c *indexCache) refreshLoop(ctx context.Context, key authcontext.SessionCredentials, idx *configIndex) {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if idx.idleSince(c.now()) > c.idleTimeout {
+			c.mu.Lock()
+			delete(c.entries, key)
+			c.mu.Unlock()
+			return
+		}
+		if _, err := c.buildIndex(ctx, idx); err != nil {
+			c.logger.Warn("failed to refresh config search index", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsearch
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/authcontext"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+)
+
+func testDocuments() []*document {
+	return entityDocuments(
+		testEntities(),
+		[]*models.Configv1Collection{{Slug: "api", Name: "API", TeamSlug: "platform"}},
+		[]*models.Configv1Team{{Slug: "platform", Name: "Platform", Description: "Owns the API gateway"}},
+		[]*models.Configv1NotificationPolicy{{Slug: "api-oncall", Name: "API on-call", TeamSlug: "platform"}},
+	)
+}
+
+func TestSearch(t *testing.T) {
+	docs := testDocuments()
+	docs[0].labels = map[string]string{"env": "prod"}
+
+	type result struct {
+		entityType, slug string
+	}
+	tests := []struct {
+		name     string
+		query    searchQuery
+		expected []result
+	}{
+		{
+			name:  "exact name ranks first",
+			query: searchQuery{terms: tokenize("API"), phrase: "API"},
+			expected: []result{
+				{entityCollection, "api"},
+				{entityDashboard, "api"},
+				{entityMonitor, "api-errors"},
+				{entityNotificationPolicy, "api-oncall"},
+				{entitySLO, "api-availability"},
+				{entityTeam, "platform"},
+			},
+		},
+		{
+			name:     "all terms must match",
+			query:    searchQuery{terms: tokenize("api errors")},
+			expected: []result{{entityMonitor, "api-errors"}},
+		},
+		{
+			name:  "content",
+			query: searchQuery{terms: tokenize("http_requests_total")},
+			expected: []result{
+				{entityRecordingRule, "http-rate"},
+				{entityRollupRule, "http-by-service"},
+				{entityDashboard, "api"},
+				{entityGrafanaDashboard, "gateway"},
+				{entityMonitor, "api-errors"},
+				{entitySLO, "api-availability"},
+			},
+		},
+		{
+			name:     "entity types",
+			query:    searchQuery{terms: tokenize("api"), entityTypes: []string{entityNotificationPolicy}},
+			expected: []result{{entityNotificationPolicy, "api-oncall"}},
+		},
+		{
+			name:  "teams",
+			query: searchQuery{teamSlugs: []string{"platform"}, entityTypes: []string{entityNotificationPolicy, entityTeam}},
+			expected: []result{
+				{entityNotificationPolicy, "api-oncall"},
+				{entityTeam, "platform"},
+			},
+		},
+		{
+			name:     "labels",
+			query:    searchQuery{labels: filters.Filters{mustParseFilter(t, "env:pr*")}},
+			expected: []result{{entityMonitor, "api-errors"}},
+		},
+		{
+			name:  "no matches",
+			query: searchQuery{terms: tokenize("kafka")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual []result
+			for _, m := range search(docs, tt.query) {
+				actual = append(actual, result{m.doc.entityType, m.doc.slug})
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestSearchMatchedFields(t *testing.T) {
+	matches := search(testDocuments(), searchQuery{terms: tokenize("api"), phrase: "api", entityTypes: []string{entityMonitor}})
+	require.Len(t, matches, 1)
+	require.Equal(t, []string{"name", "slug"}, matches[0].fields)
+	require.Equal(t, scoreName+scoreSlug, matches[0].score)
+}
+
+func TestIndexCache(t *testing.T) {
+	builds := map[authcontext.SessionCredentials]int{}
+	fail := false
+	c := newIndexCache(zap.NewNop(), func(ctx context.Context) ([]*document, []string, error) {
+		if fail {
+			return nil, nil, errors.New("unavailable")
+		}
+		key := authcontext.FetchSessionAPIToken(ctx)
+		builds[key]++
+		return []*document{newDocument(&document{entityType: entityTeam, slug: key.APIToken})}, nil, nil
+	})
+	c.refreshInterval = 24 * time.Hour
+
+	alice := authcontext.SetSessionCredentials(context.Background(), authcontext.SessionCredentials{APIToken: "alice"})
+	bob := authcontext.SetSessionCredentials(context.Background(), authcontext.SessionCredentials{APIToken: "bob"})
+
+	slugs := func(ctx context.Context, refresh bool) []string {
+		idx, err := c.get(ctx, refresh)
+		require.NoError(t, err)
+		docs, _, _ := idx.snapshot(time.Now())
+		var out []string
+		for _, d := range docs {
+			out = append(out, d.slug)
+		}
+		return out
+	}
+
+	require.Equal(t, []string{"alice"}, slugs(alice, false))
+	require.Equal(t, []string{"alice"}, slugs(alice, false))
+	require.Equal(t, []string{"bob"}, slugs(bob, false))
+	require.Equal(t, 1, builds[authcontext.SessionCredentials{APIToken: "alice"}])
+
+	require.Equal(t, []string{"alice"}, slugs(alice, true))
+	require.Equal(t, 2, builds[authcontext.SessionCredentials{APIToken: "alice"}])
+
+	fail = true
+	carol := authcontext.SetSessionCredentials(context.Background(), authcontext.SessionCredentials{APIToken: "carol"})
+	_, err := c.get(carol, false)
+	require.Error(t, err)
+	fail = false
+	require.Equal(t, []string{"carol"}, slugs(carol, false))
+}
+
+func TestIndexCacheStop(t *testing.T) {
+	var builds atomic.Int32
+	c := newIndexCache(zap.NewNop(), func(ctx context.Context) ([]*document, []string, error) {
+		builds.Add(1)
+		return nil, nil, nil
+	})
+	c.refreshInterval = time.Millisecond
+	c.idleTimeout = math.MaxInt64
+
+	alice := authcontext.SetSessionCredentials(context.Background(), authcontext.SessionCredentials{APIToken: "alice"})
+	_, err := c.get(alice, false)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return builds.Load() > 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.stop(ctx)
+	require.NoError(t, ctx.Err())
+	stopped := builds.Load()
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, stopped, builds.Load())
+}
+
+func mustParseFilter(t *testing.T, s string) filters.Filter {
+	fs, err := filters.ParseStrings([]string{s})
+	require.NoError(t, err)
+	return fs[0]
+}
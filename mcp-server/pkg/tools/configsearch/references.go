@@ -0,0 +1,465 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsearch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/service"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configowner"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+)
+
+// Entity types that can reference metrics.
+const (
+	entityMonitor          = "monitor"
+	entityDashboard        = "dashboard"
+	entityGrafanaDashboard = "grafana_dashboard"
+	entityRecordingRule    = "recording_rule"
+	entitySLO              = "slo"
+	entityDropRule         = "drop_rule"
+	entityRollupRule       = "rollup_rule"
+	entityMappingRule      = "mapping_rule"
+)
+
+// maxQueriesPerReference bounds the matching queries returned per entity, since dashboards
+// can have many.
+const maxQueriesPerReference = 5
+
+// entityTypes are the searchable entity types in the order references are returned.
+var entityTypes = []string{
+	entityMonitor,
+	entityDashboard,
+	entityGrafanaDashboard,
+	entityRecordingRule,
+	entitySLO,
+	entityDropRule,
+	entityRollupRule,
+	entityMappingRule,
+}
+
+var (
+	// sliTemplateActionRe matches the template actions of SLI query templates.
+	sliTemplateActionRe = regexp.MustCompile(`\{\{[^}]*\}\}`)
+	sliWindowRe         = regexp.MustCompile(`\.Window\b`)
+)
+
+// MetricReferences are the config entities referencing a metric or label.
+type MetricReferences struct {
+	Metric     string         `json:"metric,omitempty"`
+	Label      string         `json:"label,omitempty"`
+	Total      int            `json:"total"`
+	Counts     map[string]int `json:"counts"`
+	References []*Reference   `json:"references"`
+	Warnings   []string       `json:"warnings,omitempty"`
+}
+
+// Reference is a config entity referencing a metric or label.
+type Reference struct {
+	EntityType string `json:"entity_type"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name,omitempty"`
+	// Owner is the collection, service or bucket owning the entity, e.g. collection:payments.
+	Owner    string   `json:"owner,omitempty"`
+	TeamSlug string   `json:"team_slug,omitempty"`
+	Link     string   `json:"link,omitempty"`
+	Fields   []string `json:"fields"`
+	Queries  []string `json:"queries,omitempty"`
+
+	owner configowner.Owner
+}
+
+// referenceQuery is what to find references to.
+type referenceQuery struct {
+	metric string
+	label  string
+	// labelRe matches the label as a word, used for queries that can not be parsed.
+	labelRe *regexp.Regexp
+}
+
+// configEntities are the config entities to search.
+type configEntities struct {
+	monitors          []*models.Configv1Monitor
+	dashboards        []*models.Configv1Dashboard
+	grafanaDashboards []*models.Configv1GrafanaDashboard
+	recordingRules    []*models.Configv1RecordingRule
+	slos              []*models.Configv1SLO
+	dropRules         []*models.Configv1DropRule
+	rollupRules       []*models.Configv1RollupRule
+	mappingRules      []*models.Configv1MappingRule
+}
+
+func (t *Tools) findMetricReferences(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	metric, err := params.String(request, "metric", false, "")
+	if err != nil {
+		return nil, err
+	}
+	label, err := params.String(request, "label", false, "")
+	if err != nil {
+		return nil, err
+	}
+	types, err := params.StringArray(request, "entity_types", false, entityTypes)
+	if err != nil {
+		return nil, err
+	}
+	if metric == "" && label == "" {
+		return nil, fmt.Errorf("at least one of metric or label must be set")
+	}
+	for _, typ := range types {
+		if !slices.Contains(entityTypes, typ) {
+			return nil, fmt.Errorf("unknown entity type %q, must be one of %v", typ, entityTypes)
+		}
+	}
+
+	result := &MetricReferences{Metric: metric, Label: label, Counts: make(map[string]int)}
+	entities := t.listEntities(ctx, types, &result.Warnings)
+	references, warnings := findReferences(newReferenceQuery(metric, label), entities)
+	result.References = references
+	result.Warnings = append(result.Warnings, warnings...)
+
+	owners := newTeamResolver(ctx, t)
+	for _, ref := range result.References {
+		ref.TeamSlug = owners.team(ref.owner)
+		ref.Link = t.linkBuilder.ConfigEntity(ref.EntityType, ref.Slug)
+		result.Counts[ref.EntityType]++
+	}
+	result.Total = len(result.References)
+	result.Warnings = append(result.Warnings, owners.warnings...)
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// listEntities lists the entities of the given types. Entity types that fail to list are
+// reported as warnings, so one failing endpoint does not hide every other reference.
+func (t *Tools) listEntities(ctx context.Context, types []string, warnings *[]string) *configEntities {
+	var (
+		e   = &configEntities{}
+		err error
+	)
+	for _, typ := range types {
+		switch typ {
+		case entityMonitor:
+			e.monitors, err = configlist.Monitors(ctx, t.configAPI, configlist.Filter{})
+		case entityDashboard:
+			e.dashboards, err = configlist.Dashboards(ctx, t.configAPI, configlist.Filter{})
+		case entityGrafanaDashboard:
+			e.grafanaDashboards, err = configlist.GrafanaDashboards(ctx, t.configAPI, configlist.Filter{})
+		case entityRecordingRule:
+			e.recordingRules, err = configlist.RecordingRules(ctx, t.configAPI, configlist.Filter{})
+		case entitySLO:
+			e.slos, err = configlist.SLOs(ctx, t.configAPI, configlist.Filter{})
+		case entityDropRule:
+			e.dropRules, err = configlist.DropRules(ctx, t.configAPI, configlist.Filter{})
+		case entityRollupRule:
+			e.rollupRules, err = configlist.RollupRules(ctx, t.configAPI, configlist.Filter{})
+		case entityMappingRule:
+			e.mappingRules, err = configlist.MappingRules(ctx, t.configAPI, configlist.Filter{})
+		}
+		if err != nil {
+			*warnings = append(*warnings, fmt.Sprintf("failed to list %s entities: %s", typ, err))
+		}
+	}
+	return e
+}
+
+func newReferenceQuery(metric, label string) referenceQuery {
+	q := referenceQuery{metric: metric, label: label}
+	if label != "" {
+		q.labelRe = regexp.MustCompile(`\b` + regexp.QuoteMeta(label) + `\b`)
+	}
+	return q
+}
+
+// findReferences returns the entities referencing the metric or label, in entity type order
+// and sorted by slug within each type, and warnings for entities that could not be searched.
+func findReferences(q referenceQuery, e *configEntities) ([]*Reference, []string) {
+	var (
+		refs     []*Reference
+		warnings []string
+	)
+	addQueries := func(ref *Reference, field string, queries ...string) {
+		for _, query := range queries {
+			if query == "" || !q.matchesQuery(query) {
+				continue
+			}
+			if !slices.Contains(ref.Fields, field) {
+				ref.Fields = append(ref.Fields, field)
+			}
+			if len(ref.Queries) < maxQueriesPerReference {
+				ref.Queries = append(ref.Queries, query)
+			}
+		}
+	}
+	collect := func(ref *Reference) {
+		if len(ref.Fields) > 0 {
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, m := range e.monitors {
+		ref := newReference(entityMonitor, m.Slug, m.Name, configowner.Of(m.Collection, m.CollectionSlug, m.BucketSlug))
+		addQueries(ref, "prometheus_query", m.PrometheusQuery)
+		q.matchMonitorLabels(ref, m)
+		collect(ref)
+	}
+	for _, d := range e.dashboards {
+		ref := newReference(entityDashboard, d.Slug, d.Name, configowner.Of(d.Collection, d.CollectionSlug, ""))
+		queries, err := dashboards.ExtractQueries(d.DashboardJSON)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to parse %s %s, so it was not searched: %s", entityDashboard, d.Slug, err))
+			continue
+		}
+		addQueries(ref, "dashboard_json", queries...)
+		collect(ref)
+	}
+	for _, d := range e.grafanaDashboards {
+		ref := newReference(entityGrafanaDashboard, d.Slug, d.Name, configowner.Of(d.Collection, d.CollectionSlug, d.BucketSlug))
+		queries, err := dashboards.ExtractGrafanaQueries(d.DashboardJSON)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to parse %s %s, so it was not searched: %s", entityGrafanaDashboard, d.Slug, err))
+			continue
+		}
+		addQueries(ref, "dashboard_json", queries...)
+		collect(ref)
+	}
+	for _, r := range e.recordingRules {
+		ref := newReference(entityRecordingRule, r.Slug, r.Name, configowner.Owner{Kind: configowner.KindBucket, Slug: r.BucketSlug})
+		var labelPolicy []string
+		if r.LabelPolicy != nil {
+			for l := range r.LabelPolicy.Add {
+				labelPolicy = append(labelPolicy, l)
+			}
+		}
+		q.matchRule(ref, nil, r.MetricName, labelPolicy)
+		addQueries(ref, "prometheus_expr", r.PrometheusExpr)
+		collect(ref)
+	}
+	for _, s := range e.slos {
+		ref := newReference(entitySLO, s.Slug, s.Name, configowner.Of(s.CollectionRef, "", ""))
+		if s.Sli != nil && s.Sli.CustomIndicator != nil {
+			ci := s.Sli.CustomIndicator
+			addQueries(ref, "sli.good_query_template", renderSLITemplate(ci.GoodQueryTemplate))
+			addQueries(ref, "sli.bad_query_template", renderSLITemplate(ci.BadQueryTemplate))
+			addQueries(ref, "sli.total_query_template", renderSLITemplate(ci.TotalQueryTemplate))
+		}
+		collect(ref)
+	}
+	for _, r := range e.dropRules {
+		ref := newReference(entityDropRule, r.Slug, r.Name, configowner.Owner{})
+		q.matchRule(ref, r.Filters, "", nil)
+		collect(ref)
+	}
+	for _, r := range e.rollupRules {
+		ref := newReference(entityRollupRule, r.Slug, r.Name, configowner.Owner{Kind: configowner.KindBucket, Slug: r.BucketSlug})
+		var labelPolicy []string
+		if r.LabelPolicy != nil {
+			labelPolicy = append(append(labelPolicy, r.LabelPolicy.Keep...), r.LabelPolicy.Discard...)
+		}
+		q.matchRule(ref, r.Filters, r.MetricName, labelPolicy)
+		collect(ref)
+	}
+	for _, r := range e.mappingRules {
+		ref := newReference(entityMappingRule, r.Slug, r.Name, configowner.Owner{Kind: configowner.KindBucket, Slug: r.BucketSlug})
+		q.matchRule(ref, r.Filters, "", nil)
+		collect(ref)
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].EntityType != refs[j].EntityType {
+			return slices.Index(entityTypes, refs[i].EntityType) < slices.Index(entityTypes, refs[j].EntityType)
+		}
+		return refs[i].Slug < refs[j].Slug
+	})
+	return refs, warnings
+}
+
+// matchesQuery returns whether the PromQL query references the metric and label. Queries
+// that can not be parsed match the label lexically.
+func (q referenceQuery) matchesQuery(query string) bool {
+	if q.metric != "" && !promql.MatchesMetric(query, q.metric) {
+		return false
+	}
+	if q.label == "" {
+		return true
+	}
+	names, err := promql.LabelNames(query)
+	if err != nil {
+		return q.labelRe.MatchString(query)
+	}
+	return slices.Contains(names, q.label)
+}
+
+// matchMonitorLabels adds the fields of a monitor outside its query that reference the label:
+// its signal grouping and the label matchers of its series condition overrides. If a metric is
+// searched for too, the monitor's query must reference it.
+func (q referenceQuery) matchMonitorLabels(ref *Reference, m *models.Configv1Monitor) {
+	if q.label == "" || (q.metric != "" && !promql.MatchesMetric(m.PrometheusQuery, q.metric)) {
+		return
+	}
+	var fields []string
+	if m.SignalGrouping != nil && slices.Contains(m.SignalGrouping.LabelNames, q.label) {
+		fields = append(fields, "signal_grouping.label_names")
+	}
+	if m.SeriesConditions != nil {
+		for _, o := range m.SeriesConditions.Overrides {
+			if o != nil && slices.ContainsFunc(o.LabelMatchers, func(lm *models.Configv1LabelMatcher) bool {
+				return lm != nil && lm.Name == q.label
+			}) {
+				fields = append(fields, "series_conditions.overrides.label_matchers")
+				break
+			}
+		}
+	}
+	for _, f := range fields {
+		if !slices.Contains(ref.Fields, f) {
+			ref.Fields = append(ref.Fields, f)
+		}
+	}
+}
+
+// matchRule adds the fields of a rule referencing the metric and label: its filters, the
+// metric it produces and the labels of its label policy. If both a metric and a label are
+// searched for, the rule must reference both.
+func (q referenceQuery) matchRule(ref *Reference, lfs []*models.Configv1LabelFilter, outputMetric string, labelPolicy []string) {
+	var (
+		metricFields, labelFields []string
+		matched                   []string
+	)
+	fs, err := filters.FromModels(lfs)
+	if err == nil {
+		for _, f := range fs {
+			switch {
+			case q.metric != "" && f.Name == filters.MetricNameLabel && f.MatchesValue(q.metric):
+				metricFields = append(metricFields, "filters")
+				matched = append(matched, f.String())
+			case q.label != "" && f.Name == q.label:
+				labelFields = append(labelFields, "filters")
+				matched = append(matched, f.String())
+			}
+		}
+	}
+	if q.metric != "" && outputMetric == q.metric {
+		metricFields = append(metricFields, "metric_name")
+	}
+	if q.label != "" && slices.Contains(labelPolicy, q.label) {
+		labelFields = append(labelFields, "label_policy")
+	}
+	if (q.metric != "" && len(metricFields) == 0) || (q.label != "" && len(labelFields) == 0) {
+		return
+	}
+	for _, f := range append(metricFields, labelFields...) {
+		if !slices.Contains(ref.Fields, f) {
+			ref.Fields = append(ref.Fields, f)
+		}
+	}
+	ref.Queries = append(ref.Queries, matched...)
+}
+
+func newReference(entityType, slug, name string, owner configowner.Owner) *Reference {
+	return &Reference{EntityType: entityType, Slug: slug, Name: name, Owner: owner.String(), owner: owner}
+}
+
+// renderSLITemplate renders an SLI query template into a parseable query, using a 5m window
+// and no grouping.
+func renderSLITemplate(template string) string {
+	return sliTemplateActionRe.ReplaceAllStringFunc(template, func(action string) string {
+		if sliWindowRe.MatchString(action) {
+			return "5m"
+		}
+		return ""
+	})
+}
+
+// teamResolver resolves the team owning collections, services and buckets. Collections and
+// buckets are listed once when first needed; services are read one at a time.
+type teamResolver struct {
+	ctx      context.Context
+	t        *Tools
+	teams    map[configowner.Owner]string
+	loaded   map[string]bool
+	warnings []string
+}
+
+func newTeamResolver(ctx context.Context, t *Tools) *teamResolver {
+	return &teamResolver{ctx: ctx, t: t, teams: make(map[configowner.Owner]string), loaded: make(map[string]bool)}
+}
+
+func (r *teamResolver) team(owner configowner.Owner) string {
+	if owner.Slug == "" {
+		return ""
+	}
+	if team, ok := r.teams[owner]; ok {
+		return team
+	}
+	switch owner.Kind {
+	case configowner.KindCollection:
+		r.load(configowner.KindCollection, func() error {
+			collections, err := configlist.Collections(r.ctx, r.t.configAPI, configlist.Filter{})
+			r.addCollections(collections)
+			return err
+		})
+	case configowner.KindBucket:
+		r.load(configowner.KindBucket, func() error {
+			buckets, err := configlist.Buckets(r.ctx, r.t.configAPI, configlist.Filter{})
+			for _, b := range buckets {
+				r.teams[configowner.Owner{Kind: configowner.KindBucket, Slug: b.Slug}] = b.TeamSlug
+			}
+			return err
+		})
+	case configowner.KindService:
+		resp, err := r.t.configAPI.Service.ReadService(&service.ReadServiceParams{
+			Context: r.ctx,
+			Slug:    owner.Slug,
+		})
+		if err != nil {
+			r.t.logger.Warn("failed to read service", zap.String("slug", owner.Slug), zap.Error(err))
+		} else if resp.Payload.Service != nil {
+			r.teams[owner] = resp.Payload.Service.TeamSlug
+		}
+	}
+	team := r.teams[owner]
+	r.teams[owner] = team
+	return team
+}
+
+// addCollections records the teams of already listed collections.
+func (r *teamResolver) addCollections(collections []*models.Configv1Collection) {
+	r.loaded[configowner.KindCollection] = true
+	for _, c := range collections {
+		r.teams[configowner.Owner{Kind: configowner.KindCollection, Slug: c.Slug}] = c.TeamSlug
+	}
+}
+
+func (r *teamResolver) load(kind string, fn func() error) {
+	if r.loaded[kind] {
+		return
+	}
+	r.loaded[kind] = true
+	if err := fn(); err != nil {
+		r.warnings = append(r.warnings, fmt.Sprintf("failed to list %ss to resolve owning teams: %s", kind, err))
+	}
+}
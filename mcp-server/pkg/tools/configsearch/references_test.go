@@ -0,0 +1,171 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func testEntities() *configEntities {
+	return &configEntities{
+		monitors: []*models.Configv1Monitor{
+			{Slug: "api-errors", Name: "API errors", CollectionSlug: "api",
+				PrometheusQuery: `sum by (pod) (rate(http_requests_total{code=~"5.."}[5m])) > 1`},
+			{Slug: "any-http", Collection: &models.Configv1CollectionReference{Slug: "checkout", Type: models.Configv1CollectionReferenceTypeSERVICE},
+				PrometheusQuery: `count({__name__=~"http_.*"})`},
+			{Slug: "cpu", PrometheusQuery: `sum(rate(cpu_seconds_total[5m]))`,
+				SignalGrouping: &models.MonitorSignalGrouping{LabelNames: []string{"pod"}}},
+			{Slug: "latency", PrometheusQuery: `histogram_quantile(0.99, sum(rate(http_request_duration_seconds_bucket[5m])))`,
+				SeriesConditions: &models.MonitorSeriesConditions{Overrides: []*models.MonitorSeriesConditionsOverride{
+					{LabelMatchers: []*models.Configv1LabelMatcher{{Name: "pod", Type: models.Configv1LabelMatcherMatcherTypeEXACT, Value: "api-1"}}},
+				}}},
+		},
+		dashboards: []*models.Configv1Dashboard{
+			{Slug: "api", Name: "API", CollectionSlug: "api",
+				DashboardJSON: `{"spec": {"panels": {"a": {"spec": {"queries": [{"spec": {"plugin": {"spec": {"query": "sum(rate(http_requests_total{env=\"$env\"}[5m]))"}}}}]}}}}}`},
+		},
+		grafanaDashboards: []*models.Configv1GrafanaDashboard{
+			{Slug: "gateway", Name: "Gateway", BucketSlug: "gateway",
+				DashboardJSON: `{"panels": [{"type": "graph", "targets": [{"expr": "sum by (route) (rate(http_requests_total[5m]))"}]}]}`},
+		},
+		recordingRules: []*models.Configv1RecordingRule{
+			{Slug: "http-rate", BucketSlug: "platform", MetricName: "http_requests:rate5m",
+				PrometheusExpr: `sum by (service) (rate(http_requests_total[5m]))`},
+		},
+		slos: []*models.Configv1SLO{
+			{Slug: "api-availability", CollectionRef: &models.Configv1CollectionReference{Slug: "api", Type: models.Configv1CollectionReferenceTypeSIMPLE},
+				Sli: &models.Configv1SLI{CustomIndicator: &models.SLICustomIndicatorConfig{
+					BadQueryTemplate:   `sum by ({{.GroupBy}}) (rate(http_requests_total{code=~"5.."}[{{.Window}}]))`,
+					TotalQueryTemplate: `sum by ({{.GroupBy}}) (rate(http_requests_total[{{.Window}}]))`,
+				}}},
+		},
+		dropRules: []*models.Configv1DropRule{
+			{Slug: "drop-debug", Filters: []*models.Configv1LabelFilter{
+				{Name: "__name__", ValueGlob: "http_*"}, {Name: "pod", ValueGlob: "debug-*"},
+			}},
+		},
+		rollupRules: []*models.Configv1RollupRule{
+			{Slug: "http-by-service", MetricName: "http_requests_by_service",
+				Filters:     []*models.Configv1LabelFilter{{Name: "__name__", ValueGlob: "http_requests_total"}},
+				LabelPolicy: &models.Configv1RollupRuleLabelPolicy{Discard: []string{"pod"}}},
+		},
+		mappingRules: []*models.Configv1MappingRule{
+			{Slug: "sample-cpu", Filters: []*models.Configv1LabelFilter{{Name: "__name__", ValueGlob: "cpu_*"}}},
+		},
+	}
+}
+
+func TestFindReferences(t *testing.T) {
+	testCases := []struct {
+		name   string
+		metric string
+		label  string
+		want   map[string][]string
+	}{
+		{
+			name:   "metric",
+			metric: "http_requests_total",
+			want: map[string][]string{
+				"monitor/any-http":            {"prometheus_query"},
+				"monitor/api-errors":          {"prometheus_query"},
+				"dashboard/api":               {"dashboard_json"},
+				"grafana_dashboard/gateway":   {"dashboard_json"},
+				"recording_rule/http-rate":    {"prometheus_expr"},
+				"slo/api-availability":        {"sli.bad_query_template", "sli.total_query_template"},
+				"drop_rule/drop-debug":        {"filters"},
+				"rollup_rule/http-by-service": {"filters"},
+			},
+		},
+		{
+			name:  "label",
+			label: "pod",
+			want: map[string][]string{
+				"monitor/api-errors":          {"prometheus_query"},
+				"monitor/cpu":                 {"signal_grouping.label_names"},
+				"monitor/latency":             {"series_conditions.overrides.label_matchers"},
+				"drop_rule/drop-debug":        {"filters"},
+				"rollup_rule/http-by-service": {"label_policy"},
+			},
+		},
+		{
+			name:   "metric and label",
+			metric: "http_requests_total",
+			label:  "service",
+			want: map[string][]string{
+				"recording_rule/http-rate": {"prometheus_expr"},
+			},
+		},
+		{
+			name:   "produced metric",
+			metric: "http_requests_by_service",
+			want: map[string][]string{
+				// Regular expressions and globs on __name__ select the produced metric too.
+				"monitor/any-http":            {"prometheus_query"},
+				"drop_rule/drop-debug":        {"filters"},
+				"rollup_rule/http-by-service": {"metric_name"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			refs, warnings := findReferences(newReferenceQuery(tc.metric, tc.label), testEntities())
+			require.Empty(t, warnings)
+			got := make(map[string][]string, len(refs))
+			for _, ref := range refs {
+				got[ref.EntityType+"/"+ref.Slug] = ref.Fields
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFindReferencesOrderAndOwners(t *testing.T) {
+	refs, _ := findReferences(newReferenceQuery("http_requests_total", ""), testEntities())
+	var (
+		keys   []string
+		owners []string
+	)
+	for _, ref := range refs {
+		keys = append(keys, ref.EntityType+"/"+ref.Slug)
+		owners = append(owners, ref.Owner)
+	}
+	require.Equal(t, []string{
+		"monitor/any-http", "monitor/api-errors", "dashboard/api", "grafana_dashboard/gateway",
+		"recording_rule/http-rate", "slo/api-availability", "drop_rule/drop-debug", "rollup_rule/http-by-service",
+	}, keys)
+	require.Equal(t, []string{
+		"service:checkout", "collection:api", "collection:api", "bucket:gateway",
+		"bucket:platform", "collection:api", "", "",
+	}, owners)
+	require.Equal(t, []string{"__name__:http_*"}, refs[6].Queries)
+}
+
+func TestRenderSLITemplate(t *testing.T) {
+	require.Equal(t,
+		`sum by () (rate(http_requests_total[5m]))`,
+		renderSLITemplate(`sum by ({{.GroupBy}}) (rate(http_requests_total[{{ .Window }}]))`))
+}
+
+func TestFindReferencesUnparseableDashboard(t *testing.T) {
+	e := testEntities()
+	e.dashboards = append(e.dashboards, &models.Configv1Dashboard{Slug: "broken", DashboardJSON: "{"})
+	_, warnings := findReferences(newReferenceQuery("http_requests_total", ""), e)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "failed to parse dashboard broken")
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configowner"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+// Entity types that are indexed in addition to those that can reference metrics.
+const (
+	entityNotificationPolicy = "notification_policy"
+	entityCollection         = "collection"
+	entityTeam               = "team"
+)
+
+// searchableTypes are the entity types indexed by search_config.
+var searchableTypes = append(slices.Clone(entityTypes), entityNotificationPolicy, entityCollection, entityTeam)
+
+// SearchResults are the config entities matching a search.
+type SearchResults struct {
+	Query     string          `json:"query,omitempty"`
+	Total     int             `json:"total"`
+	Results   []*SearchResult `json:"results"`
+	IndexSize int             `json:"index_size"`
+	IndexedAt time.Time       `json:"indexed_at"`
+	Warnings  []string        `json:"warnings,omitempty"`
+}
+
+// SearchResult is a config entity matching a search.
+type SearchResult struct {
+	EntityType string            `json:"entity_type"`
+	Slug       string            `json:"slug"`
+	Name       string            `json:"name,omitempty"`
+	TeamSlug   string            `json:"team_slug,omitempty"`
+	Owner      string            `json:"owner,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Score      int               `json:"score"`
+	Matched    []string          `json:"matched,omitempty"`
+	Link       string            `json:"link,omitempty"`
+}
+
+func (t *Tools) searchConfig(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	query, err := params.String(request, "query", false, "")
+	if err != nil {
+		return nil, err
+	}
+	types, err := params.StringArray(request, "entity_types", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	teamSlugs, err := params.StringArray(request, "team_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	labelFilters, err := params.StringArray(request, "labels", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := params.Int(request, "limit", false, 20)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := params.Bool(request, "refresh", false, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, typ := range types {
+		if !slices.Contains(searchableTypes, typ) {
+			return nil, fmt.Errorf("unknown entity type %q, must be one of %v", typ, searchableTypes)
+		}
+	}
+	fs, err := filters.ParseStrings(labelFilters)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(query) == "" && len(types) == 0 && len(teamSlugs) == 0 && len(fs) == 0 {
+		return nil, fmt.Errorf("at least one of query, entity_types, team_slugs or labels must be set")
+	}
+
+	idx, err := t.index.get(ctx, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build config search index: %s", err)
+	}
+	docs, warnings, builtAt := idx.snapshot(time.Now())
+
+	matches := search(docs, searchQuery{
+		terms:       tokenize(query),
+		phrase:      strings.TrimSpace(query),
+		entityTypes: types,
+		teamSlugs:   teamSlugs,
+		labels:      fs,
+	})
+	result := &SearchResults{
+		Query:     query,
+		Total:     len(matches),
+		IndexSize: len(docs),
+		IndexedAt: builtAt,
+		Warnings:  warnings,
+	}
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result.Results = make([]*SearchResult, 0, len(matches))
+	for _, m := range matches {
+		result.Results = append(result.Results, &SearchResult{
+			EntityType: m.doc.entityType,
+			Slug:       m.doc.slug,
+			Name:       m.doc.name,
+			TeamSlug:   m.doc.teamSlug,
+			Owner:      m.doc.owner.String(),
+			Labels:     m.doc.labels,
+			Score:      m.score,
+			Matched:    m.fields,
+			Link:       t.linkBuilder.ConfigEntity(m.doc.entityType, m.doc.slug),
+		})
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// buildIndex lists every searchable entity. Entity types that fail to list are reported as
+// warnings; the build only fails if nothing could be listed.
+func (t *Tools) buildIndex(ctx context.Context) ([]*document, []string, error) {
+	var warnings []string
+	entities := t.listEntities(ctx, entityTypes, &warnings)
+
+	owners := newTeamResolver(ctx, t)
+	collections, err := configlist.Collections(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to list %s entities: %s", entityCollection, err))
+	} else {
+		owners.addCollections(collections)
+	}
+	teams, err := configlist.Teams(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to list %s entities: %s", entityTeam, err))
+	}
+	policies, err := configlist.NotificationPolicies(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to list %s entities: %s", entityNotificationPolicy, err))
+	}
+	if len(warnings) == len(searchableTypes) {
+		return nil, nil, errors.New(strings.Join(warnings, "; "))
+	}
+
+	docs := entityDocuments(entities, collections, teams, policies)
+	for _, d := range docs {
+		if d.teamSlug == "" {
+			d.teamSlug = owners.team(d.owner)
+		}
+	}
+	return docs, append(warnings, owners.warnings...), nil
+}
+
+// entityDocuments returns the documents to index for the entities.
+func entityDocuments(
+	e *configEntities,
+	collections []*models.Configv1Collection,
+	teams []*models.Configv1Team,
+	policies []*models.Configv1NotificationPolicy,
+) []*document {
+	var docs []*document
+	add := func(d *document) {
+		docs = append(docs, newDocument(d))
+	}
+	filterStrings := func(lfs []*models.Configv1LabelFilter) []string {
+		var out []string
+		for _, lf := range lfs {
+			if lf != nil {
+				out = append(out, lf.Name+":"+lf.ValueGlob)
+			}
+		}
+		return out
+	}
+
+	for _, m := range e.monitors {
+		add(&document{
+			entityType: entityMonitor, slug: m.Slug, name: m.Name, labels: m.Labels,
+			owner: configowner.Of(m.Collection, m.CollectionSlug, m.BucketSlug),
+			text:  append(mapValues(m.Annotations), m.PrometheusQuery, m.GraphiteQuery, m.LoggingQuery),
+		})
+	}
+	for _, d := range e.dashboards {
+		queries, _ := dashboards.ExtractQueries(d.DashboardJSON)
+		add(&document{
+			entityType: entityDashboard, slug: d.Slug, name: d.Name, labels: d.Labels,
+			owner: configowner.Of(d.Collection, d.CollectionSlug, ""),
+			text:  queries,
+		})
+	}
+	for _, d := range e.grafanaDashboards {
+		queries, _ := dashboards.ExtractGrafanaQueries(d.DashboardJSON)
+		add(&document{
+			entityType: entityGrafanaDashboard, slug: d.Slug, name: d.Name,
+			owner: configowner.Of(d.Collection, d.CollectionSlug, d.BucketSlug),
+			text:  queries,
+		})
+	}
+	for _, s := range e.slos {
+		doc := &document{
+			entityType: entitySLO, slug: s.Slug, name: s.Name, description: s.Description, labels: s.Labels,
+			owner: configowner.Of(s.CollectionRef, "", ""),
+			text:  mapValues(s.Annotations),
+		}
+		if s.Sli != nil && s.Sli.CustomIndicator != nil {
+			ci := s.Sli.CustomIndicator
+			doc.text = append(doc.text, ci.GoodQueryTemplate, ci.BadQueryTemplate, ci.TotalQueryTemplate)
+		}
+		add(doc)
+	}
+	for _, r := range e.recordingRules {
+		add(&document{
+			entityType: entityRecordingRule, slug: r.Slug, name: r.Name,
+			owner: configowner.Owner{Kind: configowner.KindBucket, Slug: r.BucketSlug},
+			text:  []string{r.MetricName, r.PrometheusExpr},
+		})
+	}
+	for _, r := range e.dropRules {
+		add(&document{entityType: entityDropRule, slug: r.Slug, name: r.Name, text: filterStrings(r.Filters)})
+	}
+	for _, r := range e.rollupRules {
+		add(&document{
+			entityType: entityRollupRule, slug: r.Slug, name: r.Name,
+			owner: configowner.Owner{Kind: configowner.KindBucket, Slug: r.BucketSlug},
+			text:  append(filterStrings(r.Filters), r.MetricName),
+		})
+	}
+	for _, r := range e.mappingRules {
+		add(&document{
+			entityType: entityMappingRule, slug: r.Slug, name: r.Name,
+			owner: configowner.Owner{Kind: configowner.KindBucket, Slug: r.BucketSlug},
+			text:  filterStrings(r.Filters),
+		})
+	}
+	for _, p := range policies {
+		add(&document{
+			entityType: entityNotificationPolicy, slug: p.Slug, name: p.Name, teamSlug: p.TeamSlug,
+			owner: configowner.Owner{Kind: configowner.KindBucket, Slug: p.BucketSlug},
+		})
+	}
+	for _, c := range collections {
+		add(&document{
+			entityType: entityCollection, slug: c.Slug, name: c.Name, description: c.Description, teamSlug: c.TeamSlug,
+		})
+	}
+	for _, tm := range teams {
+		add(&document{entityType: entityTeam, slug: tm.Slug, name: tm.Name, description: tm.Description, teamSlug: tm.Slug})
+	}
+	return docs
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configsnapshot provides tools for snapshotting config and detecting config drift.
+package configsnapshot
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/snapshot"
+)
+
+var _ tools.MCPTools = (*Tools)(nil)
+
+// Tools provides MCP tools for config snapshots.
+type Tools struct {
+	logger    *zap.Logger
+	configAPI *configv1.ConfigV1API
+	config    tools.SnapshotConfig
+
+	// mu serializes writing and pruning snapshots.
+	mu sync.Mutex
+}
+
+// NewTools creates new config snapshot tools. If a snapshot interval is configured, snapshots
+// are taken in the background while the server runs.
+func NewTools(
+	configAPI *configv1.ConfigV1API,
+	logger *zap.Logger,
+	config *tools.Config,
+	lc fx.Lifecycle,
+) (*Tools, error) {
+	t := &Tools{
+		logger:    logger,
+		configAPI: configAPI,
+		config:    config.Snapshots,
+	}
+	if t.config.Directory == "" {
+		logger.Info("config snapshot tool disabled, no snapshot directory configured")
+		return t, nil
+	}
+	logger.Info("config snapshot tool configured",
+		zap.String("directory", t.config.Directory),
+		zap.Duration("interval", t.config.Interval))
+
+	if t.config.Interval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				go func() {
+					defer close(done)
+					t.runSnapshots(ctx)
+				}()
+				return nil
+			},
+			OnStop: func(stopCtx context.Context) error {
+				cancel()
+				select {
+				case <-done:
+				case <-stopCtx.Done():
+				}
+				return nil
+			},
+		})
+	}
+	return t, nil
+}
+
+func (t *Tools) GroupName() string {
+	return "configsnapshot"
+}
+
+func (t *Tools) MCPTools() []tools.MCPTool {
+	if t.config.Directory == "" {
+		return nil
+	}
+	return []tools.MCPTool{
+		{
+			Metadata: tools.NewMetadata("snapshot_config",
+				mcp.WithDescription(`Takes a snapshot of all config entities now and stores it on the server, so it can later be compared with diff_config. Secrets such as notifier credentials are redacted before they are stored, so changes to them are not detected. Snapshots may also be taken periodically in the background. Snapshots are shared by all users of the server, so the snapshot tools are not available to sessions using their own API token.
+
+Response fields:
+- snapshot: Name, time and number of entities per entity type of the new snapshot
+- pruned: Names of old snapshots that were removed to stay within the retention limit`),
+			),
+			Handler: t.snapshotConfig,
+		},
+		{
+			Metadata: tools.NewMetadata("list_config_snapshots",
+				mcp.WithDescription(`Lists the stored config snapshots, oldest first. Use the names with diff_config.`),
+			),
+			Handler: t.listConfigSnapshots,
+		},
+		{
+			Metadata: tools.NewMetadata("diff_config",
+				mcp.WithDescription(`Compares config between two snapshots, or a snapshot and the live config, to find out what changed. Use this to answer questions like "what changed in our config since yesterday".
+
+Snapshots are referred to by name (see list_config_snapshots), "latest", "previous" (the one before latest) or "live" for the current config.
+
+Response fields:
+- added, removed: Entities that only exist in the to or from snapshot, by entity type, slug and name
+- modified: Entities that exist in both with the changed fields, each with its path (e.g. series_conditions.defaults.critical.conditions[0].value), old value and new value. Dashboard JSON is compared field by field.
+- warnings: Entity types that could not be compared`),
+				mcp.WithString("from",
+					mcp.Description(`Snapshot to compare from. Defaults to "latest".`),
+				),
+				mcp.WithString("to",
+					mcp.Description(`Snapshot to compare to. Defaults to "live".`),
+				),
+				params.WithStringArray("entity_types",
+					mcp.Description("Entity types to compare. Defaults to all of "+strings.Join(snapshot.EntityTypeNames(), ", ")+"."),
+				),
+				mcp.WithBoolean("summary_only",
+					mcp.Description("Only list the added, removed and modified entities, without field changes. Defaults to false."),
+				),
+			),
+			Handler: t.diffConfig,
+		},
+	}
+}
+
+// runSnapshots takes a snapshot whenever the configured interval has passed since the latest
+// stored snapshot, until ctx is done. Background snapshots use the configured API token.
+func (t *Tools) runSnapshots(ctx context.Context) {
+	var lastAttempt time.Time
+	for {
+		next := lastAttempt
+		if infos, err := snapshot.List(t.config.Directory); err != nil {
+			t.logger.Warn("failed to list config snapshots", zap.Error(err))
+		} else if len(infos) > 0 && infos[len(infos)-1].TakenAt.After(next) {
+			next = infos[len(infos)-1].TakenAt
+		}
+		wait := time.Until(next.Add(t.config.Interval))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		lastAttempt = time.Now()
+		info, _, err := t.takeSnapshot(ctx)
+		if err != nil {
+			t.logger.Warn("failed to take config snapshot", zap.Error(err))
+			continue
+		}
+		t.logger.Info("took config snapshot", zap.String("name", info.Name))
+	}
+}
+
+// takeSnapshot stores a snapshot of the current config and prunes old snapshots.
+func (t *Tools) takeSnapshot(ctx context.Context) (*snapshot.Info, []string, error) {
+	s, err := snapshot.Take(ctx, t.configAPI, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := snapshot.Write(t.config.Directory, s); err != nil {
+		return nil, nil, err
+	}
+	pruned, err := snapshot.Prune(t.config.Directory, t.config.Retain)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.Info(), pruned, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsnapshot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/authcontext"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/snapshot"
+)
+
+// TakenSnapshot is a snapshot taken on demand.
+type TakenSnapshot struct {
+	Snapshot *snapshot.Info `json:"snapshot"`
+	Pruned   []string       `json:"pruned,omitempty"`
+}
+
+// checkServerCredentials returns an error if the request uses its own credentials. Snapshots
+// are shared by every user of the server and taken with its API token, so sessions forwarding
+// their own API token could otherwise see config their token has no access to, or change what
+// other users compare against.
+func checkServerCredentials(ctx context.Context) error {
+	if !authcontext.FetchSessionAPIToken(ctx).IsEmpty() {
+		return fmt.Errorf("config snapshots are shared by all users of the server, so they are not available to sessions using their own API token")
+	}
+	return nil
+}
+
+func (t *Tools) snapshotConfig(ctx context.Context, _ mcp.CallToolRequest) (*tools.Result, error) {
+	if err := checkServerCredentials(ctx); err != nil {
+		return nil, err
+	}
+	info, pruned, err := t.takeSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{JSONContent: &TakenSnapshot{Snapshot: info, Pruned: pruned}}, nil
+}
+
+func (t *Tools) listConfigSnapshots(ctx context.Context, _ mcp.CallToolRequest) (*tools.Result, error) {
+	if err := checkServerCredentials(ctx); err != nil {
+		return nil, err
+	}
+	infos, err := snapshot.List(t.config.Directory)
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{JSONContent: infos}, nil
+}
+
+func (t *Tools) diffConfig(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	if err := checkServerCredentials(ctx); err != nil {
+		return nil, err
+	}
+	from, err := params.String(request, "from", false, snapshot.Latest)
+	if err != nil {
+		return nil, err
+	}
+	to, err := params.String(request, "to", false, snapshot.Live)
+	if err != nil {
+		return nil, err
+	}
+	entityTypes, err := params.StringArray(request, "entity_types", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	summaryOnly, err := params.Bool(request, "summary_only", false, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, et := range entityTypes {
+		if !slices.ContainsFunc(snapshot.EntityTypes, func(s snapshot.EntityType) bool { return s.Name == et }) {
+			return nil, fmt.Errorf("unknown entity type %q", et)
+		}
+	}
+
+	fromSnapshot, err := snapshot.Open(ctx, t.configAPI, t.config.Directory, from)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := snapshot.Open(ctx, t.configAPI, t.config.Directory, to)
+	if err != nil {
+		return nil, err
+	}
+	diff := snapshot.Compare(fromSnapshot, toSnapshot, entityTypes)
+	if summaryOnly {
+		for _, c := range diff.Modified {
+			c.Changes = nil
+		}
+	}
+	return &tools.Result{JSONContent: diff}, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsnapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/authcontext"
+)
+
+func TestCheckServerCredentials(t *testing.T) {
+	require.NoError(t, checkServerCredentials(context.Background()))
+
+	ctx := authcontext.SetSessionCredentials(context.Background(), authcontext.SessionCredentials{APIToken: "alice"})
+	require.ErrorContains(t, checkServerCredentials(ctx), "not available to sessions using their own API token")
+}
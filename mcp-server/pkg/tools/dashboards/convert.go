@@ -0,0 +1,124 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/classic_dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	dashboardjson "github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+// ConvertedDashboard is a classic dashboard converted to a dashboard.
+type ConvertedDashboard struct {
+	ClassicSlug     string                           `json:"classic_slug"`
+	Dashboard       *models.Configv1Dashboard        `json:"dashboard"`
+	ConvertedPanels int                              `json:"converted_panels"`
+	Issues          []*dashboardjson.ConversionIssue `json:"issues,omitempty"`
+	Created         bool                             `json:"created"`
+	Warnings        []string                         `json:"warnings,omitempty"`
+}
+
+func (t *Tools) convertClassicDashboard(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	name, err := params.String(request, "name", false, "")
+	if err != nil {
+		return nil, err
+	}
+	newSlug, err := params.String(request, "new_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	collectionSlug, err := params.String(request, "collection_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	create, err := params.Bool(request, "create", false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.configAPI.ClassicDashboard.ReadClassicDashboard(&classic_dashboard.ReadClassicDashboardParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read classic dashboard %s: %s", slug, err)
+	}
+	classic := resp.Payload.ClassicDashboard
+	g, err := dashboardjson.ParseGrafana(classic.DashboardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = classic.Name
+	}
+	if collectionSlug == "" {
+		collectionSlug = classic.CollectionSlug
+	}
+	d, issues := dashboardjson.ConvertGrafana(g)
+	d.Metadata.Name = name
+	d.Spec.Display.Name = name
+	dashboardJSON, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dashboard: %s", err)
+	}
+
+	result := &ConvertedDashboard{
+		ClassicSlug: slug,
+		Dashboard: &models.Configv1Dashboard{
+			Slug:           newSlug,
+			Name:           name,
+			CollectionSlug: collectionSlug,
+			DashboardJSON:  string(dashboardJSON),
+		},
+		ConvertedPanels: len(d.Spec.Panels),
+		Issues:          issues,
+	}
+	if collectionSlug == "" {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("classic dashboard belongs to bucket %s; set collection_slug to create the dashboard", classic.BucketSlug))
+	}
+
+	if create {
+		created, err := t.createDashboard(ctx, result.Dashboard)
+		switch {
+		case errors.Is(err, errWritesDisabled):
+			result.Warnings = append(result.Warnings, err.Error())
+		case err != nil:
+			return nil, err
+		default:
+			result.Dashboard, result.Created = created, true
+		}
+	}
+
+	toolResult := &tools.Result{JSONContent: result}
+	if result.Created {
+		toolResult.ChronosphereLink = t.linkBuilder.Custom("/dashboards/" + result.Dashboard.Slug).String()
+	}
+	return toolResult, nil
+}
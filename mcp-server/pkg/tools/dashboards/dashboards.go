@@ -0,0 +1,207 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dashboards provides tools for reading dashboards the way a human looks at them.
+package dashboards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/prometheus"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
+)
+
+var _ tools.MCPTools = (*Tools)(nil)
+
+var errWritesDisabled = errors.New("writes are disabled in the tools config; the dashboard was not created")
+
+// Tools provides MCP tools for dashboards.
+type Tools struct {
+	logger      *zap.Logger
+	configAPI   *configv1.ConfigV1API
+	promAPI     v1.API
+	renderer    *prometheus.Renderer
+	linkBuilder *links.Builder
+	config      *tools.Config
+}
+
+// NewTools creates new dashboard tools.
+func NewTools(
+	configAPI *configv1.ConfigV1API,
+	promClient api.Client,
+	logger *zap.Logger,
+	linkBuilder *links.Builder,
+	config *tools.Config,
+) (*Tools, error) {
+	renderer, err := prometheus.NewRenderer(prometheus.RendererOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("dashboards tool configured")
+
+	return &Tools{
+		logger:      logger,
+		configAPI:   configAPI,
+		promAPI:     v1.NewAPI(promClient),
+		renderer:    renderer,
+		linkBuilder: linkBuilder,
+		config:      config,
+	}, nil
+}
+
+func (t *Tools) GroupName() string {
+	return "dashboards"
+}
+
+func (t *Tools) MCPTools() []tools.MCPTool {
+	mcpTools := []tools.MCPTool{
+		{
+			Metadata: tools.NewMetadata("summarize_dashboard",
+				mcp.WithDescription(`Summarizes what a dashboard currently shows. Use this to "look at" a dashboard instead of reading its raw definition with get_dashboard.
+
+Every panel's PromQL queries are extracted, dashboard variables are substituted and the queries are evaluated over the time range. Variables without a given value use their default; variables defaulting to all values match any value.
+
+Response fields:
+- variables: Each variable with the value used and whether it came from the variables parameter, the default or matches all values
+- panels: Per panel in layout order, the group, title, chart kind, unit and per query the substituted query, number of series, min, max and average across all series, and the series with the highest average
+- images: If include_images is set, a chart per panel is returned after the summary; image_index of a panel is its position among the images`),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the dashboard."),
+					mcp.Required(),
+				),
+				mcp.WithObject("variables",
+					mcp.Description(`Values for dashboard variables, e.g. {"service": "checkout", "env": ["prod", "staging"]}. A list selects several values.`),
+				),
+				params.WithTimeRange(),
+				mcp.WithNumber("step_seconds",
+					mcp.Description("Query resolution in seconds. Defaults to the time range divided into 200 points, at least 60."),
+				),
+				mcp.WithBoolean("include_images",
+					mcp.Description("Whether to render a chart per panel. Default is false."),
+				),
+				mcp.WithNumber("max_panels",
+					mcp.Description("Maximum number of panels to evaluate, in layout order. Default is 30."),
+				),
+				mcp.WithNumber("max_images",
+					mcp.Description("Maximum number of panels to render when include_images is set. Default is 6."),
+				),
+			),
+			Handler: t.summarizeDashboard,
+		},
+		{
+			Metadata: tools.NewMetadata("generate_dashboard",
+				mcp.WithDescription(`Generates a dashboard showing the request rate, errors and duration (RED) of a service.
+
+The metrics of the service are listed by the service label. Request counters, error counters and duration histograms are picked by naming conventions, falling back to metric metadata for metrics whose type can not be told from their name. If there is no error counter, errors are counted by the status label of the request metric (5xx for HTTP, anything but OK for gRPC).
+
+The dashboard has a service variable, request rate, error ratio and p50/p95/p99 latency panels, and panels by route if the request metric has a route label.
+
+The dashboard is only created if create is set and writes are enabled in the server config. Otherwise the definition is returned for review and can be created later.
+
+Response fields:
+- discovered: The metric picked for each signal, with its type and unit, and the status and route labels used
+- dashboard: The dashboard in the config API format, with the generated definition in dashboard_json
+- created: Whether the dashboard was created`),
+				mcp.WithString("service",
+					mcp.Description("Name of the service, e.g. checkout."),
+					mcp.Required(),
+				),
+				mcp.WithString("service_label",
+					mcp.Description("Label identifying the service on its metrics, e.g. service, service_name or job. Default is service."),
+				),
+				mcp.WithString("name",
+					mcp.Description(`Name of the dashboard. Default is "<service> RED".`),
+				),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the dashboard. Generated from the name if not set."),
+				),
+				mcp.WithString("collection_slug",
+					mcp.Description("Slug of the collection the dashboard belongs to. Required to create the dashboard."),
+				),
+				mcp.WithBoolean("create",
+					mcp.Description("Whether to create the dashboard. Only has an effect if writes are enabled. Default is false."),
+				),
+				params.WithTimeRange(),
+			),
+			Handler: t.generateDashboard,
+		},
+	}
+	if t.config.EnableClassicDashboards {
+		mcpTools = append(mcpTools, tools.MCPTool{
+			Metadata: tools.NewMetadata("convert_classic_dashboard",
+				mcp.WithDescription(`Converts a classic (Grafana style) dashboard to a dashboard, to migrate off classic dashboards.
+
+Panels, PromQL queries, rows, variables and units are converted. Graph and time series panels become time series charts, stat and singlestat panels stat charts, gauges gauge charts, bar gauges bar charts, and text panels markdown. Query variables using label_values, label_names or query_result, custom, interval, constant and textbox variables are converted; the deprecated [[var]] syntax becomes $var.
+
+The dashboard is only created if create is set and writes are enabled in the server config. Otherwise the definition is returned for review and can be created later.
+
+Response fields:
+- dashboard: The dashboard in the config API format, with the converted definition in dashboard_json
+- converted_panels: Number of panels converted
+- issues: Panels, variables and queries that were left out and units that were replaced, with the reason
+- created: Whether the dashboard was created`),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the classic dashboard."),
+					mcp.Required(),
+				),
+				mcp.WithString("name",
+					mcp.Description("Name of the dashboard. Defaults to the name of the classic dashboard."),
+				),
+				mcp.WithString("new_slug",
+					mcp.Description("Slug of the dashboard. Generated from the name if not set."),
+				),
+				mcp.WithString("collection_slug",
+					mcp.Description("Slug of the collection the dashboard belongs to. Defaults to the collection of the classic dashboard."),
+				),
+				mcp.WithBoolean("create",
+					mcp.Description("Whether to create the dashboard. Only has an effect if writes are enabled. Default is false."),
+				),
+			),
+			Handler: t.convertClassicDashboard,
+		})
+	}
+	return mcpTools
+}
+
+// createDashboard creates the dashboard if writes are enabled, returning errWritesDisabled
+// otherwise.
+func (t *Tools) createDashboard(ctx context.Context, d *models.Configv1Dashboard) (*models.Configv1Dashboard, error) {
+	if !t.config.EnableWrites {
+		return nil, errWritesDisabled
+	}
+	if d.CollectionSlug == "" {
+		return nil, fmt.Errorf("collection_slug is required to create a dashboard")
+	}
+	resp, err := t.configAPI.Dashboard.CreateDashboard(&dashboard.CreateDashboardParams{
+		Context: ctx,
+		Body:    &models.Configv1CreateDashboardRequest{Dashboard: d},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dashboard: %s", err)
+	}
+	return resp.Payload.Dashboard, nil
+}
@@ -0,0 +1,471 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	dashboardjson "github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+const (
+	serviceVariable = "service"
+	rateWindow      = "5m"
+	panelWidth      = 12
+	panelHeight     = 8
+	// maxMetadataLookups bounds the metadata requests made for metrics whose type can not be
+	// told from their name.
+	maxMetadataLookups = 20
+
+	unitRequestsPerSec = "requests/sec"
+	unitPercentDecimal = "percent-decimal"
+	unitSeconds        = "seconds"
+	unitMilliseconds   = "milliseconds"
+
+	metricTypeCounter   = "counter"
+	metricTypeHistogram = "histogram"
+)
+
+var (
+	requestKeywords  = []string{"request", "calls", "rpc", "handled", "server"}
+	errorKeywords    = []string{"error", "fail", "exception"}
+	durationKeywords = []string{"duration", "latency", "response_time", "seconds", "milliseconds"}
+
+	// httpStatusLabels hold HTTP status codes, where 5xx codes are errors.
+	httpStatusLabels = []string{"code", "status", "status_code", "http_status_code", "http_response_status_code"}
+	// grpcStatusLabels hold gRPC status codes, where anything but OK is an error.
+	grpcStatusLabels = []string{"grpc_code", "grpc_status_code", "rpc_grpc_status_code"}
+	// routeLabels break requests down by what was requested.
+	routeLabels = []string{"route", "http_route", "endpoint", "handler", "operation", "grpc_method", "rpc_method", "method"}
+)
+
+// GeneratedDashboard is a dashboard generated from the RED metrics of a service.
+type GeneratedDashboard struct {
+	Service      string                    `json:"service"`
+	ServiceLabel string                    `json:"service_label"`
+	Discovered   *REDMetrics               `json:"discovered"`
+	Dashboard    *models.Configv1Dashboard `json:"dashboard"`
+	Created      bool                      `json:"created"`
+	Warnings     []string                  `json:"warnings,omitempty"`
+}
+
+// REDMetrics are the metrics measuring the request rate, errors and duration of a service.
+type REDMetrics struct {
+	Requests *DiscoveredMetric `json:"requests,omitempty"`
+	Errors   *DiscoveredMetric `json:"errors,omitempty"`
+	Duration *DiscoveredMetric `json:"duration,omitempty"`
+	// StatusLabel is the label of the request metric holding the response status, used to
+	// count errors when there is no error metric.
+	StatusLabel string `json:"status_label,omitempty"`
+	// RouteLabel is the label of the request metric to break requests down by.
+	RouteLabel string `json:"route_label,omitempty"`
+}
+
+// DiscoveredMetric is a metric chosen for a RED signal.
+type DiscoveredMetric struct {
+	Metric string `json:"metric"`
+	Type   string `json:"type"`
+	Unit   string `json:"unit,omitempty"`
+}
+
+func (t *Tools) generateDashboard(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	service, err := params.String(request, "service", true, "")
+	if err != nil {
+		return nil, err
+	}
+	serviceLabel, err := params.String(request, "service_label", false, "service")
+	if err != nil {
+		return nil, err
+	}
+	name, err := params.String(request, "name", false, service+" RED")
+	if err != nil {
+		return nil, err
+	}
+	slug, err := params.String(request, "slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	collectionSlug, err := params.String(request, "collection_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	create, err := params.Bool(request, "create", false, false)
+	if err != nil {
+		return nil, err
+	}
+	timeRange, err := params.ParseTimeRange(request)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := fmt.Sprintf("{%s=%q}", serviceLabel, service)
+	names, _, err := t.promAPI.LabelValues(ctx, "__name__", []string{selector}, timeRange.Start, timeRange.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics of service %s: %s", service, err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no metrics found with %s between %s and %s", selector,
+			timeRange.Start.Format(time.RFC3339), timeRange.End.Format(time.RFC3339))
+	}
+	metrics := make([]string, 0, len(names))
+	for _, n := range names {
+		metrics = append(metrics, string(n))
+	}
+
+	result := &GeneratedDashboard{Service: service, ServiceLabel: serviceLabel}
+	red := discoverRED(metrics, t.metricTypes(ctx, metrics))
+	if red.Requests == nil && red.Duration == nil {
+		return nil, fmt.Errorf("no request or duration metrics found for service %s among %d metrics", service, len(metrics))
+	}
+	if red.Requests != nil {
+		labelNames, _, err := t.promAPI.LabelNames(ctx,
+			[]string{fmt.Sprintf("{__name__=%q,%s=%q}", red.Requests.Metric, serviceLabel, service)},
+			timeRange.Start, timeRange.End)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to list labels of %s: %s", red.Requests.Metric, err))
+		} else {
+			red.StatusLabel, red.RouteLabel = requestLabels(labelNames)
+		}
+	}
+	if red.Errors == nil && red.StatusLabel == "" {
+		result.Warnings = append(result.Warnings, "no error metric or status label found; the dashboard has no error panel")
+	}
+	result.Discovered = red
+
+	dashboardJSON, err := json.Marshal(buildREDDashboard(name, service, serviceLabel, red))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dashboard: %s", err)
+	}
+	result.Dashboard = &models.Configv1Dashboard{
+		Slug:           slug,
+		Name:           name,
+		CollectionSlug: collectionSlug,
+		DashboardJSON:  string(dashboardJSON),
+	}
+
+	if create {
+		created, err := t.createDashboard(ctx, result.Dashboard)
+		switch {
+		case errors.Is(err, errWritesDisabled):
+			result.Warnings = append(result.Warnings, err.Error())
+		case err != nil:
+			return nil, err
+		default:
+			result.Dashboard, result.Created = created, true
+		}
+	}
+
+	toolResult := &tools.Result{JSONContent: result}
+	if result.Created {
+		toolResult.ChronosphereLink = t.linkBuilder.Custom("/dashboards/" + result.Dashboard.Slug).String()
+	}
+	return toolResult, nil
+}
+
+// metricTypes returns the metadata type of metrics whose type can not be told from their name.
+func (t *Tools) metricTypes(ctx context.Context, metrics []string) map[string]string {
+	types := make(map[string]string)
+	lookups := 0
+	for _, m := range metrics {
+		// The _count and _sum series of histograms and summaries are covered by their buckets.
+		if typeFromName(m) != "" || strings.HasSuffix(m, "_count") || strings.HasSuffix(m, "_sum") ||
+			!containsAny(m, requestKeywords, errorKeywords, durationKeywords) {
+			continue
+		}
+		if lookups >= maxMetadataLookups {
+			break
+		}
+		lookups++
+		resp, err := t.promAPI.Metadata(ctx, m, "1")
+		if err != nil {
+			t.logger.Warn("failed to read metric metadata", zap.String("metric", m), zap.Error(err))
+			continue
+		}
+		if md := resp[m]; len(md) > 0 {
+			types[m] = string(md[0].Type)
+		}
+	}
+	return types
+}
+
+// discoverRED picks the metrics measuring the request rate, errors and duration of a service
+// from the names of its metrics. types holds the metadata type of metrics whose type can not
+// be told from their name.
+func discoverRED(metrics []string, types map[string]string) *REDMetrics {
+	var (
+		red                                   = &REDMetrics{}
+		requests, errs, durations             []string
+		requestScore, errScore, durationScore = map[string]int{}, map[string]int{}, map[string]int{}
+	)
+	for _, m := range metrics {
+		typ := typeFromName(m)
+		if typ == "" {
+			typ = types[m]
+		}
+		switch {
+		case typ == metricTypeHistogram && strings.HasSuffix(m, "_bucket"):
+			base := strings.TrimSuffix(m, "_bucket")
+			if containsAny(base, durationKeywords) {
+				durations = append(durations, base)
+				durationScore[base] = keywordScore(base, requestKeywords)
+			}
+		case typ == metricTypeCounter && containsAny(m, errorKeywords):
+			errs = append(errs, m)
+			errScore[m] = keywordScore(m, requestKeywords)
+		case typ == metricTypeCounter && containsAny(m, requestKeywords):
+			requests = append(requests, m)
+			requestScore[m] = keywordScore(m, requestKeywords)
+		}
+	}
+
+	if best := bestMetric(durations, durationScore); best != "" {
+		red.Duration = &DiscoveredMetric{Metric: best, Type: metricTypeHistogram, Unit: durationUnit(best)}
+	}
+	if best := bestMetric(requests, requestScore); best != "" {
+		red.Requests = &DiscoveredMetric{Metric: best, Type: metricTypeCounter, Unit: unitRequestsPerSec}
+	} else if red.Duration != nil {
+		// Every observation of a duration histogram is a request.
+		red.Requests = &DiscoveredMetric{Metric: red.Duration.Metric + "_count", Type: metricTypeCounter, Unit: unitRequestsPerSec}
+	}
+	if best := bestMetric(errs, errScore); best != "" {
+		red.Errors = &DiscoveredMetric{Metric: best, Type: metricTypeCounter, Unit: unitPercentDecimal}
+	}
+	return red
+}
+
+// typeFromName returns the metric type implied by the Prometheus naming conventions, if any.
+func typeFromName(metric string) string {
+	switch {
+	case strings.HasSuffix(metric, "_bucket"):
+		return metricTypeHistogram
+	case strings.HasSuffix(metric, "_total"):
+		return metricTypeCounter
+	}
+	return ""
+}
+
+// requestLabels returns the status and route labels among the labels of a request metric.
+func requestLabels(labelNames []string) (status, route string) {
+	has := make(map[string]bool, len(labelNames))
+	for _, l := range labelNames {
+		has[l] = true
+	}
+	for _, l := range append(append([]string{}, httpStatusLabels...), grpcStatusLabels...) {
+		if has[l] {
+			status = l
+			break
+		}
+	}
+	for _, l := range routeLabels {
+		if has[l] {
+			route = l
+			break
+		}
+	}
+	return status, route
+}
+
+// buildREDDashboard returns a dashboard with request rate, error ratio and latency panels for
+// the discovered metrics, filtered by a service variable.
+func buildREDDashboard(name, service, serviceLabel string, red *REDMetrics) *dashboardjson.Dashboard {
+	filter := fmt.Sprintf(`%s="$%s"`, serviceLabel, serviceVariable)
+	rate := func(metric, extra string) string {
+		matchers := filter
+		if extra != "" {
+			matchers += "," + extra
+		}
+		return fmt.Sprintf("rate(%s{%s}[%s])", metric, matchers, rateWindow)
+	}
+
+	var (
+		keys   []string
+		panels = make(map[string]*dashboardjson.Panel)
+	)
+	add := func(key, title, unit string, queries ...string) {
+		keys = append(keys, key)
+		panels[key] = timeSeriesPanel(title, unit, queries...)
+	}
+	if red.Requests != nil {
+		add("request-rate", "Request rate", unitRequestsPerSec,
+			fmt.Sprintf("sum(%s)", rate(red.Requests.Metric, "")))
+	}
+	switch {
+	case red.Errors != nil && red.Requests != nil:
+		add("error-ratio", "Error ratio", unitPercentDecimal,
+			fmt.Sprintf("sum(%s) / sum(%s)", rate(red.Errors.Metric, ""), rate(red.Requests.Metric, "")))
+	case red.Errors != nil:
+		add("error-rate", "Error rate", unitRequestsPerSec,
+			fmt.Sprintf("sum(%s)", rate(red.Errors.Metric, "")))
+	case red.StatusLabel != "":
+		add("error-ratio", "Error ratio", unitPercentDecimal,
+			fmt.Sprintf("sum(%s) / sum(%s)",
+				rate(red.Requests.Metric, errorMatcher(red.StatusLabel)), rate(red.Requests.Metric, "")))
+	}
+	if red.Duration != nil {
+		var queries []string
+		for _, q := range []string{"0.5", "0.95", "0.99"} {
+			queries = append(queries, fmt.Sprintf("histogram_quantile(%s, sum by (le) (%s))",
+				q, rate(red.Duration.Metric+"_bucket", "")))
+		}
+		add("latency", "Latency", red.Duration.Unit, queries...)
+	}
+	if red.Requests != nil && red.RouteLabel != "" {
+		add("request-rate-by-"+red.RouteLabel, "Request rate by "+red.RouteLabel, unitRequestsPerSec,
+			fmt.Sprintf("sum by (%s) (%s)", red.RouteLabel, rate(red.Requests.Metric, "")))
+		if red.Duration != nil {
+			add("p99-latency-by-"+red.RouteLabel, "p99 latency by "+red.RouteLabel, red.Duration.Unit,
+				fmt.Sprintf("histogram_quantile(0.99, sum by (le, %s) (%s))",
+					red.RouteLabel, rate(red.Duration.Metric+"_bucket", "")))
+		}
+	}
+
+	items := make([]*dashboardjson.LayoutItem, 0, len(keys))
+	for i, key := range keys {
+		items = append(items, &dashboardjson.LayoutItem{
+			X:       (i % 2) * panelWidth,
+			Y:       (i / 2) * panelHeight,
+			Width:   panelWidth,
+			Height:  panelHeight,
+			Content: dashboardjson.PanelReference(key),
+		})
+	}
+
+	return &dashboardjson.Dashboard{
+		Kind:     dashboardjson.KindDashboard,
+		Metadata: dashboardjson.Metadata{Name: name},
+		Spec: dashboardjson.DashboardSpec{
+			Display:  &dashboardjson.Display{Name: name},
+			Duration: "1h",
+			Variables: []*dashboardjson.Variable{{
+				Kind: dashboardjson.KindListVariable,
+				Spec: dashboardjson.VariableSpec{
+					Name:         serviceVariable,
+					Display:      &dashboardjson.Display{Name: "Service"},
+					DefaultValue: service,
+					Plugin: &dashboardjson.Plugin{
+						Kind: dashboardjson.KindPrometheusLabelValuesVariable,
+						Spec: map[string]any{"labelName": serviceLabel},
+					},
+				},
+			}},
+			Panels: panels,
+			Layouts: []*dashboardjson.Layout{{
+				Kind: dashboardjson.KindGrid,
+				Spec: dashboardjson.LayoutSpec{
+					Display: &dashboardjson.LayoutDisplay{
+						Title:    "RED",
+						Collapse: &dashboardjson.Collapse{Open: true},
+					},
+					Items: items,
+				},
+			}},
+		},
+	}
+}
+
+func timeSeriesPanel(title, unit string, queries ...string) *dashboardjson.Panel {
+	p := &dashboardjson.Panel{
+		Kind: dashboardjson.KindPanel,
+		Spec: dashboardjson.PanelSpec{
+			Display: dashboardjson.Display{Name: title},
+			Plugin: dashboardjson.Plugin{
+				Kind: dashboardjson.KindTimeSeriesChart,
+				Spec: map[string]any{
+					"legend": map[string]any{"position": "bottom"},
+					"yAxis":  map[string]any{"format": map[string]any{"unit": unit}},
+				},
+			},
+		},
+	}
+	for _, q := range queries {
+		p.Spec.Queries = append(p.Spec.Queries, &dashboardjson.Query{
+			Kind: dashboardjson.KindTimeSeriesQuery,
+			Spec: dashboardjson.QuerySpec{
+				Plugin: dashboardjson.Plugin{
+					Kind: dashboardjson.KindPrometheusTimeSeriesQuery,
+					Spec: map[string]any{"query": q},
+				},
+			},
+		})
+	}
+	return p
+}
+
+// errorMatcher returns the matcher selecting failed requests by their status label.
+func errorMatcher(statusLabel string) string {
+	for _, l := range grpcStatusLabels {
+		if l == statusLabel {
+			return fmt.Sprintf(`%s!="OK"`, statusLabel)
+		}
+	}
+	return fmt.Sprintf(`%s=~"5.."`, statusLabel)
+}
+
+func durationUnit(metric string) string {
+	if strings.Contains(metric, "milliseconds") || strings.HasSuffix(metric, "_ms") {
+		return unitMilliseconds
+	}
+	return unitSeconds
+}
+
+// bestMetric returns the metric with the highest score, preferring shorter and then
+// alphabetically first names.
+func bestMetric(metrics []string, score map[string]int) string {
+	if len(metrics) == 0 {
+		return ""
+	}
+	sort.SliceStable(metrics, func(i, j int) bool {
+		a, b := metrics[i], metrics[j]
+		if score[a] != score[b] {
+			return score[a] > score[b]
+		}
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	return metrics[0]
+}
+
+func keywordScore(metric string, keywords []string) int {
+	score := 0
+	for _, k := range keywords {
+		if strings.Contains(metric, k) {
+			score++
+		}
+	}
+	return score
+}
+
+func containsAny(metric string, keywordSets ...[]string) bool {
+	for _, keywords := range keywordSets {
+		if keywordScore(metric, keywords) > 0 {
+			return true
+		}
+	}
+	return false
+}
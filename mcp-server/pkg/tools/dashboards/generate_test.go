@@ -0,0 +1,126 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dashboardjson "github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+)
+
+func TestDiscoverRED(t *testing.T) {
+	testCases := []struct {
+		name    string
+		metrics []string
+		types   map[string]string
+		want    *REDMetrics
+	}{
+		{
+			name: "prometheus conventions",
+			metrics: []string{
+				"go_goroutines",
+				"http_requests_total",
+				"http_server_requests_errors_total",
+				"http_request_duration_seconds_bucket",
+				"http_request_duration_seconds_count",
+				"db_query_duration_seconds_bucket",
+			},
+			want: &REDMetrics{
+				Requests: &DiscoveredMetric{Metric: "http_requests_total", Type: metricTypeCounter, Unit: unitRequestsPerSec},
+				Errors:   &DiscoveredMetric{Metric: "http_server_requests_errors_total", Type: metricTypeCounter, Unit: unitPercentDecimal},
+				Duration: &DiscoveredMetric{Metric: "http_request_duration_seconds", Type: metricTypeHistogram, Unit: unitSeconds},
+			},
+		},
+		{
+			name: "requests from histogram count",
+			metrics: []string{
+				"http_server_duration_milliseconds_bucket",
+				"http_server_duration_milliseconds_count",
+			},
+			want: &REDMetrics{
+				Requests: &DiscoveredMetric{Metric: "http_server_duration_milliseconds_count", Type: metricTypeCounter, Unit: unitRequestsPerSec},
+				Duration: &DiscoveredMetric{Metric: "http_server_duration_milliseconds", Type: metricTypeHistogram, Unit: unitMilliseconds},
+			},
+		},
+		{
+			name:    "counter type from metadata",
+			metrics: []string{"rpc_calls", "process_cpu_seconds"},
+			types:   map[string]string{"rpc_calls": metricTypeCounter},
+			want: &REDMetrics{
+				Requests: &DiscoveredMetric{Metric: "rpc_calls", Type: metricTypeCounter, Unit: unitRequestsPerSec},
+			},
+		},
+		{
+			name:    "no red metrics",
+			metrics: []string{"go_goroutines", "process_resident_memory_bytes"},
+			want:    &REDMetrics{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, discoverRED(tc.metrics, tc.types))
+		})
+	}
+}
+
+func TestRequestLabels(t *testing.T) {
+	status, route := requestLabels([]string{"__name__", "method", "code", "handler", "service"})
+	require.Equal(t, "code", status)
+	require.Equal(t, "handler", route)
+
+	status, route = requestLabels([]string{"__name__", "service"})
+	require.Empty(t, status)
+	require.Empty(t, route)
+}
+
+func TestBuildREDDashboard(t *testing.T) {
+	red := &REDMetrics{
+		Requests:    &DiscoveredMetric{Metric: "grpc_server_handled_total", Type: metricTypeCounter, Unit: unitRequestsPerSec},
+		Duration:    &DiscoveredMetric{Metric: "grpc_server_handling_seconds", Type: metricTypeHistogram, Unit: unitSeconds},
+		StatusLabel: "grpc_code",
+		RouteLabel:  "grpc_method",
+	}
+	b, err := json.Marshal(buildREDDashboard("checkout RED", "checkout", "service_name", red))
+	require.NoError(t, err)
+
+	// The generated definition must read back like any other dashboard.
+	d, err := dashboardjson.Parse(string(b))
+	require.NoError(t, err)
+	require.Equal(t, dashboardjson.KindDashboard, d.Kind)
+	require.Equal(t, []string{"checkout"}, d.Spec.Variables[0].Defaults())
+	require.Equal(t, "service_name", d.Spec.Variables[0].Spec.Plugin.Spec["labelName"])
+
+	panels := d.OrderedPanels()
+	require.Len(t, panels, 5)
+	var keys []string
+	for _, p := range panels {
+		keys = append(keys, p.Key)
+		require.Equal(t, "RED", p.Group)
+	}
+	require.Equal(t, []string{"request-rate", "error-ratio", "latency", "request-rate-by-grpc_method", "p99-latency-by-grpc_method"}, keys)
+
+	require.Equal(t, unitPercentDecimal, panels[1].Panel.Unit())
+	require.Equal(t, []string{
+		`sum(rate(grpc_server_handled_total{service_name="$service",grpc_code!="OK"}[5m])) / sum(rate(grpc_server_handled_total{service_name="$service"}[5m]))`,
+	}, panels[1].Panel.PromQL())
+	require.Equal(t, unitSeconds, panels[2].Panel.Unit())
+	require.Len(t, panels[2].Panel.PromQL(), 3)
+	require.Equal(t, []string{
+		`histogram_quantile(0.99, sum by (le, grpc_method) (rate(grpc_server_handling_seconds_bucket{service_name="$service"}[5m])))`,
+	}, panels[4].Panel.PromQL())
+}
@@ -0,0 +1,386 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	dashboardjson "github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+)
+
+const (
+	// matchAll is substituted for variables that select every value.
+	matchAll = ".*"
+
+	variableSourceParam   = "param"
+	variableSourceDefault = "default"
+	variableSourceAll     = "all"
+
+	targetPoints   = 200
+	minStepSecs    = 60
+	topSeriesCount = 5
+)
+
+// DashboardSummary is what a dashboard shows over a time range.
+type DashboardSummary struct {
+	Slug        string           `json:"slug"`
+	Name        string           `json:"name,omitempty"`
+	Start       time.Time        `json:"start"`
+	End         time.Time        `json:"end"`
+	StepSecs    int              `json:"step_secs"`
+	Variables   []*VariableValue `json:"variables,omitempty"`
+	TotalPanels int              `json:"total_panels"`
+	Panels      []*PanelSummary  `json:"panels"`
+	Warnings    []string         `json:"warnings,omitempty"`
+}
+
+// VariableValue is the value a dashboard variable was substituted with.
+type VariableValue struct {
+	Name   string   `json:"name"`
+	Kind   string   `json:"kind,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Source string   `json:"source"`
+}
+
+// PanelSummary is what a single panel shows.
+type PanelSummary struct {
+	Key         string          `json:"key"`
+	Group       string          `json:"group,omitempty"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Kind        string          `json:"kind"`
+	Unit        string          `json:"unit,omitempty"`
+	Queries     []*QuerySummary `json:"queries,omitempty"`
+	ImageIndex  *int            `json:"image_index,omitempty"`
+}
+
+// QuerySummary summarizes the result of a panel query.
+type QuerySummary struct {
+	Query     string         `json:"query"`
+	Series    int            `json:"series"`
+	Min       *float64       `json:"min,omitempty"`
+	Max       *float64       `json:"max,omitempty"`
+	Avg       *float64       `json:"avg,omitempty"`
+	TopSeries []*SeriesStats `json:"top_series,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// SeriesStats summarizes a single series of a query result.
+type SeriesStats struct {
+	Labels map[string]string `json:"labels"`
+	Min    float64           `json:"min"`
+	Max    float64           `json:"max"`
+	Avg    float64           `json:"avg"`
+	Last   float64           `json:"last"`
+}
+
+func (t *Tools) summarizeDashboard(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	variables, err := params.Object[map[string]any](request, "variables", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	timeRange, err := params.ParseTimeRange(request)
+	if err != nil {
+		return nil, err
+	}
+	step, err := params.Int(request, "step_seconds", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	includeImages, err := params.Bool(request, "include_images", false, false)
+	if err != nil {
+		return nil, err
+	}
+	maxPanels, err := params.Int(request, "max_panels", false, 30)
+	if err != nil {
+		return nil, err
+	}
+	maxImages, err := params.Int(request, "max_images", false, 6)
+	if err != nil {
+		return nil, err
+	}
+	if step <= 0 {
+		step = defaultStep(timeRange)
+	}
+
+	resp, err := t.configAPI.Dashboard.ReadDashboard(&dashboard.ReadDashboardParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dashboard %s: %s", slug, err)
+	}
+	d, err := dashboardjson.Parse(resp.Payload.Dashboard.DashboardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	givenValues, err := variableParams(variables)
+	if err != nil {
+		return nil, err
+	}
+	values, used := resolveVariables(d.Spec.Variables, givenValues)
+	summary := &DashboardSummary{
+		Slug:      slug,
+		Name:      resp.Payload.Dashboard.Name,
+		Start:     timeRange.Start,
+		End:       timeRange.End,
+		StepSecs:  step,
+		Variables: used,
+	}
+
+	panels := d.OrderedPanels()
+	summary.TotalPanels = len(panels)
+	if maxPanels > 0 && len(panels) > maxPanels {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf(
+			"dashboard has %d panels; only the first %d are evaluated", len(panels), maxPanels))
+		panels = panels[:maxPanels]
+	}
+
+	var images [][]byte
+	for _, ref := range panels {
+		ps := &PanelSummary{
+			Key:         ref.Key,
+			Group:       ref.Group,
+			Title:       ref.Panel.Spec.Display.Name,
+			Description: ref.Panel.Spec.Display.Description,
+			Kind:        ref.Panel.Spec.Plugin.Kind,
+			Unit:        ref.Panel.Unit(),
+		}
+		var rendered model.Matrix
+		for _, q := range ref.Panel.PromQL() {
+			query := promql.ReplaceVariablesAsRegex(q, values)
+			qs := &QuerySummary{Query: query}
+			matrix, err := t.queryRange(ctx, query, timeRange, step)
+			if err != nil {
+				qs.Error = err.Error()
+			} else {
+				summarizeMatrix(qs, matrix)
+				rendered = append(rendered, matrix...)
+			}
+			ps.Queries = append(ps.Queries, qs)
+		}
+		if includeImages && len(rendered) > 0 && len(images) < maxImages {
+			buf := bytes.NewBuffer(nil)
+			if err := t.renderer.RenderSeries(buf, rendered, 1024, 512, len(rendered) <= 10); err != nil {
+				summary.Warnings = append(summary.Warnings, fmt.Sprintf("failed to render panel %s: %s", ref.Key, err))
+			} else {
+				idx := len(images)
+				ps.ImageIndex = &idx
+				images = append(images, buf.Bytes())
+			}
+		}
+		summary.Panels = append(summary.Panels, ps)
+	}
+
+	link := t.linkBuilder.Custom("/dashboards/"+slug).
+		WithTimeSec("start", timeRange.Start).
+		WithTimeSec("end", timeRange.End)
+	for _, v := range used {
+		if v.Source == variableSourceParam {
+			for _, value := range v.Values {
+				link.WithParam("var-"+v.Name, value)
+			}
+		}
+	}
+	return &tools.Result{
+		JSONContent:      summary,
+		Images:           images,
+		ChronosphereLink: link.String(),
+	}, nil
+}
+
+func (t *Tools) queryRange(ctx context.Context, query string, timeRange *params.TimeRange, step int) (model.Matrix, error) {
+	resp, _, err := t.promAPI.QueryRange(ctx, query, v1.Range{
+		Start: timeRange.Start,
+		End:   timeRange.End,
+		Step:  time.Duration(step) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query range: %s", err)
+	}
+	matrix, ok := resp.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s from prometheus server", resp.Type())
+	}
+	return matrix, nil
+}
+
+func defaultStep(timeRange *params.TimeRange) int {
+	step := int(timeRange.End.Sub(timeRange.Start).Seconds()) / targetPoints
+	if step < minStepSecs {
+		step = minStepSecs
+	}
+	return step
+}
+
+// variableParams converts the variables parameter into values per variable. A list of values
+// selects several values.
+func variableParams(variables map[string]any) (map[string][]string, error) {
+	out := make(map[string][]string, len(variables))
+	for name, v := range variables {
+		switch value := v.(type) {
+		case string:
+			out[name] = []string{value}
+		case []any:
+			for _, item := range value {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("variable %s must be a string or a list of strings", name)
+				}
+				out[name] = append(out[name], s)
+			}
+		default:
+			return nil, fmt.Errorf("variable %s must be a string or a list of strings", name)
+		}
+	}
+	return out, nil
+}
+
+// resolveVariables returns the regular expression each variable is substituted with and how
+// the value was chosen. Given values take precedence over defaults, and variables without a
+// value or defaulting to all values match any value.
+func resolveVariables(variables []*dashboardjson.Variable, given map[string][]string) (map[string]string, []*VariableValue) {
+	values := make(map[string]string, len(variables)+len(given))
+	used := make([]*VariableValue, 0, len(variables))
+	seen := make(map[string]bool, len(variables))
+	for _, v := range variables {
+		if v == nil {
+			continue
+		}
+		name := v.Spec.Name
+		seen[name] = true
+		vv := &VariableValue{Name: name, Kind: v.Kind}
+		if v.Spec.Plugin != nil {
+			vv.Kind = v.Spec.Plugin.Kind
+		}
+		switch {
+		case len(given[name]) > 0:
+			vv.Values, vv.Source = given[name], variableSourceParam
+		case len(v.Defaults()) > 0 && !containsAll(v.Defaults()):
+			vv.Values, vv.Source = v.Defaults(), variableSourceDefault
+		default:
+			vv.Source = variableSourceAll
+		}
+		values[name] = variableRegex(vv.Values)
+		used = append(used, vv)
+	}
+	// Values for variables not declared by the dashboard are still substituted, since queries
+	// may reference variables of a parent scope.
+	var undeclared []string
+	for name := range given {
+		if !seen[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		values[name] = variableRegex(given[name])
+		used = append(used, &VariableValue{Name: name, Values: given[name], Source: variableSourceParam})
+	}
+	return values, used
+}
+
+func containsAll(values []string) bool {
+	for _, v := range values {
+		if v == dashboardjson.AllValue {
+			return true
+		}
+	}
+	return false
+}
+
+// variableRegex returns a regular expression selecting any of the values.
+func variableRegex(values []string) string {
+	if len(values) == 0 {
+		return matchAll
+	}
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, regexp.QuoteMeta(v))
+	}
+	return strings.Join(quoted, "|")
+}
+
+// summarizeMatrix sets the series count and statistics of a query result, ignoring NaN values.
+func summarizeMatrix(qs *QuerySummary, matrix model.Matrix) {
+	qs.Series = len(matrix)
+	var (
+		stats  []*SeriesStats
+		lo, hi = math.Inf(1), math.Inf(-1)
+		sum    float64
+		count  int
+	)
+	for _, s := range matrix {
+		ss := &SeriesStats{Labels: make(map[string]string, len(s.Metric)), Min: math.Inf(1), Max: math.Inf(-1)}
+		for k, v := range s.Metric {
+			ss.Labels[string(k)] = string(v)
+		}
+		var (
+			seriesSum float64
+			n         int
+		)
+		for _, p := range s.Values {
+			v := float64(p.Value)
+			if math.IsNaN(v) {
+				continue
+			}
+			ss.Min = math.Min(ss.Min, v)
+			ss.Max = math.Max(ss.Max, v)
+			ss.Last = v
+			seriesSum += v
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		ss.Avg = seriesSum / float64(n)
+		lo, hi = math.Min(lo, ss.Min), math.Max(hi, ss.Max)
+		sum += seriesSum
+		count += n
+		stats = append(stats, ss)
+	}
+	if count == 0 {
+		return
+	}
+	avg := sum / float64(count)
+	qs.Min, qs.Max, qs.Avg = &lo, &hi, &avg
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Avg > stats[j].Avg
+	})
+	if len(stats) > topSeriesCount {
+		stats = stats[:topSeriesCount]
+	}
+	qs.TopSeries = stats
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	dashboardjson "github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+)
+
+func TestResolveVariables(t *testing.T) {
+	variables := []*dashboardjson.Variable{
+		{Kind: dashboardjson.KindListVariable, Spec: dashboardjson.VariableSpec{Name: "env", DefaultValue: "prod"}},
+		{Kind: dashboardjson.KindListVariable, Spec: dashboardjson.VariableSpec{Name: "pod", DefaultValue: []any{dashboardjson.AllValue}}},
+		{Kind: dashboardjson.KindListVariable, Spec: dashboardjson.VariableSpec{
+			Name:   "service",
+			Plugin: &dashboardjson.Plugin{Kind: dashboardjson.KindPrometheusLabelValuesVariable},
+		}},
+	}
+	given := map[string][]string{
+		"service": {"checkout", "cart.v2"},
+		"region":  {"us-east-1"},
+	}
+
+	values, used := resolveVariables(variables, given)
+	require.Equal(t, map[string]string{
+		"env":     "prod",
+		"pod":     ".*",
+		"service": `checkout|cart\.v2`,
+		"region":  "us-east-1",
+	}, values)
+	require.Equal(t, []*VariableValue{
+		{Name: "env", Kind: dashboardjson.KindListVariable, Values: []string{"prod"}, Source: variableSourceDefault},
+		{Name: "pod", Kind: dashboardjson.KindListVariable, Source: variableSourceAll},
+		{Name: "service", Kind: dashboardjson.KindPrometheusLabelValuesVariable, Values: []string{"checkout", "cart.v2"}, Source: variableSourceParam},
+		{Name: "region", Values: []string{"us-east-1"}, Source: variableSourceParam},
+	}, used)
+}
+
+func TestVariableParams(t *testing.T) {
+	got, err := variableParams(map[string]any{"env": "prod", "pod": []any{"a", "b"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"env": {"prod"}, "pod": {"a", "b"}}, got)
+
+	_, err = variableParams(map[string]any{"replicas": 3.0})
+	require.ErrorContains(t, err, "variable replicas must be a string or a list of strings")
+}
+
+func TestSummarizeMatrix(t *testing.T) {
+	matrix := model.Matrix{
+		{
+			Metric: model.Metric{"pod": "a"},
+			Values: []model.SamplePair{{Timestamp: 0, Value: 1}, {Timestamp: 60, Value: 3}},
+		},
+		{
+			Metric: model.Metric{"pod": "b"},
+			Values: []model.SamplePair{{Timestamp: 0, Value: 10}, {Timestamp: 60, Value: model.SampleValue(math.NaN())}},
+		},
+		{
+			Metric: model.Metric{"pod": "c"},
+			Values: []model.SamplePair{{Timestamp: 0, Value: model.SampleValue(math.NaN())}},
+		},
+	}
+
+	var qs QuerySummary
+	summarizeMatrix(&qs, matrix)
+	require.Equal(t, 3, qs.Series)
+	require.Equal(t, 1.0, *qs.Min)
+	require.Equal(t, 10.0, *qs.Max)
+	require.InDelta(t, 14.0/3, *qs.Avg, 1e-9)
+	require.Len(t, qs.TopSeries, 2)
+	require.Equal(t, "b", qs.TopSeries[0].Labels["pod"])
+	require.Equal(t, 10.0, qs.TopSeries[0].Last)
+	require.Equal(t, 2.0, qs.TopSeries[1].Avg)
+
+	var empty QuerySummary
+	summarizeMatrix(&empty, model.Matrix{})
+	require.Nil(t, empty.Avg)
+}
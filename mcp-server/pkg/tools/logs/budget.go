@@ -0,0 +1,379 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_allocation_config"
+	configv1models "github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable/data_unstable"
+	dataunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/models"
+	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1/version1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// defaultDatasetName names the default dataset, which holds the logs of no other dataset.
+const defaultDatasetName = "default"
+
+// LogBudgetStatus is the log volume of each dataset compared to its allocation.
+type LogBudgetStatus struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// TotalBytes is the volume of all datasets.
+	TotalBytes float64          `json:"total_bytes"`
+	Datasets   []*DatasetBudget `json:"datasets"`
+	// UsageTotalBytes is the total volume reported by logging usage.
+	UsageTotalBytes           uint64                      `json:"usage_total_bytes,omitempty"`
+	TopPatterns               []*LogPattern               `json:"top_patterns,omitempty"`
+	ConfiguredLogScaleActions []*ConfiguredLogScaleAction `json:"configured_log_scale_actions,omitempty"`
+	Warnings                  []string                    `json:"warnings,omitempty"`
+}
+
+// DatasetBudget is the log volume of a dataset compared to its allocation.
+type DatasetBudget struct {
+	Slug string `json:"slug,omitempty"`
+	Name string `json:"name"`
+	// Query is the match criteria of the dataset.
+	Query         string  `json:"query,omitempty"`
+	Bytes         float64 `json:"bytes"`
+	VolumePercent float64 `json:"volume_percent"`
+	// AllocationPercent is the percent of the license allocated to the dataset.
+	AllocationPercent float64 `json:"allocation_percent"`
+	// RelativeAllocationUsePercent is the volume percent relative to the allocation percent.
+	// Both are shares, of the volume in the time range and of the license, so this is not
+	// the use of the license volume allocated to the dataset, which is not known.
+	RelativeAllocationUsePercent float64 `json:"relative_allocation_use_percent"`
+	// OverAllocationShare is whether the dataset's share of the volume is larger than its
+	// share of the license. It only leads to drops if the license is exceeded as a whole.
+	OverAllocationShare bool     `json:"over_allocation_share"`
+	HighPriorityFilters []string `json:"high_priority_filters,omitempty"`
+	LowPriorityFilters  []string `json:"low_priority_filters,omitempty"`
+}
+
+// LogPattern is a log pattern with its volume.
+type LogPattern struct {
+	Pattern        string `json:"pattern"`
+	LogQuery       string `json:"log_query,omitempty"`
+	VolumeBytes    uint64 `json:"volume_bytes"`
+	VolumeBytes24h uint64 `json:"volume_bytes_24h"`
+}
+
+// ConfiguredLogScaleAction is a LogScale action configured on enabled LogScale alerts, which
+// is triggered when one of the alerts fires.
+type ConfiguredLogScaleAction struct {
+	Slug       string   `json:"slug"`
+	Name       string   `json:"name,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	AlertSlugs []string `json:"alert_slugs"`
+}
+
+// datasetVolumeQuery is the log query selecting the logs of a dataset.
+type datasetVolumeQuery struct {
+	budget *DatasetBudget
+	// query selects the logs of the dataset, excluding the logs of datasets before it.
+	query string
+}
+
+func (t *Tools) getLogBudgetStatus(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	timeRange, err := t.parseLogTimeRange(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	topPatterns, err := params.Int(request, "top_patterns", false, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &LogBudgetStatus{Start: timeRange.Start, End: timeRange.End}
+	var allocation *configv1models.Configv1LogAllocationConfig
+	resp, err := t.configAPI.LogAllocationConfig.ReadLogAllocationConfig(&log_allocation_config.ReadLogAllocationConfigParams{
+		Context: ctx,
+	})
+	var notFound *log_allocation_config.ReadLogAllocationConfigNotFound
+	switch {
+	case errors.As(err, &notFound):
+		status.Warnings = append(status.Warnings, "no log allocation config exists, so all logs are in the default dataset")
+	case err != nil:
+		return nil, fmt.Errorf("failed to read log allocation config: %s", err)
+	default:
+		allocation = resp.Payload.LogAllocationConfig
+	}
+
+	var datasets []*configv1models.Configv1Dataset
+	if allocation != nil && len(allocation.DatasetAllocations) > 0 {
+		var slugs []string
+		for _, a := range allocation.DatasetAllocations {
+			if a != nil {
+				slugs = append(slugs, a.DatasetSlug)
+			}
+		}
+		datasets, err = configlist.Datasets(ctx, t.configAPI, configlist.Filter{Slugs: slugs})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	queries, warnings := datasetVolumeQueries(allocation, datasets)
+	status.Warnings = append(status.Warnings, warnings...)
+	for _, q := range queries {
+		bytes, err := t.queryLogBytes(ctx, q.query, timeRange.Start, timeRange.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query volume of dataset %s: %s", q.budget.Name, err)
+		}
+		q.budget.Bytes = bytes
+		status.Datasets = append(status.Datasets, q.budget)
+	}
+	status.TotalBytes = computeBudgetUse(status.Datasets)
+
+	usage, err := t.dataUnstableAPI.DataUnstable.GetLoggingUsage(&data_unstable.GetLoggingUsageParams{
+		Context:     ctx,
+		After:       (*strfmt.DateTime)(&timeRange.Start),
+		Before:      (*strfmt.DateTime)(&timeRange.End),
+		Order:       ptr.To(string(dataunstablemodels.GetLoggingUsageRequestResponseOrderTypeVOLUMEDESC)),
+		PageMaxSize: ptr.To(int64(topPatterns)),
+	})
+	if err != nil {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("failed to get logging usage: %s", err))
+	} else {
+		status.UsageTotalBytes, status.TopPatterns = logPatterns(usage.Payload, topPatterns)
+	}
+
+	actions, err := t.configuredLogScaleActions(ctx)
+	if err != nil {
+		status.Warnings = append(status.Warnings, err.Error())
+	}
+	status.ConfiguredLogScaleActions = actions
+
+	return &tools.Result{JSONContent: status}, nil
+}
+
+// datasetVolumeQueries returns the queries selecting the logs of each allocated dataset and of
+// the default dataset. Datasets are evaluated in order, so logs matching an earlier dataset are
+// excluded from later ones.
+func datasetVolumeQueries(
+	allocation *configv1models.Configv1LogAllocationConfig,
+	datasets []*configv1models.Configv1Dataset,
+) ([]*datasetVolumeQuery, []string) {
+	bySlug := make(map[string]*configv1models.Configv1Dataset, len(datasets))
+	for _, d := range datasets {
+		bySlug[d.Slug] = d
+	}
+	if allocation == nil {
+		allocation = &configv1models.Configv1LogAllocationConfig{}
+	}
+
+	var (
+		queries  []*datasetVolumeQuery
+		excluded []string
+		warnings []string
+	)
+	for _, a := range allocation.DatasetAllocations {
+		if a == nil {
+			continue
+		}
+		budget := &DatasetBudget{Slug: a.DatasetSlug, Name: a.DatasetSlug}
+		setAllocation(budget, a.Allocation, a.Priorities)
+		d, ok := bySlug[a.DatasetSlug]
+		if ok {
+			budget.Name = d.Name
+			if c := d.Configuration; c != nil && c.LogDataset != nil && c.LogDataset.MatchCriteria != nil {
+				budget.Query = c.LogDataset.MatchCriteria.Query
+			}
+		}
+		if budget.Query == "" {
+			warnings = append(warnings, fmt.Sprintf("dataset %s has no log match criteria, so its volume is not known", a.DatasetSlug))
+			continue
+		}
+		query := strings.Join(append([]string{"(" + budget.Query + ")"}, excluded...), " AND ")
+		queries = append(queries, &datasetVolumeQuery{budget: budget, query: query})
+		excluded = append(excluded, "NOT ("+budget.Query+")")
+	}
+
+	budget := &DatasetBudget{Name: defaultDatasetName}
+	if d := allocation.DefaultDataset; d != nil {
+		setAllocation(budget, d.Allocation, d.Priorities)
+	}
+	queries = append(queries, &datasetVolumeQuery{budget: budget, query: strings.Join(excluded, " AND ")})
+	return queries, warnings
+}
+
+func setAllocation(
+	budget *DatasetBudget,
+	allocation *configv1models.Configv1LogAllocationConfigAllocation,
+	priorities *configv1models.LogAllocationConfigHighLowPriorities,
+) {
+	if allocation != nil {
+		budget.AllocationPercent = allocation.PercentOfLicense
+	}
+	if priorities == nil {
+		return
+	}
+	for _, f := range priorities.HighPriorityFilters {
+		if f != nil {
+			budget.HighPriorityFilters = append(budget.HighPriorityFilters, f.Query)
+		}
+	}
+	for _, f := range priorities.LowPriorityFilters {
+		if f != nil {
+			budget.LowPriorityFilters = append(budget.LowPriorityFilters, f.Query)
+		}
+	}
+}
+
+// computeBudgetUse sets the share of the total volume of each dataset and compares it with
+// the dataset's share of the license, and returns the total volume. The license volume is
+// not known, so only shares are compared.
+func computeBudgetUse(budgets []*DatasetBudget) float64 {
+	var total float64
+	for _, b := range budgets {
+		total += b.Bytes
+	}
+	if total == 0 {
+		return 0
+	}
+	for _, b := range budgets {
+		b.VolumePercent = b.Bytes / total * 100
+		if b.AllocationPercent > 0 {
+			b.RelativeAllocationUsePercent = b.VolumePercent / b.AllocationPercent * 100
+		}
+		b.OverAllocationShare = b.VolumePercent > b.AllocationPercent
+	}
+	return total
+}
+
+// queryLogBytes returns the total payload size of the logs matching the query.
+func (t *Tools) queryLogBytes(ctx context.Context, query string, start, end time.Time) (float64, error) {
+	query = strings.TrimSpace(query + " | summarize sum(_payloadSize)")
+	resp, err := t.dataV1API.Version1.QueryLogsRange(&version1.QueryLogsRangeParams{
+		Context:         ctx,
+		Query:           &query,
+		TimeRangeAfter:  (*strfmt.DateTime)(&start),
+		TimeRangeBefore: (*strfmt.DateTime)(&end),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return summarizedValue(resp.Payload)
+}
+
+// summarizedValue returns the single value of a summarize query without groups.
+func summarizedValue(payload *models.Datav1QueryLogsRangeResponse) (float64, error) {
+	if payload == nil || payload.GridData == nil || len(payload.GridData.Rows) == 0 {
+		return 0, nil
+	}
+	row := payload.GridData.Rows[0]
+	if row == nil || len(row.Values) == 0 || row.Values[0] == nil {
+		return 0, nil
+	}
+	v := row.Values[0]
+	if v.StringValue != "" {
+		f, err := strconv.ParseFloat(v.StringValue, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse summarized value %q: %s", v.StringValue, err)
+		}
+		return f, nil
+	}
+	return v.FloatValue, nil
+}
+
+// logPatterns returns the total volume and the top patterns of the logging usage.
+func logPatterns(usage *dataunstablemodels.DataunstableGetLoggingUsageResponse, limit int) (uint64, []*LogPattern) {
+	if usage == nil {
+		return 0, nil
+	}
+	var total uint64
+	if usage.Meta != nil {
+		total, _ = strconv.ParseUint(usage.Meta.TotalBytes, 10, 64)
+	}
+	var patterns []*LogPattern
+	for _, c := range usage.Clusters {
+		if c == nil {
+			continue
+		}
+		p := &LogPattern{Pattern: c.Pattern, LogQuery: c.LogQuery}
+		p.VolumeBytes, _ = strconv.ParseUint(c.VolumeBytes, 10, 64)
+		p.VolumeBytes24h, _ = strconv.ParseUint(c.VolumeBytes24h, 10, 64)
+		patterns = append(patterns, p)
+	}
+	slices.SortStableFunc(patterns, func(a, b *LogPattern) int {
+		switch {
+		case a.VolumeBytes > b.VolumeBytes:
+			return -1
+		case a.VolumeBytes < b.VolumeBytes:
+			return 1
+		}
+		return 0
+	})
+	if limit > 0 && len(patterns) > limit {
+		patterns = patterns[:limit]
+	}
+	return total, patterns
+}
+
+// configuredLogScaleActions returns the LogScale actions of enabled LogScale alerts. Alert
+// state is not available, so whether the alerts have fired is not known.
+func (t *Tools) configuredLogScaleActions(ctx context.Context) ([]*ConfiguredLogScaleAction, error) {
+	alerts, err := configlist.LogScaleAlerts(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	alertSlugs := make(map[string][]string)
+	var actionSlugs []string
+	for _, a := range alerts {
+		if a == nil || a.Disabled {
+			continue
+		}
+		for _, slug := range a.LogScaleActionSlugs {
+			if _, ok := alertSlugs[slug]; !ok {
+				actionSlugs = append(actionSlugs, slug)
+			}
+			alertSlugs[slug] = append(alertSlugs[slug], a.Slug)
+		}
+	}
+	if len(actionSlugs) == 0 {
+		return nil, nil
+	}
+	actions, err := configlist.LogScaleActions(ctx, t.configAPI, configlist.Filter{Slugs: actionSlugs})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ConfiguredLogScaleAction, 0, len(actions))
+	for _, a := range actions {
+		if a == nil {
+			continue
+		}
+		result = append(result, &ConfiguredLogScaleAction{
+			Slug:       a.Slug,
+			Name:       a.Name,
+			Type:       string(a.ActionType),
+			AlertSlugs: alertSlugs[a.Slug],
+		})
+	}
+	return result, nil
+}
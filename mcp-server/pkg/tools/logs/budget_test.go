@@ -0,0 +1,129 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configv1models "github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	dataunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/models"
+	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
+)
+
+func TestDatasetVolumeQueries(t *testing.T) {
+	logDataset := func(slug, name, query string) *configv1models.Configv1Dataset {
+		return &configv1models.Configv1Dataset{
+			Slug: slug,
+			Name: name,
+			Configuration: &configv1models.DatasetDatasetConfiguration{
+				LogDataset: &configv1models.Configv1LogDataset{MatchCriteria: &configv1models.Configv1LogSearchFilter{Query: query}},
+			},
+		}
+	}
+	allocation := &configv1models.Configv1LogAllocationConfig{
+		DatasetAllocations: []*configv1models.LogAllocationConfigDatasetAllocation{
+			{
+				DatasetSlug: "payments",
+				Allocation:  &configv1models.Configv1LogAllocationConfigAllocation{PercentOfLicense: 20},
+				Priorities: &configv1models.LogAllocationConfigHighLowPriorities{
+					LowPriorityFilters: []*configv1models.Configv1LogSearchFilter{{Query: `severity = "DEBUG"`}},
+				},
+			},
+			{DatasetSlug: "platform", Allocation: &configv1models.Configv1LogAllocationConfigAllocation{PercentOfLicense: 50}},
+			{DatasetSlug: "missing", Allocation: &configv1models.Configv1LogAllocationConfigAllocation{PercentOfLicense: 10}},
+		},
+		DefaultDataset: &configv1models.LogAllocationConfigDefaultDataset{
+			Allocation: &configv1models.Configv1LogAllocationConfigAllocation{PercentOfLicense: 20},
+		},
+	}
+	datasets := []*configv1models.Configv1Dataset{
+		logDataset("payments", "Payments", `service = "payments"`),
+		logDataset("platform", "Platform", `kubernetes.namespace_name = "platform"`),
+	}
+
+	queries, warnings := datasetVolumeQueries(allocation, datasets)
+	require.Equal(t, []string{"dataset missing has no log match criteria, so its volume is not known"}, warnings)
+	var got []string
+	for _, q := range queries {
+		got = append(got, q.budget.Name+": "+q.query)
+	}
+	require.Equal(t, []string{
+		`Payments: (service = "payments")`,
+		`Platform: (kubernetes.namespace_name = "platform") AND NOT (service = "payments")`,
+		`default: NOT (service = "payments") AND NOT (kubernetes.namespace_name = "platform")`,
+	}, got)
+	require.Equal(t, []string{`severity = "DEBUG"`}, queries[0].budget.LowPriorityFilters)
+
+	queries[0].budget.Bytes = 400
+	queries[1].budget.Bytes = 400
+	queries[2].budget.Bytes = 200
+	budgets := []*DatasetBudget{queries[0].budget, queries[1].budget, queries[2].budget}
+	require.Equal(t, float64(1000), computeBudgetUse(budgets))
+	require.Equal(t, &DatasetBudget{
+		Slug:                         "payments",
+		Name:                         "Payments",
+		Query:                        `service = "payments"`,
+		Bytes:                        400,
+		VolumePercent:                40,
+		AllocationPercent:            20,
+		RelativeAllocationUsePercent: 200,
+		OverAllocationShare:          true,
+		LowPriorityFilters:           []string{`severity = "DEBUG"`},
+	}, budgets[0])
+	require.False(t, budgets[1].OverAllocationShare)
+	require.Equal(t, float64(80), budgets[1].RelativeAllocationUsePercent)
+	require.False(t, budgets[2].OverAllocationShare)
+
+	queries, warnings = datasetVolumeQueries(nil, nil)
+	require.Empty(t, warnings)
+	require.Len(t, queries, 1)
+	require.Equal(t, "", queries[0].query)
+}
+
+func TestSummarizedValue(t *testing.T) {
+	value, err := summarizedValue(&models.Datav1QueryLogsRangeResponse{GridData: &models.QueryLogsRangeResponseGridData{
+		Rows: []*models.QueryLogsRangeResponseRow{{Values: []*models.QueryLogsRangeResponseRowValue{{FloatValue: 1234}}}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, float64(1234), value)
+
+	value, err = summarizedValue(&models.Datav1QueryLogsRangeResponse{GridData: &models.QueryLogsRangeResponseGridData{
+		Rows: []*models.QueryLogsRangeResponseRow{{Values: []*models.QueryLogsRangeResponseRowValue{{StringValue: "5678"}}}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, float64(5678), value)
+
+	value, err = summarizedValue(&models.Datav1QueryLogsRangeResponse{})
+	require.NoError(t, err)
+	require.Zero(t, value)
+}
+
+func TestLogPatterns(t *testing.T) {
+	total, patterns := logPatterns(&dataunstablemodels.DataunstableGetLoggingUsageResponse{
+		Meta: &dataunstablemodels.GetLoggingUsageResponseMetadata{TotalBytes: "1000"},
+		Clusters: []*dataunstablemodels.DataunstableGetLoggingUsageResponseLogCluster{
+			{Pattern: "small", VolumeBytes: "10"},
+			{Pattern: "large", VolumeBytes: "500", VolumeBytes24h: "9000", LogQuery: `service = "payments"`},
+			{Pattern: "medium", VolumeBytes: "100"},
+		},
+	}, 2)
+	require.Equal(t, uint64(1000), total)
+	require.Equal(t, []*LogPattern{
+		{Pattern: "large", LogQuery: `service = "payments"`, VolumeBytes: 500, VolumeBytes24h: 9000},
+		{Pattern: "medium", VolumeBytes: 100},
+	}, patterns)
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package logs provides tools for querying Chronosphere logs.
+// Package logs provides tools for querying Chronosphere logs and their budget.
 package logs
 
 import (
@@ -22,8 +22,10 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
 	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable"
 	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable/data_unstable"
 	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1"
@@ -41,23 +43,43 @@ type Tools struct {
 	logger          *zap.Logger
 	dataV1API       *datav1.DataV1API
 	dataUnstableAPI *dataunstable.DataUnstableAPI
+	configAPI       *configv1.ConfigV1API
 	linkBuilder     *links.Builder
+	tenantConfig    *tenantConfigCache
 }
 
 func NewTools(
 	dataV1API *datav1.DataV1API,
 	dataUnstableAPI *dataunstable.DataUnstableAPI,
+	configAPI *configv1.ConfigV1API,
 	logger *zap.Logger,
 	linkBuilder *links.Builder,
+	lc fx.Lifecycle,
 ) (*Tools, error) {
 	logger.Info("logging tool configured")
 
-	return &Tools{
+	t := &Tools{
 		logger:          logger,
 		dataV1API:       dataV1API,
 		dataUnstableAPI: dataUnstableAPI,
+		configAPI:       configAPI,
 		linkBuilder:     linkBuilder,
-	}, nil
+	}
+	t.tenantConfig = &tenantConfigCache{fetch: t.fetchTenantConfiguration}
+
+	// The tenant configuration limits log queries, so it is loaded up front. Failing to load it
+	// does not prevent the server from starting.
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, tenantConfigStartupTimeout)
+			defer cancel()
+			if _, _, err := t.tenantConfig.load(ctx); err != nil {
+				logger.Warn("failed to load tenant configuration, log query ranges are not limited by retention", zap.Error(err))
+			}
+			return nil
+		},
+	})
+	return t, nil
 }
 
 func (t *Tools) GroupName() string {
@@ -108,7 +130,7 @@ the next page. Consult the Log Query Syntax resource for more details on query s
 					return nil, err
 				}
 
-				timeRange, err := params.ParseTimeRange(request)
+				timeRange, err := t.parseLogTimeRange(ctx, request)
 				if err != nil {
 					return nil, err
 				}
@@ -192,7 +214,7 @@ the next page. Consult the Log Query Syntax resource for more details on query s
 				),
 			),
 			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
-				timeRange, err := params.ParseTimeRange(request)
+				timeRange, err := t.parseLogTimeRange(ctx, request)
 				if err != nil {
 					return nil, err
 				}
@@ -244,7 +266,7 @@ the next page. Consult the Log Query Syntax resource for more details on query s
 				),
 			),
 			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
-				timeRange, err := params.ParseTimeRange(request)
+				timeRange, err := t.parseLogTimeRange(ctx, request)
 				if err != nil {
 					return nil, err
 				}
@@ -295,7 +317,7 @@ the next page. Consult the Log Query Syntax resource for more details on query s
 				),
 			),
 			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
-				timeRange, err := params.ParseTimeRange(request)
+				timeRange, err := t.parseLogTimeRange(ctx, request)
 				if err != nil {
 					return nil, err
 				}
@@ -338,6 +360,42 @@ the next page. Consult the Log Query Syntax resource for more details on query s
 				}, nil
 			},
 		},
+		{
+			Metadata: tools.NewMetadata("get_log_budget_status",
+				mcp.WithDescription(`Gets the log volume of each dataset compared to its allocation in the log allocation config. Use this to answer questions like "why are payments logs being dropped?".
+
+The volume of each dataset is measured with a "| summarize sum(_payloadSize)" log query of its match criteria. Datasets are evaluated in order, so logs matching an earlier dataset are excluded from later ones, and logs matching no dataset are in the default dataset. When the license is exceeded, datasets using more than their allocation have their logs dropped first, starting with low priority logs. The top patterns by volume come from logging usage.
+
+Response fields:
+- datasets: Per dataset, the volume in bytes, volume_percent of the total, allocation_percent of the license, relative_allocation_use_percent (volume_percent relative to allocation_percent), over_allocation_share (volume_percent above allocation_percent) and the high and low priority filters. The license volume is not known, so these compare shares: a dataset over its allocation share only has logs dropped when the license is exceeded as a whole
+- total_bytes: Volume of all datasets
+- usage_total_bytes: Total volume reported by logging usage
+- top_patterns: Log patterns with the most volume and the queries selecting them
+- configured_log_scale_actions: LogScale actions configured on enabled LogScale alerts. Whether the alerts fired is not known`),
+				params.WithTimeRange(),
+				mcp.WithNumber("top_patterns",
+					mcp.Description("Number of log patterns with the most volume to return. Default is 10."),
+				),
+			),
+			Handler: t.getLogBudgetStatus,
+		},
+		{
+			Metadata: tools.NewMetadata("get_tenant_configuration",
+				mcp.WithDescription(`Gets tenant-level settings that affect how queries should be written. The configuration is cached when the server starts.
+
+Log query tools reject time ranges that start before the log data retention or are longer than max_log_query_range, so check earliest_log_time before querying old logs. The tenant configuration only includes the retention, so the time range is the only limit derived from it; default result limits are not tenant specific.
+
+Response fields:
+- data_retention_days: Number of days logs are retained
+- max_log_query_range: Longest time range a log query may cover
+- earliest_log_time: Earliest start time of log queries
+- fetched_at: When the configuration was fetched`),
+				mcp.WithBoolean("refresh",
+					mcp.Description("Fetch the configuration again instead of using the cached one. Defaults to false."),
+				),
+			),
+			Handler: t.getTenantConfiguration,
+		},
 	}
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1"
@@ -221,7 +222,7 @@ func TestQueryLogsRangeAPICall(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	linkBuilder := links.NewBuilder("https://test.chronosphere.io")
 
-	tools, err := NewTools(client, nil, logger, linkBuilder)
+	tools, err := NewTools(client, nil, nil, logger, linkBuilder, fxtest.NewLifecycle(t))
 	require.NoError(t, err)
 
 	// Create query params
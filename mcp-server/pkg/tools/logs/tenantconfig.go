@@ -0,0 +1,147 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable/data_unstable"
+	dataunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+const (
+	// tenantConfigStartupTimeout bounds loading the tenant configuration when the server starts.
+	tenantConfigStartupTimeout = 5 * time.Second
+	// tenantConfigRetryInterval is how long to wait before loading the tenant configuration again
+	// after it failed, so that log queries do not each retry it.
+	tenantConfigRetryInterval = time.Minute
+)
+
+// TenantConfiguration is the tenant configuration with the log query limits derived from it.
+type TenantConfiguration struct {
+	DataRetentionDays int `json:"data_retention_days"`
+	// MaxLogQueryRange is the longest time range log queries may cover, which is the retention,
+	// e.g. "30d".
+	MaxLogQueryRange string `json:"max_log_query_range,omitempty"`
+	// EarliestLogTime is the earliest time log queries may start at.
+	EarliestLogTime *time.Time `json:"earliest_log_time,omitempty"`
+	FetchedAt       time.Time  `json:"fetched_at"`
+}
+
+// tenantConfigCache caches the tenant configuration, which rarely changes. It is loaded when the
+// server starts, or on first use if that failed.
+type tenantConfigCache struct {
+	fetch func(ctx context.Context) (*dataunstablemodels.DataunstableGetTenantConfigurationResponse, error)
+
+	mu        sync.Mutex
+	config    *dataunstablemodels.DataunstableGetTenantConfigurationResponse
+	fetchedAt time.Time
+	failedAt  time.Time
+}
+
+// load fetches the tenant configuration and caches it.
+func (c *tenantConfigCache) load(ctx context.Context) (*dataunstablemodels.DataunstableGetTenantConfigurationResponse, time.Time, error) {
+	config, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if err != nil {
+		c.failedAt = now
+		return nil, time.Time{}, err
+	}
+	c.config, c.fetchedAt = config, now
+	return config, now, nil
+}
+
+// get returns the cached tenant configuration, loading it if it is not cached and loading has not
+// recently failed. It returns nil if the tenant configuration is unknown.
+func (c *tenantConfigCache) get(ctx context.Context) (*dataunstablemodels.DataunstableGetTenantConfigurationResponse, time.Time) {
+	c.mu.Lock()
+	config, fetchedAt, failedAt := c.config, c.fetchedAt, c.failedAt
+	c.mu.Unlock()
+	if config != nil || time.Since(failedAt) < tenantConfigRetryInterval {
+		return config, fetchedAt
+	}
+	config, fetchedAt, _ = c.load(ctx)
+	return config, fetchedAt
+}
+
+// retention returns the log data retention, or zero if it is unknown.
+func (c *tenantConfigCache) retention(ctx context.Context) time.Duration {
+	config, _ := c.get(ctx)
+	if config == nil {
+		return 0
+	}
+	return time.Duration(config.DataRetentionDays) * 24 * time.Hour
+}
+
+func (t *Tools) fetchTenantConfiguration(ctx context.Context) (*dataunstablemodels.DataunstableGetTenantConfigurationResponse, error) {
+	resp, err := t.dataUnstableAPI.DataUnstable.GetTenantConfiguration(&data_unstable.GetTenantConfigurationParams{
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant configuration: %s", err)
+	}
+	if resp.Payload == nil {
+		return nil, fmt.Errorf("failed to get tenant configuration: empty response")
+	}
+	return resp.Payload, nil
+}
+
+// parseLogTimeRange parses the time range of a log query, which may not start before the log
+// data retention.
+func (t *Tools) parseLogTimeRange(ctx context.Context, request mcp.CallToolRequest) (*params.TimeRange, error) {
+	return params.ParseTimeRangeWithRetention(request, t.tenantConfig.retention(ctx))
+}
+
+func (t *Tools) getTenantConfiguration(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	refresh, err := params.Bool(request, "refresh", false, false)
+	if err != nil {
+		return nil, err
+	}
+	config, fetchedAt := t.tenantConfig.get(ctx)
+	if config == nil || refresh {
+		config, fetchedAt, err = t.tenantConfig.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &tools.Result{JSONContent: newTenantConfiguration(config, fetchedAt, time.Now())}, nil
+}
+
+// newTenantConfiguration returns the tenant configuration with the limits derived from it. The
+// API only exposes the data retention, so only the time range of log queries is limited; result
+// limits keep their defaults.
+func newTenantConfiguration(config *dataunstablemodels.DataunstableGetTenantConfigurationResponse, fetchedAt, now time.Time) *TenantConfiguration {
+	c := &TenantConfiguration{
+		DataRetentionDays: int(config.DataRetentionDays),
+		FetchedAt:         fetchedAt,
+	}
+	if c.DataRetentionDays > 0 {
+		retention := time.Duration(c.DataRetentionDays) * 24 * time.Hour
+		earliest := now.Add(-retention)
+		c.MaxLogQueryRange = fmt.Sprintf("%dd", c.DataRetentionDays)
+		c.EarliestLogTime = &earliest
+	}
+	return c
+}
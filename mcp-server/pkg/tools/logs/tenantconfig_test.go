@@ -0,0 +1,67 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dataunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/models"
+)
+
+func TestTenantConfigCache(t *testing.T) {
+	var (
+		calls int
+		err   = errors.New("unauthorized")
+	)
+	c := &tenantConfigCache{fetch: func(context.Context) (*dataunstablemodels.DataunstableGetTenantConfigurationResponse, error) {
+		calls++
+		if err != nil {
+			return nil, err
+		}
+		return &dataunstablemodels.DataunstableGetTenantConfigurationResponse{DataRetentionDays: 30}, nil
+	}}
+
+	// A failed load is not retried by every log query.
+	_, _, loadErr := c.load(t.Context())
+	require.Error(t, loadErr)
+	require.Zero(t, c.retention(t.Context()))
+	require.Equal(t, 1, calls)
+
+	// Once the retry interval passed, the configuration is loaded on use and then cached.
+	err = nil
+	c.failedAt = time.Now().Add(-tenantConfigRetryInterval)
+	require.Equal(t, 30*24*time.Hour, c.retention(t.Context()))
+	require.Equal(t, 30*24*time.Hour, c.retention(t.Context()))
+	require.Equal(t, 2, calls)
+}
+
+func TestNewTenantConfiguration(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	earliest := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, &TenantConfiguration{
+		DataRetentionDays: 30,
+		MaxLogQueryRange:  "30d",
+		EarliestLogTime:   &earliest,
+		FetchedAt:         now,
+	}, newTenantConfiguration(&dataunstablemodels.DataunstableGetTenantConfigurationResponse{DataRetentionDays: 30}, now, now))
+
+	require.Equal(t, &TenantConfiguration{FetchedAt: now},
+		newTenantConfiguration(&dataunstablemodels.DataunstableGetTenantConfigurationResponse{}, now, now))
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricrules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+)
+
+// maxDerivedMetricDepth bounds how deeply derived metrics using other derived metrics are
+// expanded.
+const maxDerivedMetricDepth = 5
+
+// derivedMetricVariableRe matches variables in derived metric queries, e.g. $service.
+var derivedMetricVariableRe = regexp.MustCompile(`\$([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+// ExpandedQuery is a query with its derived metrics replaced by their definitions.
+type ExpandedQuery struct {
+	Query          string              `json:"query"`
+	ExpandedQuery  string              `json:"expanded_query"`
+	DerivedMetrics []*DerivedMetricUse `json:"derived_metrics"`
+	Warnings       []string            `json:"warnings,omitempty"`
+}
+
+// DerivedMetricUse is a derived metric used in a query and what it was expanded to.
+type DerivedMetricUse struct {
+	MetricName string `json:"metric_name"`
+	Slug       string `json:"slug"`
+	// Selector is the selector using the derived metric, e.g. cpu_usage:service{service="api"}.
+	Selector string `json:"selector"`
+	// QueryIndex is the index of the derived metric query whose selector matched.
+	QueryIndex int    `json:"query_index"`
+	Expansion  string `json:"expansion"`
+	// Depth is 0 for derived metrics used in the query, and 1 or more for derived metrics
+	// used by the definitions of other derived metrics.
+	Depth int `json:"depth"`
+}
+
+func (t *Tools) expandDerivedMetrics(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	query, err := params.String(request, "query", true, "")
+	if err != nil {
+		return nil, err
+	}
+	derivedMetrics, err := configlist.DerivedMetrics(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	result, err := expandDerivedMetrics(query, derivedMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// expandDerivedMetrics replaces the derived metrics used in the query with their definitions.
+func expandDerivedMetrics(query string, derivedMetrics []*models.Configv1DerivedMetric) (*ExpandedQuery, error) {
+	expr, err := promql.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query %q: %s", query, err)
+	}
+	e := &derivedMetricExpander{
+		metrics: make(map[string]*models.Configv1DerivedMetric, len(derivedMetrics)),
+	}
+	for _, dm := range derivedMetrics {
+		e.metrics[dm.MetricName] = dm
+	}
+	expr = e.expand(expr, 0, nil)
+	if paren, ok := expr.(*parser.ParenExpr); ok {
+		expr = paren.Expr
+	}
+
+	result := &ExpandedQuery{
+		Query:          query,
+		ExpandedQuery:  expr.String(),
+		DerivedMetrics: e.uses,
+		Warnings:       e.warnings,
+	}
+	if result.DerivedMetrics == nil {
+		result.DerivedMetrics = []*DerivedMetricUse{}
+	}
+	return result, nil
+}
+
+type derivedMetricExpander struct {
+	// metrics are the derived metrics by metric name.
+	metrics  map[string]*models.Configv1DerivedMetric
+	uses     []*DerivedMetricUse
+	warnings []string
+}
+
+// expand returns the expression with derived metrics expanded. seen are the derived metrics
+// being expanded, to detect derived metrics that use themselves.
+func (e *derivedMetricExpander) expand(expr parser.Expr, depth int, seen []string) parser.Expr {
+	switch n := expr.(type) {
+	case *parser.VectorSelector:
+		return e.expandSelector(n, depth, seen)
+	case *parser.MatrixSelector:
+		if vs, ok := n.VectorSelector.(*parser.VectorSelector); ok {
+			if name := selectorMetricName(vs); e.metrics[name] != nil {
+				e.warnf("derived metric %s is used in the range selector %s, which can not be expanded", name, n)
+			}
+		}
+	case *parser.AggregateExpr:
+		n.Expr = e.expand(n.Expr, depth, seen)
+		if n.Param != nil {
+			n.Param = e.expand(n.Param, depth, seen)
+		}
+	case *parser.BinaryExpr:
+		n.LHS = e.expand(n.LHS, depth, seen)
+		n.RHS = e.expand(n.RHS, depth, seen)
+	case *parser.Call:
+		for i, arg := range n.Args {
+			n.Args[i] = e.expand(arg, depth, seen)
+		}
+	case *parser.SubqueryExpr:
+		n.Expr = e.expand(n.Expr, depth, seen)
+	case *parser.ParenExpr:
+		n.Expr = e.expand(n.Expr, depth, seen)
+	case *parser.UnaryExpr:
+		n.Expr = e.expand(n.Expr, depth, seen)
+	case *parser.StepInvariantExpr:
+		n.Expr = e.expand(n.Expr, depth, seen)
+	}
+	return expr
+}
+
+func (e *derivedMetricExpander) expandSelector(vs *parser.VectorSelector, depth int, seen []string) parser.Expr {
+	name := selectorMetricName(vs)
+	dm := e.metrics[name]
+	if dm == nil {
+		return vs
+	}
+	if slices.Contains(seen, name) {
+		e.warnf("derived metric %s uses itself and was not expanded further", name)
+		return vs
+	}
+	if depth >= maxDerivedMetricDepth {
+		e.warnf("derived metric %s was not expanded since derived metrics are nested more than %d deep", name, maxDerivedMetricDepth)
+		return vs
+	}
+
+	matchers := make(map[string]*labels.Matcher, len(vs.LabelMatchers))
+	for _, m := range vs.LabelMatchers {
+		if m.Name != labels.MetricName {
+			matchers[m.Name] = m
+		}
+	}
+	index, q := selectDerivedMetricQuery(dm, matchers)
+	if q == nil {
+		e.warnf("no query of derived metric %s matches %s", name, vs)
+		return vs
+	}
+
+	// Variables are replaced by the matcher on the label of the same name, falling back to the
+	// default selector of the variable.
+	used := make(map[string]bool)
+	if q.Selector != nil {
+		for _, l := range q.Selector.Labels {
+			used[l.Name] = true
+		}
+	}
+	defaults := make(map[string]string, len(q.Query.Variables))
+	for _, v := range q.Query.Variables {
+		defaults[v.Name] = v.DefaultPrometheusSelector
+	}
+	expansion := derivedMetricVariableRe.ReplaceAllStringFunc(q.Query.PrometheusExpr, func(variable string) string {
+		label := variable[1:]
+		if m, ok := matchers[label]; ok {
+			used[label] = true
+			return m.String()
+		}
+		if d, ok := defaults[label]; ok {
+			return d
+		}
+		return variable
+	})
+	for _, m := range vs.LabelMatchers {
+		if m.Name != labels.MetricName && !used[m.Name] {
+			e.warnf("matcher %s is not a selector label or variable of derived metric %s and is ignored", m, name)
+		}
+	}
+
+	expr, err := promql.Parse(expansion)
+	if err != nil {
+		e.warnf("failed to parse the expansion %q of derived metric %s: %s", expansion, name, err)
+		return vs
+	}
+	inheritModifiers(expr, vs)
+	e.uses = append(e.uses, &DerivedMetricUse{
+		MetricName: name,
+		Slug:       dm.Slug,
+		Selector:   vs.String(),
+		QueryIndex: index,
+		Expansion:  expr.String(),
+		Depth:      depth,
+	})
+	return &parser.ParenExpr{Expr: e.expand(expr, depth+1, append(seen, name))}
+}
+
+func (e *derivedMetricExpander) warnf(format string, args ...any) {
+	e.warnings = append(e.warnings, fmt.Sprintf(format, args...))
+}
+
+// selectDerivedMetricQuery returns the first query of the derived metric whose selector
+// labels all match the given matchers exactly. Queries without selector labels always match.
+func selectDerivedMetricQuery(dm *models.Configv1DerivedMetric, matchers map[string]*labels.Matcher) (int, *models.DerivedMetricSelectorQuery) {
+	for i, q := range dm.Queries {
+		if q == nil || q.Query == nil {
+			continue
+		}
+		matches := true
+		if q.Selector != nil {
+			for _, l := range q.Selector.Labels {
+				m, ok := matchers[l.Name]
+				if !ok || m.Type != labels.MatchEqual || m.Value != l.Value {
+					matches = false
+					break
+				}
+			}
+		}
+		if matches {
+			return i, q
+		}
+	}
+	return 0, nil
+}
+
+// inheritModifiers applies the offset and @ modifiers of the selector using a derived metric
+// to every selector of its expansion that does not set its own.
+func inheritModifiers(expr parser.Expr, vs *parser.VectorSelector) {
+	if vs.OriginalOffset == 0 && vs.Timestamp == nil && vs.StartOrEnd == 0 {
+		return
+	}
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		inner, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+		if inner.OriginalOffset == 0 {
+			inner.OriginalOffset = vs.OriginalOffset
+		}
+		if inner.Timestamp == nil && inner.StartOrEnd == 0 {
+			inner.Timestamp = vs.Timestamp
+			inner.StartOrEnd = vs.StartOrEnd
+		}
+		return nil
+	})
+}
+
+// selectorMetricName returns the metric name selected by a vector selector, if any.
+func selectorMetricName(vs *parser.VectorSelector) string {
+	if vs.Name != "" {
+		return vs.Name
+	}
+	for _, m := range vs.LabelMatchers {
+		if m.Name == labels.MetricName && m.Type == labels.MatchEqual {
+			return m.Value
+		}
+	}
+	return ""
+}
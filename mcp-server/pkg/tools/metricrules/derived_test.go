@@ -0,0 +1,127 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricrules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestExpandDerivedMetrics(t *testing.T) {
+	derivedMetrics := []*models.Configv1DerivedMetric{
+		{
+			Slug:       "requests-by-service",
+			MetricName: "http_requests_by_service",
+			Queries: []*models.DerivedMetricSelectorQuery{
+				{
+					Selector: &models.DerivedMetricSelector{Labels: []*models.Configv1DerivedMetricLabelMatcher{
+						{Name: "source", Type: models.Configv1DerivedMetricLabelMatcherMatcherTypeEXACT, Value: "envoy"},
+					}},
+					Query: &models.DerivedMetricQuery{
+						PrometheusExpr: `sum by (service) (rate(envoy_requests_total{$service}[1m]))`,
+						Variables:      []*models.DerivedMetricVariable{{Name: "service", DefaultPrometheusSelector: `service=~".+"`}},
+					},
+				},
+				{
+					Query: &models.DerivedMetricQuery{
+						PrometheusExpr: `sum by (service) (rate(http_requests_total{$service}[1m]))`,
+						Variables:      []*models.DerivedMetricVariable{{Name: "service", DefaultPrometheusSelector: `service=~".+"`}},
+					},
+				},
+			},
+		},
+		{
+			Slug:       "error-ratio",
+			MetricName: "error_ratio",
+			Queries: []*models.DerivedMetricSelectorQuery{{
+				Query: &models.DerivedMetricQuery{
+					PrometheusExpr: `http_errors_by_service{$service} / http_requests_by_service{$service}`,
+					Variables:      []*models.DerivedMetricVariable{{Name: "service", DefaultPrometheusSelector: `service=~".+"`}},
+				},
+			}},
+		},
+		{
+			Slug:       "loop",
+			MetricName: "loop",
+			Queries:    []*models.DerivedMetricSelectorQuery{{Query: &models.DerivedMetricQuery{PrometheusExpr: `loop + 1`}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+		uses     []string
+		warnings []string
+	}{
+		{
+			name:     "default query with variable",
+			query:    `http_requests_by_service{service="api"} > 10`,
+			expected: `(sum by (service) (rate(http_requests_total{service="api"}[1m]))) > 10`,
+			uses:     []string{"http_requests_by_service:1:0"},
+		},
+		{
+			name:     "selector picks query and default selector",
+			query:    `http_requests_by_service{source="envoy"}`,
+			expected: `sum by (service) (rate(envoy_requests_total{service=~".+"}[1m]))`,
+			uses:     []string{"http_requests_by_service:0:0"},
+		},
+		{
+			name:     "nested derived metric with offset",
+			query:    `error_ratio{service="api",env="prod"} offset 1h`,
+			expected: `http_errors_by_service{service="api"} offset 1h / (sum by (service) (rate(http_requests_total{service="api"}[1m] offset 1h)))`,
+			uses:     []string{"error_ratio:0:0", "http_requests_by_service:1:1"},
+			warnings: []string{`matcher env="prod" is not a selector label or variable of derived metric error_ratio and is ignored`},
+		},
+		{
+			name:     "range selector",
+			query:    `rate(http_requests_by_service[5m])`,
+			expected: `rate(http_requests_by_service[5m])`,
+			warnings: []string{"derived metric http_requests_by_service is used in the range selector http_requests_by_service[5m], which can not be expanded"},
+		},
+		{
+			name:     "cycle",
+			query:    `loop`,
+			expected: `loop + 1`,
+			uses:     []string{"loop:0:0"},
+			warnings: []string{"derived metric loop uses itself and was not expanded further"},
+		},
+		{
+			name:     "no derived metrics",
+			query:    `sum(up)`,
+			expected: `sum(up)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expandDerivedMetrics(tt.query, derivedMetrics)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result.ExpandedQuery)
+			var uses []string
+			for _, u := range result.DerivedMetrics {
+				uses = append(uses, fmt.Sprintf("%s:%d:%d", u.MetricName, u.QueryIndex, u.Depth))
+			}
+			require.Equal(t, tt.uses, uses)
+			require.Equal(t, tt.warnings, result.Warnings)
+		})
+	}
+
+	_, err := expandDerivedMetrics(`sum(`, derivedMetrics)
+	require.Error(t, err)
+}
@@ -0,0 +1,339 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricrules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+)
+
+const (
+	ruleTypeMapping = "mapping"
+
+	outputSourceRaw = "raw"
+
+	// metricNameTemplate is replaced with the source metric name in rollup rule metric names.
+	metricNameTemplate = "{{.MetricName}}"
+)
+
+// SeriesExplanation describes how the metric rules apply to a single series.
+type SeriesExplanation struct {
+	Labels           map[string]string `json:"labels"`
+	RawSeriesDropped bool              `json:"raw_series_dropped"`
+	MatchedRules     []*RuleMatch      `json:"matched_rules"`
+	OutputSeries     []*OutputSeries   `json:"output_series"`
+	UnmatchedRules   []*RuleMiss       `json:"unmatched_rules,omitempty"`
+	RulesEvaluated   map[string]int    `json:"rules_evaluated"`
+}
+
+// RuleMatch is a rule whose filters match the series.
+type RuleMatch struct {
+	Order    int    `json:"order"`
+	RuleType string `json:"rule_type"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Mode     string `json:"mode,omitempty"`
+	Applied  bool   `json:"applied"`
+	Effect   string `json:"effect"`
+}
+
+// RuleMiss is a rule whose filters do not match the series.
+type RuleMiss struct {
+	RuleType         string   `json:"rule_type"`
+	Slug             string   `json:"slug"`
+	Name             string   `json:"name"`
+	UnmatchedFilters []string `json:"unmatched_filters"`
+}
+
+// OutputSeries is a series that is stored as a result of the rules.
+type OutputSeries struct {
+	Source          string            `json:"source"`
+	Labels          map[string]string `json:"labels"`
+	Aggregation     string            `json:"aggregation,omitempty"`
+	Interval        string            `json:"interval,omitempty"`
+	StoragePolicies []string          `json:"storage_policies,omitempty"`
+}
+
+// ruleSet holds the rules which are evaluated against a series.
+type ruleSet struct {
+	dropRules    []*models.Configv1DropRule
+	mappingRules []*models.Configv1MappingRule
+	rollupRules  []*models.Configv1RollupRule
+}
+
+func (t *Tools) explainSeriesRules(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	lbls, err := params.Object[map[string]string](request, "labels", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	series, err := params.String(request, "series", false, "")
+	if err != nil {
+		return nil, err
+	}
+	includeUnmatched, err := params.Bool(request, "include_unmatched", false, false)
+	if err != nil {
+		return nil, err
+	}
+	if series != "" {
+		if lbls != nil {
+			return nil, fmt.Errorf("only one of labels or series can be provided")
+		}
+		lbls, err = promql.SeriesLabels(series)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(lbls) == 0 {
+		return nil, fmt.Errorf("one of labels or series must be provided")
+	}
+
+	var rules ruleSet
+	rules.dropRules, err = configlist.DropRules(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	rules.mappingRules, err = configlist.MappingRules(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	rules.rollupRules, err = configlist.RollupRules(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	explanation, err := explainSeries(lbls, rules, includeUnmatched)
+	if err != nil {
+		return nil, err
+	}
+	return &tools.Result{
+		JSONContent: explanation,
+	}, nil
+}
+
+// explainSeries evaluates the rules against the label set in the order they are applied
+// at ingestion: drop rules, then mapping rules, then rollup rules.
+func explainSeries(lbls map[string]string, rules ruleSet, includeUnmatched bool) (*SeriesExplanation, error) {
+	e := &SeriesExplanation{
+		Labels: lbls,
+		RulesEvaluated: map[string]int{
+			ruleTypeDrop:    len(rules.dropRules),
+			ruleTypeMapping: len(rules.mappingRules),
+			ruleTypeRollup:  len(rules.rollupRules),
+		},
+	}
+	// match records whether the rule filters match, tracking the misses if requested.
+	match := func(ruleType, slug, name string, lfs []*models.Configv1LabelFilter) (bool, error) {
+		fs, err := filters.FromModels(lfs)
+		if err != nil {
+			return false, fmt.Errorf("%s rule %s: %w", ruleType, slug, err)
+		}
+		unmatched := fs.Unmatched(lbls)
+		if len(unmatched) == 0 {
+			return true, nil
+		}
+		if includeUnmatched {
+			e.UnmatchedRules = append(e.UnmatchedRules, &RuleMiss{
+				RuleType:         ruleType,
+				Slug:             slug,
+				Name:             name,
+				UnmatchedFilters: unmatched.Strings(),
+			})
+		}
+		return false, nil
+	}
+	addMatch := func(m *RuleMatch) {
+		m.Order = len(e.MatchedRules) + 1
+		e.MatchedRules = append(e.MatchedRules, m)
+	}
+
+	var droppedBy string
+	dropRules := sortedBySlug(rules.dropRules, func(r *models.Configv1DropRule) string { return r.Slug })
+	for _, r := range dropRules {
+		ok, err := match(ruleTypeDrop, r.Slug, r.Name, r.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		m := &RuleMatch{RuleType: ruleTypeDrop, Slug: r.Slug, Name: r.Name, Mode: string(r.Mode)}
+		switch {
+		case r.Mode == models.Configv1DropRuleModeDISABLED:
+			m.Effect = "none: rule is disabled"
+		case droppedBy != "":
+			m.Effect = fmt.Sprintf("none: series already dropped by drop rule %s", droppedBy)
+		case r.ValueBasedDrop != nil && r.ValueBasedDrop.Enabled:
+			m.Applied = true
+			m.Effect = fmt.Sprintf("drops data points with value %v", r.ValueBasedDrop.TargetDropValue)
+		case r.ConditionalRateBasedDrop != nil && r.ConditionalRateBasedDrop.Enabled:
+			m.Applied = true
+			m.Effect = fmt.Sprintf("drops the series only while the matched series exceed %v data points per second",
+				r.ConditionalRateBasedDrop.RateLimitThreshold)
+		case r.DropNanValue:
+			m.Applied = true
+			m.Effect = "drops NaN data points"
+		default:
+			m.Applied = true
+			m.Effect = "drops the series"
+			droppedBy = r.Slug
+		}
+		addMatch(m)
+	}
+
+	raw := &OutputSeries{Source: outputSourceRaw, Labels: lbls}
+	mappingRules := sortedBySlug(rules.mappingRules, func(r *models.Configv1MappingRule) string { return r.Slug })
+	for _, r := range mappingRules {
+		ok, err := match(ruleTypeMapping, r.Slug, r.Name, r.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		m := &RuleMatch{RuleType: ruleTypeMapping, Slug: r.Slug, Name: r.Name, Mode: string(r.Mode)}
+		switch {
+		case droppedBy != "":
+			m.Effect = fmt.Sprintf("none: series dropped by drop rule %s", droppedBy)
+		case !modeEnabled(string(r.Mode)):
+			m.Effect = fmt.Sprintf("none: rule mode is %s", r.Mode)
+		case r.Drop:
+			m.Applied = true
+			m.Effect = "drops the raw series"
+			e.RawSeriesDropped = true
+		default:
+			m.Applied = true
+			m.Effect = "sets the aggregation and storage policy of the raw series"
+			if p := r.AggregationPolicy; p != nil {
+				raw.Aggregation = string(p.Aggregation)
+				raw.Interval = p.Interval
+				if p.StoragePolicy != nil {
+					raw.StoragePolicies = append(raw.StoragePolicies,
+						storagePolicy(p.StoragePolicy.Resolution, p.StoragePolicy.Retention))
+				}
+			}
+		}
+		addMatch(m)
+	}
+
+	var rollups []*OutputSeries
+	rollupRules := sortedBySlug(rules.rollupRules, func(r *models.Configv1RollupRule) string { return r.Slug })
+	for _, r := range rollupRules {
+		ok, err := match(ruleTypeRollup, r.Slug, r.Name, r.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		m := &RuleMatch{RuleType: ruleTypeRollup, Slug: r.Slug, Name: r.Name, Mode: string(r.Mode)}
+		switch {
+		case droppedBy != "":
+			m.Effect = fmt.Sprintf("none: series dropped by drop rule %s", droppedBy)
+		case !modeEnabled(string(r.Mode)):
+			m.Effect = fmt.Sprintf("none: rule mode is %s", r.Mode)
+		default:
+			m.Applied = true
+			out := &OutputSeries{
+				Source:      ruleTypeRollup + ":" + r.Slug,
+				Labels:      rollupLabels(lbls, r),
+				Aggregation: string(r.Aggregation),
+				Interval:    r.Interval,
+			}
+			if r.StoragePolicy != nil {
+				out.StoragePolicies = []string{storagePolicy(r.StoragePolicy.Resolution, r.StoragePolicy.Retention)}
+			}
+			rollups = append(rollups, out)
+			m.Effect = fmt.Sprintf("aggregates into %s", out.Labels[filters.MetricNameLabel])
+			if r.DropRaw {
+				m.Effect += " and drops the raw series"
+				e.RawSeriesDropped = true
+			}
+		}
+		addMatch(m)
+	}
+
+	if droppedBy != "" {
+		e.RawSeriesDropped = true
+	}
+	if !e.RawSeriesDropped {
+		e.OutputSeries = append(e.OutputSeries, raw)
+	}
+	e.OutputSeries = append(e.OutputSeries, rollups...)
+	return e, nil
+}
+
+// rollupLabels returns the labels of the series a rollup rule produces from the input labels.
+func rollupLabels(lbls map[string]string, r *models.Configv1RollupRule) map[string]string {
+	out := make(map[string]string, len(lbls))
+	switch {
+	case r.LabelPolicy != nil && len(r.LabelPolicy.Keep) > 0:
+		for _, k := range r.LabelPolicy.Keep {
+			if v, ok := lbls[k]; ok {
+				out[k] = v
+			}
+		}
+	case r.LabelPolicy != nil && len(r.LabelPolicy.Discard) > 0:
+		discard := make(map[string]struct{}, len(r.LabelPolicy.Discard))
+		for _, k := range r.LabelPolicy.Discard {
+			discard[k] = struct{}{}
+		}
+		for k, v := range lbls {
+			if _, ok := discard[k]; !ok {
+				out[k] = v
+			}
+		}
+	default:
+		for k, v := range lbls {
+			out[k] = v
+		}
+	}
+	name := lbls[filters.MetricNameLabel]
+	if r.MetricName != "" {
+		name = strings.ReplaceAll(strings.ReplaceAll(r.MetricName, "{{ .MetricName }}", metricNameTemplate),
+			metricNameTemplate, name)
+	}
+	out[filters.MetricNameLabel] = name
+	return out
+}
+
+// modeEnabled returns whether a rule mode means the rule is applied. Rules default to enabled.
+func modeEnabled(mode string) bool {
+	return mode == "" || mode == "ENABLED"
+}
+
+func storagePolicy(resolution, retention string) string {
+	return fmt.Sprintf("resolution=%s retention=%s", resolution, retention)
+}
+
+// sortedBySlug returns a copy of the rules sorted by slug, so evaluation order is stable.
+func sortedBySlug[T any](rules []T, slug func(T) string) []T {
+	sorted := make([]T, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return slug(sorted[i]) < slug(sorted[j])
+	})
+	return sorted
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func labelFilters(kv ...string) []*models.Configv1LabelFilter {
+	var lfs []*models.Configv1LabelFilter
+	for i := 0; i < len(kv); i += 2 {
+		lfs = append(lfs, &models.Configv1LabelFilter{Name: kv[i], ValueGlob: kv[i+1]})
+	}
+	return lfs
+}
+
+func TestExplainSeries(t *testing.T) {
+	lbls := map[string]string{
+		"__name__": "http_requests_total",
+		"service":  "api",
+		"instance": "10.0.0.1:8080",
+		"code":     "200",
+	}
+
+	testCases := []struct {
+		name             string
+		rules            ruleSet
+		wantMatched      []string
+		wantApplied      []bool
+		wantRawDropped   bool
+		wantOutputLabels []map[string]string
+	}{
+		{
+			name: "no matching rules keeps raw series",
+			rules: ruleSet{
+				dropRules: []*models.Configv1DropRule{
+					{Slug: "drop-web", Filters: labelFilters("service", "web")},
+				},
+			},
+			wantOutputLabels: []map[string]string{lbls},
+		},
+		{
+			name: "rollup with keep labels and drop raw",
+			rules: ruleSet{
+				rollupRules: []*models.Configv1RollupRule{
+					{
+						Slug:        "http-by-service",
+						Filters:     labelFilters("__name__", "http_*", "service", "api"),
+						MetricName:  "{{.MetricName}}:by_service",
+						Aggregation: "SUM",
+						LabelPolicy: &models.Configv1RollupRuleLabelPolicy{Keep: []string{"service", "code", "missing"}},
+						DropRaw:     true,
+					},
+				},
+			},
+			wantMatched:    []string{"rollup:http-by-service"},
+			wantApplied:    []bool{true},
+			wantRawDropped: true,
+			wantOutputLabels: []map[string]string{
+				{"__name__": "http_requests_total:by_service", "service": "api", "code": "200"},
+			},
+		},
+		{
+			name: "rollup with discard labels keeps raw series",
+			rules: ruleSet{
+				rollupRules: []*models.Configv1RollupRule{
+					{
+						Slug:        "no-instance",
+						Filters:     labelFilters("__name__", "http_requests_total"),
+						MetricName:  "http_requests_total:no_instance",
+						LabelPolicy: &models.Configv1RollupRuleLabelPolicy{Discard: []string{"instance"}},
+					},
+				},
+			},
+			wantMatched: []string{"rollup:no-instance"},
+			wantApplied: []bool{true},
+			wantOutputLabels: []map[string]string{
+				lbls,
+				{"__name__": "http_requests_total:no_instance", "service": "api", "code": "200"},
+			},
+		},
+		{
+			name: "drop rule stops later rules",
+			rules: ruleSet{
+				dropRules: []*models.Configv1DropRule{
+					{Slug: "drop-api", Filters: labelFilters("service", "api"), Mode: models.Configv1DropRuleModeENABLED},
+				},
+				mappingRules: []*models.Configv1MappingRule{
+					{Slug: "downsample", Filters: labelFilters("__name__", "http_*")},
+				},
+				rollupRules: []*models.Configv1RollupRule{
+					{Slug: "rollup", Filters: labelFilters("__name__", "http_*"), MetricName: "rolled"},
+				},
+			},
+			wantMatched:    []string{"drop:drop-api", "mapping:downsample", "rollup:rollup"},
+			wantApplied:    []bool{true, false, false},
+			wantRawDropped: true,
+		},
+		{
+			name: "disabled and preview rules are not applied",
+			rules: ruleSet{
+				dropRules: []*models.Configv1DropRule{
+					{Slug: "drop-api", Filters: labelFilters("service", "api"), Mode: models.Configv1DropRuleModeDISABLED},
+				},
+				rollupRules: []*models.Configv1RollupRule{
+					{Slug: "preview", Filters: labelFilters("service", "api"), Mode: models.Configv1RollupRuleModePREVIEW, DropRaw: true},
+				},
+			},
+			wantMatched:      []string{"drop:drop-api", "rollup:preview"},
+			wantApplied:      []bool{false, false},
+			wantOutputLabels: []map[string]string{lbls},
+		},
+		{
+			name: "mapping rule drop and negated filter",
+			rules: ruleSet{
+				mappingRules: []*models.Configv1MappingRule{
+					{Slug: "drop-non-5xx", Filters: labelFilters("code", "!5*"), Drop: true},
+				},
+			},
+			wantMatched:    []string{"mapping:drop-non-5xx"},
+			wantApplied:    []bool{true},
+			wantRawDropped: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := explainSeries(lbls, tc.rules, false)
+			require.NoError(t, err)
+
+			var matched []string
+			var applied []bool
+			for i, m := range e.MatchedRules {
+				require.Equal(t, i+1, m.Order)
+				matched = append(matched, m.RuleType+":"+m.Slug)
+				applied = append(applied, m.Applied)
+			}
+			require.Equal(t, tc.wantMatched, matched)
+			require.Equal(t, tc.wantApplied, applied)
+			require.Equal(t, tc.wantRawDropped, e.RawSeriesDropped)
+
+			var outputLabels []map[string]string
+			for _, o := range e.OutputSeries {
+				outputLabels = append(outputLabels, o.Labels)
+			}
+			require.Equal(t, tc.wantOutputLabels, outputLabels)
+		})
+	}
+}
+
+func TestExplainSeriesUnmatched(t *testing.T) {
+	lbls := map[string]string{"__name__": "up", "service": "api"}
+	rules := ruleSet{
+		dropRules: []*models.Configv1DropRule{
+			{Slug: "drop-web-up", Filters: labelFilters("__name__", "up", "service", "web")},
+		},
+	}
+	e, err := explainSeries(lbls, rules, true)
+	require.NoError(t, err)
+	require.Empty(t, e.MatchedRules)
+	require.Len(t, e.UnmatchedRules, 1)
+	require.Equal(t, []string{"service:web"}, e.UnmatchedRules[0].UnmatchedFilters)
+	require.Equal(t, 1, e.RulesEvaluated[ruleTypeDrop])
+}
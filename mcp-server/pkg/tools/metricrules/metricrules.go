@@ -0,0 +1,151 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricrules provides tools for evaluating the impact of drop, rollup and mapping rules
+// and for expanding derived metrics.
+package metricrules
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/statev1/statev1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
+)
+
+var _ tools.MCPTools = (*Tools)(nil)
+
+// Tools provides MCP tools for metric rules.
+type Tools struct {
+	logger      *zap.Logger
+	configAPI   *configv1.ConfigV1API
+	stateAPI    *statev1.StateV1API
+	promAPI     v1.API
+	linkBuilder *links.Builder
+}
+
+// NewTools creates new metric rule tools.
+func NewTools(
+	configAPI *configv1.ConfigV1API,
+	stateAPI *statev1.StateV1API,
+	promClient api.Client,
+	logger *zap.Logger,
+	linkBuilder *links.Builder,
+) (*Tools, error) {
+	logger.Info("metric rules tool configured")
+	return &Tools{
+		logger:      logger,
+		configAPI:   configAPI,
+		stateAPI:    stateAPI,
+		promAPI:     v1.NewAPI(promClient),
+		linkBuilder: linkBuilder,
+	}, nil
+}
+
+func (t *Tools) GroupName() string {
+	return "metric_rules"
+}
+
+func (t *Tools) MCPTools() []tools.MCPTool {
+	return []tools.MCPTool{
+		{
+			Metadata: tools.NewMetadata("simulate_metric_rule",
+				mcp.WithDescription(`Simulates a proposed drop or rollup rule before it is created. Use this to estimate how much a rule would save and what it would break.
+
+The filters are evaluated against current series to find the matching series count per metric. Ingestion rates (dpps) come from metric usage statistics and are scaled by the fraction of each metric's series that match. Affected dashboards, monitors and recording rules are found by scanning their PromQL.
+
+Response fields:
+- selector: PromQL selector equivalent to the filters
+- matched_series: Number of series matching the filters
+- output_series: For rollup rules, number of series the rollup would produce
+- estimated_matched_dpps: Estimated data points per second of the matched series
+- estimated_dpps_saved: Estimated reduction in data points per second (negative if the rule adds data)
+- metrics: Per-metric series counts, dpps and usage.reference_counts_by_type
+- references: Dashboards, monitors and recording rules whose queries select the affected series`),
+				mcp.WithString("rule_type",
+					mcp.Description("Type of rule to simulate."),
+					mcp.Enum(ruleTypeDrop, ruleTypeRollup),
+					mcp.Required(),
+				),
+				params.WithStringArray("filters",
+					mcp.Description(`Label filters of the proposed rule in the form "label:value_glob", e.g. ["__name__:http_requests_*", "service:checkout"]. Globs support *, ?, [abc], {a,b} and a leading ! to negate.`),
+					mcp.Required(),
+				),
+				params.WithStringArray("keep_labels",
+					mcp.Description("Rollup only. Labels to keep in the rolled up series; all other labels are aggregated away."),
+				),
+				params.WithStringArray("discard_labels",
+					mcp.Description("Rollup only. Labels to aggregate away; all other labels are kept. Ignored if keep_labels is set."),
+				),
+				mcp.WithBoolean("drop_raw",
+					mcp.Description("Rollup only. Whether the raw matched series are dropped once rolled up. Default is false."),
+				),
+				mcp.WithNumber("lookback_secs",
+					mcp.Description("Time range in seconds used for metric usage statistics. Default is 2592000 (30 days)."),
+				),
+				mcp.WithNumber("max_metrics",
+					mcp.Description("Maximum number of matched metrics to fetch usage and references for, ordered by matched series. Default is 50."),
+				),
+			),
+			Handler: t.simulateMetricRule,
+		},
+		{
+			Metadata: tools.NewMetadata("explain_series_rules",
+				mcp.WithDescription(`Explains which drop, mapping and rollup rules apply to a single series and what is stored as a result. Use this to understand why a series is missing, aggregated or renamed.
+
+Rules are evaluated locally in the order they are applied at ingestion: drop rules, then mapping rules, then rollup rules. A series dropped by a drop rule is not seen by later rules.
+
+Response fields:
+- matched_rules: Rules whose filters match the series, in evaluation order, with whether they were applied and their effect
+- raw_series_dropped: Whether the raw series is dropped
+- output_series: Series that are stored, either the raw series or rollup outputs with their labels, aggregation and storage policies
+- unmatched_rules: If include_unmatched is set, rules that did not match and which of their filters failed
+- rules_evaluated: Number of rules evaluated per rule type`),
+				mcp.WithObject("labels",
+					mcp.Description(`Label set of the series, including __name__, e.g. {"__name__": "http_requests_total", "service": "api"}. Such as one series returned by list_prometheus_series.`),
+					mcp.AdditionalProperties(map[string]any{"type": "string"}),
+				),
+				mcp.WithString("series",
+					mcp.Description(`Alternative to labels. The series in selector form, e.g. http_requests_total{service="api",code="200"}.`),
+				),
+				mcp.WithBoolean("include_unmatched",
+					mcp.Description("Whether to include rules that did not match and the filters that failed. Default is false."),
+				),
+			),
+			Handler: t.explainSeriesRules,
+		},
+		{
+			Metadata: tools.NewMetadata("expand_derived_metrics",
+				mcp.WithDescription(`Rewrites a PromQL query by inlining the definitions of the derived metrics it uses. Use this to see what a query using derived metrics actually evaluates, e.g. before debugging unexpected results.
+
+Derived metrics are metrics defined at query time by one or more PromQL queries. For each use of a derived metric, the first of its queries whose selector labels all match the use is chosen, and its variables (e.g. $service) are replaced by the matcher on the label of the same name, or by the default selector of the variable. Offset and @ modifiers are applied to the expansion. Derived metrics used by other derived metrics are expanded too.
+
+Response fields:
+- expanded_query: The query with derived metrics inlined
+- derived_metrics: Per use of a derived metric its slug, the selector using it, the index of the chosen query, its expansion and how deeply it is nested
+- warnings: Uses that could not be expanded, e.g. in range selectors, and matchers that are ignored since they are neither selector labels nor variables`),
+				mcp.WithString("query",
+					mcp.Description("PromQL query to expand, e.g. sum(http_requests_by_service{service=\"api\"})."),
+					mcp.Required(),
+				),
+			),
+			Handler: t.expandDerivedMetrics,
+		},
+	}
+}
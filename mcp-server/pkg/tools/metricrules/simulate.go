@@ -0,0 +1,433 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricrules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/statev1/models"
+	"github.com/chronosphereio/chronosphere-mcp/generated/statev1/statev1/metric_usages_by_metric_name"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/dashboards"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+const (
+	ruleTypeDrop   = "drop"
+	ruleTypeRollup = "rollup"
+
+	// rollupNameLabel temporarily holds the metric name while aggregating without labels,
+	// since PromQL aggregations drop __name__.
+	rollupNameLabel = "rollup_metric_name"
+)
+
+// SimulationResult is the result of simulating a drop or rollup rule.
+type SimulationResult struct {
+	RuleType             string           `json:"rule_type"`
+	Filters              []string         `json:"filters"`
+	Selector             string           `json:"selector"`
+	MatchedSeries        int              `json:"matched_series"`
+	OutputSeries         *int             `json:"output_series,omitempty"`
+	EstimatedMatchedDPPS float64          `json:"estimated_matched_dpps"`
+	EstimatedDPPSSaved   float64          `json:"estimated_dpps_saved"`
+	ReferenceCounts      map[string]int32 `json:"reference_counts_by_type,omitempty"`
+	Metrics              []*MetricImpact  `json:"metrics"`
+	References           []*RuleReference `json:"references"`
+	Warnings             []string         `json:"warnings,omitempty"`
+}
+
+// MetricImpact describes how a single metric is affected by a rule.
+type MetricImpact struct {
+	MetricName           string                     `json:"metric_name"`
+	MatchedSeries        int                        `json:"matched_series"`
+	TotalSeries          int                        `json:"total_series"`
+	DPPS                 float64                    `json:"dpps"`
+	EstimatedMatchedDPPS float64                    `json:"estimated_matched_dpps"`
+	Usage                *models.Statev1MetricUsage `json:"usage,omitempty"`
+}
+
+// RuleReference is a config entity whose queries select series affected by a rule.
+type RuleReference struct {
+	EntityType string   `json:"entity_type"`
+	Slug       string   `json:"slug"`
+	Name       string   `json:"name"`
+	Metrics    []string `json:"metrics"`
+}
+
+func (t *Tools) simulateMetricRule(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	ruleType, err := params.String(request, "rule_type", true, "")
+	if err != nil {
+		return nil, err
+	}
+	if ruleType != ruleTypeDrop && ruleType != ruleTypeRollup {
+		return nil, fmt.Errorf("rule_type must be one of %q or %q", ruleTypeDrop, ruleTypeRollup)
+	}
+	filterStrs, err := params.StringArray(request, "filters", true, nil)
+	if err != nil {
+		return nil, err
+	}
+	keepLabels, err := params.StringArray(request, "keep_labels", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	discardLabels, err := params.StringArray(request, "discard_labels", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	dropRaw, err := params.Bool(request, "drop_raw", false, false)
+	if err != nil {
+		return nil, err
+	}
+	lookbackSecs, err := params.Int(request, "lookback_secs", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	maxMetrics, err := params.Int(request, "max_metrics", false, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := filters.ParseStrings(filterStrs)
+	if err != nil {
+		return nil, err
+	}
+	if len(fs) == 0 {
+		return nil, fmt.Errorf("at least one filter must be provided")
+	}
+
+	now := time.Now()
+	selector := fs.Selector()
+	result := &SimulationResult{
+		RuleType: ruleType,
+		Filters:  fs.Strings(),
+		Selector: selector,
+	}
+
+	matched, err := t.seriesCountByMetric(ctx, selector, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count matching series: %s", err)
+	}
+	metricNames := make([]string, 0, len(matched))
+	for name, count := range matched {
+		metricNames = append(metricNames, name)
+		result.MatchedSeries += count
+	}
+	sort.Slice(metricNames, func(i, j int) bool {
+		if matched[metricNames[i]] != matched[metricNames[j]] {
+			return matched[metricNames[i]] > matched[metricNames[j]]
+		}
+		return metricNames[i] < metricNames[j]
+	})
+	if maxMetrics > 0 && len(metricNames) > maxMetrics {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"%d metrics matched; usage and references are only reported for the %d metrics with the most matched series",
+			len(metricNames), maxMetrics))
+		metricNames = metricNames[:maxMetrics]
+	}
+
+	if len(metricNames) > 0 {
+		totals, err := t.seriesCountByMetric(ctx, metricNameSelector(metricNames), now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count total series: %s", err)
+		}
+		for _, name := range metricNames {
+			impact := &MetricImpact{
+				MetricName:    name,
+				MatchedSeries: matched[name],
+				TotalSeries:   totals[name],
+			}
+			usage, err := t.metricUsage(ctx, name, lookbackSecs)
+			if err != nil {
+				return nil, err
+			}
+			if usage != nil {
+				impact.DPPS = usage.Dpps
+				impact.Usage = usage.Usage
+				impact.EstimatedMatchedDPPS = scaleDPPS(usage.Dpps, impact.MatchedSeries, impact.TotalSeries)
+			}
+			result.EstimatedMatchedDPPS += impact.EstimatedMatchedDPPS
+			result.Metrics = append(result.Metrics, impact)
+		}
+	}
+	result.ReferenceCounts = sumReferenceCounts(result.Metrics)
+
+	result.EstimatedDPPSSaved = result.EstimatedMatchedDPPS
+	if ruleType == ruleTypeRollup {
+		outputSeries, err := t.rollupOutputSeries(ctx, selector, keepLabels, discardLabels, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count rollup output series: %s", err)
+		}
+		result.OutputSeries = ptr.To(outputSeries)
+		result.EstimatedDPPSSaved = rollupDPPSSaved(result.EstimatedMatchedDPPS, result.MatchedSeries, outputSeries, dropRaw)
+	}
+
+	references, err := t.findReferences(ctx, metricNames, fs)
+	if err != nil {
+		return nil, err
+	}
+	result.References = references
+
+	return &tools.Result{
+		JSONContent: result,
+		ChronosphereLink: t.linkBuilder.MetricExplorer().
+			WithQuery(fmt.Sprintf("count by (__name__) (%s)", selector)).
+			WithEndTime(now).
+			String(),
+	}, nil
+}
+
+// seriesCountByMetric returns the number of series matching the selector per metric name.
+func (t *Tools) seriesCountByMetric(ctx context.Context, selector string, ts time.Time) (map[string]int, error) {
+	resp, _, err := t.promAPI.Query(ctx, fmt.Sprintf("count by (__name__) (%s)", selector), ts)
+	if err != nil {
+		return nil, err
+	}
+	vector, ok := resp.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result from prometheus server")
+	}
+	counts := make(map[string]int, len(vector))
+	for _, sample := range vector {
+		counts[string(sample.Metric[model.MetricNameLabel])] = int(sample.Value)
+	}
+	return counts, nil
+}
+
+// scaleDPPS returns the share of a metric's DPPS produced by some of its series, assuming
+// every series has the same DPPS.
+func scaleDPPS(dpps float64, series, totalSeries int) float64 {
+	if totalSeries <= 0 {
+		return 0
+	}
+	return dpps * float64(series) / float64(totalSeries)
+}
+
+// rollupDPPSSaved returns the DPPS saved by a rollup rule. The rollup output adds DPPS in
+// proportion to its series, and the matched DPPS is only saved if the raw series are dropped.
+func rollupDPPSSaved(matchedDPPS float64, matchedSeries, outputSeries int, dropRaw bool) float64 {
+	outputDPPS := scaleDPPS(matchedDPPS, outputSeries, matchedSeries)
+	if dropRaw {
+		return matchedDPPS - outputDPPS
+	}
+	return -outputDPPS
+}
+
+// rollupOutputQuery returns the query counting the series a rollup of the selected series
+// would produce.
+func rollupOutputQuery(selector string, keepLabels, discardLabels []string) string {
+	switch {
+	case len(keepLabels) > 0:
+		return fmt.Sprintf("count(count by (__name__, %s) (%s))", strings.Join(keepLabels, ", "), selector)
+	case len(discardLabels) > 0:
+		return fmt.Sprintf(`count(count without (%s) (label_replace(%s, %q, "$1", "__name__", "(.*)")))`,
+			strings.Join(discardLabels, ", "), selector, rollupNameLabel)
+	default:
+		return fmt.Sprintf("count(%s)", selector)
+	}
+}
+
+// rollupOutputSeries returns the number of series a rollup of the selected series would produce.
+func (t *Tools) rollupOutputSeries(ctx context.Context, selector string, keepLabels, discardLabels []string, ts time.Time) (int, error) {
+	resp, _, err := t.promAPI.Query(ctx, rollupOutputQuery(selector, keepLabels, discardLabels), ts)
+	if err != nil {
+		return 0, err
+	}
+	vector, ok := resp.(model.Vector)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result from prometheus server")
+	}
+	if len(vector) == 0 {
+		return 0, nil
+	}
+	return int(vector[0].Value), nil
+}
+
+// metricUsage returns the usage statistics of a single metric, or nil if there are none.
+func (t *Tools) metricUsage(ctx context.Context, metricName string, lookbackSecs int) (*models.Statev1MetricUsageByMetricName, error) {
+	queryParams := metric_usages_by_metric_name.NewListMetricUsagesByMetricNameParams().
+		WithContext(ctx).
+		WithMetricNameGlob(ptr.To(metricName)).
+		WithIncludeCountsByType(ptr.To(true))
+	if lookbackSecs > 0 {
+		queryParams.SetLookbackSecs(ptr.To(int32(lookbackSecs)))
+	}
+	resp, err := t.stateAPI.MetricUsagesByMetricName.ListMetricUsagesByMetricName(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metric usage for %s: %w", metricName, err)
+	}
+	for _, usage := range resp.Payload.Usages {
+		if usage != nil && usage.MetricName == metricName {
+			return usage, nil
+		}
+	}
+	return nil, nil
+}
+
+// findReferences scans monitors, dashboards and recording rules for queries selecting the affected series.
+func (t *Tools) findReferences(ctx context.Context, metricNames []string, fs filters.Filters) ([]*RuleReference, error) {
+	if len(metricNames) == 0 {
+		return nil, nil
+	}
+	var references []*RuleReference
+	add := func(entityType, slug, name string, queries ...string) {
+		affected := make(map[string]struct{})
+		for _, q := range queries {
+			for _, m := range affectedMetrics(q, metricNames, fs) {
+				affected[m] = struct{}{}
+			}
+		}
+		if len(affected) == 0 {
+			return
+		}
+		metrics := make([]string, 0, len(affected))
+		for m := range affected {
+			metrics = append(metrics, m)
+		}
+		sort.Strings(metrics)
+		references = append(references, &RuleReference{
+			EntityType: entityType,
+			Slug:       slug,
+			Name:       name,
+			Metrics:    metrics,
+		})
+	}
+
+	monitors, err := configlist.Monitors(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range monitors {
+		add("monitor", m.Slug, m.Name, m.PrometheusQuery)
+	}
+
+	dashboardList, err := configlist.Dashboards(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dashboardList {
+		queries, err := dashboards.ExtractQueries(d.DashboardJSON)
+		if err != nil {
+			t.logger.Debug("skipping unparseable dashboard")
+			continue
+		}
+		add("dashboard", d.Slug, d.Name, queries...)
+	}
+
+	recordingRules, err := configlist.RecordingRules(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recordingRules {
+		add("recording_rule", r.Slug, r.Name, r.PrometheusExpr)
+	}
+	return references, nil
+}
+
+// affectedMetrics returns which of the metric names are selected by the query in a way
+// that overlaps with the rule filters.
+func affectedMetrics(query string, metricNames []string, fs filters.Filters) []string {
+	if query == "" {
+		return nil
+	}
+	selectors, err := promql.Selectors(query)
+	if err != nil {
+		// Fall back to metric name matching for queries we cannot parse.
+		var affected []string
+		for _, name := range metricNames {
+			if promql.MatchesMetric(query, name) {
+				affected = append(affected, name)
+			}
+		}
+		return affected
+	}
+	var affected []string
+	for _, name := range metricNames {
+		for _, matchers := range selectors {
+			if selectorOverlaps(matchers, name, fs) {
+				affected = append(affected, name)
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// selectorOverlaps returns whether a selector could select series of the metric that the
+// filters also match. Only equality matchers are used to rule out an overlap.
+func selectorOverlaps(matchers []*labels.Matcher, metricName string, fs filters.Filters) bool {
+	selectsMetric := false
+	for _, m := range matchers {
+		if m.Name == labels.MetricName {
+			if !m.Matches(metricName) {
+				return false
+			}
+			selectsMetric = true
+		}
+	}
+	if !selectsMetric {
+		return false
+	}
+	for _, m := range matchers {
+		if m.Name == labels.MetricName || m.Type != labels.MatchEqual {
+			continue
+		}
+		for _, f := range fs {
+			if f.Name == m.Name && !f.MatchesValue(m.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func metricNameSelector(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return fmt.Sprintf("{__name__=~%q}", strings.Join(quoted, "|"))
+}
+
+func sumReferenceCounts(metrics []*MetricImpact) map[string]int32 {
+	counts := make(map[string]int32)
+	for _, m := range metrics {
+		if m.Usage == nil || m.Usage.ReferenceCountsByType == nil {
+			continue
+		}
+		rc := m.Usage.ReferenceCountsByType
+		counts["dashboards"] += rc.Dashboards
+		counts["monitors"] += rc.Monitors
+		counts["recording_rules"] += rc.RecordingRules
+		counts["drop_rules"] += rc.DropRules
+		counts["aggregation_rules"] += rc.AggregationRules
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
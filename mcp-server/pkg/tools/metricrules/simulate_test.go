@@ -0,0 +1,196 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/filters"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+)
+
+func TestSelectorOverlaps(t *testing.T) {
+	fs, err := filters.ParseStrings([]string{"__name__:http_requests_total", "service:api"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		query      string
+		metricName string
+		want       bool
+	}{
+		{
+			name:       "same metric and label",
+			query:      `http_requests_total{service="api"}`,
+			metricName: "http_requests_total",
+			want:       true,
+		},
+		{
+			name:       "no label matcher",
+			query:      `http_requests_total`,
+			metricName: "http_requests_total",
+			want:       true,
+		},
+		{
+			name:       "conflicting label value",
+			query:      `http_requests_total{service="web"}`,
+			metricName: "http_requests_total",
+			want:       false,
+		},
+		{
+			name:       "regexp label matcher cannot rule out overlap",
+			query:      `http_requests_total{service=~"we.*"}`,
+			metricName: "http_requests_total",
+			want:       true,
+		},
+		{
+			name:       "other metric",
+			query:      `http_errors_total{service="api"}`,
+			metricName: "http_requests_total",
+			want:       false,
+		},
+		{
+			name:       "metric name regexp",
+			query:      `{__name__=~"http_.*", service="api"}`,
+			metricName: "http_requests_total",
+			want:       true,
+		},
+		{
+			name:       "no metric name",
+			query:      `{service="api"}`,
+			metricName: "http_requests_total",
+			want:       false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selectors, err := promql.Selectors(tc.query)
+			require.NoError(t, err)
+			require.Len(t, selectors, 1)
+			require.Equal(t, tc.want, selectorOverlaps(selectors[0], tc.metricName, fs))
+		})
+	}
+}
+
+func TestAffectedMetrics(t *testing.T) {
+	fs, err := filters.ParseStrings([]string{"service:api"})
+	require.NoError(t, err)
+	metricNames := []string{"http_requests_total", "http_errors_total", "up"}
+
+	testCases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "ratio of two metrics",
+			query: `sum(rate(http_errors_total{service="api"}[5m])) / sum(rate(http_requests_total[5m]))`,
+			want:  []string{"http_requests_total", "http_errors_total"},
+		},
+		{
+			name:  "other service",
+			query: `sum(rate(http_requests_total{service="web"}[5m]))`,
+		},
+		{
+			name:  "unparseable query falls back to metric names",
+			query: `sum(rate(http_requests_total{service="api"}[5m])`,
+			want:  []string{"http_requests_total"},
+		},
+		{
+			name: "empty query",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, affectedMetrics(tc.query, metricNames, fs))
+		})
+	}
+}
+
+func TestRollupOutputQuery(t *testing.T) {
+	selector := `{__name__="http_requests_total",service="api"}`
+	testCases := []struct {
+		name          string
+		keepLabels    []string
+		discardLabels []string
+		want          string
+	}{
+		{
+			name:       "keep labels",
+			keepLabels: []string{"service", "code"},
+			want:       `count(count by (__name__, service, code) ({__name__="http_requests_total",service="api"}))`,
+		},
+		{
+			name:          "discard labels",
+			discardLabels: []string{"instance"},
+			want:          `count(count without (instance) (label_replace({__name__="http_requests_total",service="api"}, "rollup_metric_name", "$1", "__name__", "(.*)")))`,
+		},
+		{
+			name: "no labels",
+			want: `count({__name__="http_requests_total",service="api"})`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := rollupOutputQuery(selector, tc.keepLabels, tc.discardLabels)
+			require.Equal(t, tc.want, query)
+			_, err := promql.Parse(query)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDPPSScaling(t *testing.T) {
+	require.Equal(t, 25.0, scaleDPPS(100, 25, 100))
+	require.Equal(t, 0.0, scaleDPPS(100, 25, 0))
+
+	testCases := []struct {
+		name          string
+		matchedDPPS   float64
+		matchedSeries int
+		outputSeries  int
+		dropRaw       bool
+		want          float64
+	}{
+		{
+			name:          "drop raw saves matched minus output",
+			matchedDPPS:   100,
+			matchedSeries: 50,
+			outputSeries:  5,
+			dropRaw:       true,
+			want:          90,
+		},
+		{
+			name:          "keeping raw adds the output",
+			matchedDPPS:   100,
+			matchedSeries: 50,
+			outputSeries:  5,
+			want:          -10,
+		},
+		{
+			name:         "no matched series",
+			outputSeries: 5,
+			dropRaw:      true,
+			want:         0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, rollupDPPSSaved(tc.matchedDPPS, tc.matchedSeries, tc.outputSeries, tc.dropRaw))
+		})
+	}
+}
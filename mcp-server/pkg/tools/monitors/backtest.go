@@ -0,0 +1,581 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/monitor"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/prometheus"
+)
+
+const (
+	defaultIntervalSecs = 60
+
+	// maxBacktestPoints bounds the number of evaluations per series.
+	maxBacktestPoints = 11000
+)
+
+var warnThresholdColor = color.RGBA{R: 241, G: 196, B: 15, A: 255}
+
+// BacktestResult is the result of evaluating monitor conditions over a past window.
+type BacktestResult struct {
+	MonitorSlug     string                          `json:"monitor_slug,omitempty"`
+	Query           string                          `json:"query"`
+	Start           time.Time                       `json:"start"`
+	End             time.Time                       `json:"end"`
+	StepSecs        int                             `json:"step_secs"`
+	Conditions      *models.MonitorSeriesConditions `json:"series_conditions"`
+	Summary         map[string]*SeveritySummary     `json:"summary"`
+	SeriesEvaluated int                             `json:"series_evaluated"`
+	Series          []*SeriesBacktest               `json:"series"`
+	Warnings        []string                        `json:"warnings,omitempty"`
+}
+
+// SeveritySummary aggregates the firing intervals of a severity across all series.
+type SeveritySummary struct {
+	FiringSeries     int     `json:"firing_series"`
+	Alerts           int     `json:"alerts"`
+	TotalFiringSecs  float64 `json:"total_firing_secs"`
+	FiringPercentage float64 `json:"firing_percentage"`
+}
+
+// SeriesBacktest is the evaluation of a single series.
+type SeriesBacktest struct {
+	Labels    map[string]string `json:"labels"`
+	Min       float64           `json:"min"`
+	Max       float64           `json:"max"`
+	Intervals []*FiringInterval `json:"firing_intervals"`
+}
+
+// FiringInterval is a period during which a series would have been alerting.
+type FiringInterval struct {
+	Severity     string    `json:"severity"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	DurationSecs float64   `json:"duration_secs"`
+	// Ongoing is set if the series was still firing at the end of the window.
+	Ongoing bool `json:"ongoing,omitempty"`
+}
+
+func (t *Tools) backtestMonitor(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	monitorSlug, err := params.String(request, "monitor_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	query, err := params.String(request, "query", false, "")
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := params.Object[*models.MonitorSeriesConditions](request, "series_conditions", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err = inlineConditions(request, conditions)
+	if err != nil {
+		return nil, err
+	}
+	timeRange, err := params.ParseTimeRange(request)
+	if err != nil {
+		return nil, err
+	}
+	step, err := params.Int(request, "step_seconds", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	render, err := params.Bool(request, "render", false, false)
+	if err != nil {
+		return nil, err
+	}
+	maxSeries, err := params.Int(request, "max_series", false, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	intervalSecs := 0
+	if monitorSlug != "" {
+		resp, err := t.configAPI.Monitor.ReadMonitor(&monitor.ReadMonitorParams{
+			Context: ctx,
+			Slug:    monitorSlug,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read monitor %s: %s", monitorSlug, err)
+		}
+		m := resp.Payload.Monitor
+		if query == "" {
+			if m.PrometheusQuery == "" {
+				return nil, fmt.Errorf("monitor %s does not have a prometheus query, provide one with query", monitorSlug)
+			}
+			query = m.PrometheusQuery
+		}
+		if conditions == nil {
+			conditions = m.SeriesConditions
+		}
+		intervalSecs = int(m.IntervalSecs)
+	}
+	if query == "" {
+		return nil, fmt.Errorf("one of monitor_slug or query must be provided")
+	}
+	if conditions == nil || (conditions.Defaults == nil && len(conditions.Overrides) == 0) {
+		return nil, fmt.Errorf("series_conditions or op and threshold must be provided when the monitor has no conditions")
+	}
+	if step <= 0 {
+		step = intervalSecs
+	}
+	if step <= 0 {
+		step = defaultIntervalSecs
+	}
+
+	result, matrix, err := t.runBacktest(ctx, query, conditions, timeRange, step)
+	if err != nil {
+		return nil, err
+	}
+	result.MonitorSlug = monitorSlug
+	if maxSeries > 0 && len(result.Series) > maxSeries {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"%d series evaluated; only the %d series with the most firing time are listed", len(result.Series), maxSeries))
+		result.Series = result.Series[:maxSeries]
+	}
+
+	toolResult := &tools.Result{
+		JSONContent: result,
+		ChronosphereLink: t.linkBuilder.MetricExplorer().
+			WithQuery(query).
+			WithTimeRange(timeRange.Start, timeRange.End).
+			String(),
+	}
+	if render {
+		buf := bytes.NewBuffer(nil)
+		if err := t.renderer.RenderSeriesWithThresholds(buf, matrix, conditionThresholds(conditions), 1024, 768, true); err != nil {
+			return nil, fmt.Errorf("failed to render query: %s", err)
+		}
+		toolResult.Images = [][]byte{buf.Bytes()}
+	}
+	return toolResult, nil
+}
+
+// runBacktest queries the range and evaluates the conditions against every returned series.
+func (t *Tools) runBacktest(
+	ctx context.Context,
+	query string,
+	conditions *models.MonitorSeriesConditions,
+	timeRange *params.TimeRange,
+	step int,
+) (*BacktestResult, model.Matrix, error) {
+	matrix, err := t.queryRange(ctx, query, timeRange, step)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := evaluateBacktest(matrix, conditions, timeRange.Start, timeRange.End, time.Duration(step)*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.Query = query
+	result.StepSecs = step
+	return result, matrix, nil
+}
+
+// queryRange evaluates the query at every step of the time range.
+func (t *Tools) queryRange(ctx context.Context, query string, timeRange *params.TimeRange, step int) (model.Matrix, error) {
+	stepDur := time.Duration(step) * time.Second
+	if timeRange.End.Sub(timeRange.Start)/stepDur > maxBacktestPoints {
+		return nil, fmt.Errorf("time range %s with step %ds exceeds %d points, use a larger step_seconds",
+			timeRange.End.Sub(timeRange.Start), step, maxBacktestPoints)
+	}
+	resp, _, err := t.promAPI.QueryRange(ctx, query, v1.Range{
+		Start: timeRange.Start,
+		End:   timeRange.End,
+		Step:  stepDur,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query range: %s", err)
+	}
+	matrix, ok := resp.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result from prometheus server")
+	}
+	return matrix, nil
+}
+
+// inlineConditions builds critical series conditions from the op, threshold and sustain
+// parameters when series_conditions is not given.
+func inlineConditions(request mcp.CallToolRequest, conditions *models.MonitorSeriesConditions) (*models.MonitorSeriesConditions, error) {
+	op, err := params.String(request, "op", false, "")
+	if err != nil {
+		return nil, err
+	}
+	if op == "" {
+		return conditions, nil
+	}
+	if conditions != nil {
+		return nil, fmt.Errorf("only one of series_conditions or op can be provided")
+	}
+	// Value conditions compare against the threshold, so it must be given rather than default to 0.
+	valueOp := models.ConditionOp(op) != models.ConditionOpEXISTS && models.ConditionOp(op) != models.ConditionOpNOTEXISTS
+	threshold, err := params.Float(request, "threshold", valueOp, 0)
+	if err != nil {
+		return nil, err
+	}
+	sustainSecs, err := params.Int(request, "sustain_secs", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	resolveSustainSecs, err := params.Int(request, "resolve_sustain_secs", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	severity, err := params.String(request, "severity", false, severityCritical)
+	if err != nil {
+		return nil, err
+	}
+	cond := &models.SeriesConditionsConditions{
+		Conditions: []*models.MonitorCondition{{
+			Op:                 models.ConditionOp(op),
+			Value:              threshold,
+			SustainSecs:        int32(sustainSecs),
+			ResolveSustainSecs: int32(resolveSustainSecs),
+		}},
+	}
+	defaults := &models.SeriesConditionsSeverityConditions{}
+	switch severity {
+	case severityCritical:
+		defaults.Critical = cond
+	case severityWarn:
+		defaults.Warn = cond
+	default:
+		return nil, fmt.Errorf("severity must be one of %q or %q", severityCritical, severityWarn)
+	}
+	return &models.MonitorSeriesConditions{Defaults: defaults}, nil
+}
+
+// evaluateBacktest applies the monitor conditions to every series of the matrix at each step
+// between start and end. A condition starts firing once it has held for its sustain duration and
+// resolves once it has not held for its resolve sustain duration. Missing points do not satisfy
+// value conditions. NOT_EXISTS conditions are evaluated against the query as a whole.
+func evaluateBacktest(
+	matrix model.Matrix,
+	conditions *models.MonitorSeriesConditions,
+	start, end time.Time,
+	step time.Duration,
+) (*BacktestResult, error) {
+	result := &BacktestResult{
+		Start:           start,
+		End:             end,
+		Conditions:      conditions,
+		Summary:         make(map[string]*SeveritySummary),
+		SeriesEvaluated: len(matrix),
+	}
+	grid := stepGrid(start, end, step)
+	window := end.Sub(start).Seconds()
+
+	present := make(map[int64]struct{})
+	for _, s := range matrix {
+		lbls := make(map[string]string, len(s.Metric))
+		for k, v := range s.Metric {
+			lbls[string(k)] = string(v)
+		}
+		values := make(map[int64]float64, len(s.Values))
+		sb := &SeriesBacktest{Labels: lbls}
+		for i, p := range s.Values {
+			ts := p.Timestamp.Unix()
+			values[ts] = float64(p.Value)
+			present[ts] = struct{}{}
+			if i == 0 || float64(p.Value) < sb.Min {
+				sb.Min = float64(p.Value)
+			}
+			if i == 0 || float64(p.Value) > sb.Max {
+				sb.Max = float64(p.Value)
+			}
+		}
+		severities, err := seriesConditions(conditions, lbls)
+		if err != nil {
+			return nil, err
+		}
+		for _, severity := range []string{severityCritical, severityWarn} {
+			sb.Intervals = append(sb.Intervals, evaluateSeverity(severity, severities[severity], grid, func(ts int64) (float64, bool) {
+				v, ok := values[ts]
+				return v, ok
+			}, false)...)
+		}
+		result.Series = append(result.Series, sb)
+	}
+
+	// NOT_EXISTS conditions fire when the query returns no series at all.
+	absent := &SeriesBacktest{Labels: map[string]string{}}
+	defaults, err := seriesConditions(conditions, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, severity := range []string{severityCritical, severityWarn} {
+		absent.Intervals = append(absent.Intervals, evaluateSeverity(severity, defaults[severity], grid, func(ts int64) (float64, bool) {
+			_, ok := present[ts]
+			return 0, ok
+		}, true)...)
+	}
+	if len(absent.Intervals) > 0 {
+		result.Series = append(result.Series, absent)
+	}
+
+	for _, sb := range result.Series {
+		firing := make(map[string]bool)
+		for _, iv := range sb.Intervals {
+			summary, ok := result.Summary[iv.Severity]
+			if !ok {
+				summary = &SeveritySummary{}
+				result.Summary[iv.Severity] = summary
+			}
+			summary.Alerts++
+			summary.TotalFiringSecs += iv.DurationSecs
+			firing[iv.Severity] = true
+		}
+		for severity := range firing {
+			result.Summary[severity].FiringSeries++
+		}
+	}
+	// The NOT_EXISTS pseudo-series is not a series of the query, so percentages are relative to
+	// the returned series, or to the window alone if the query returned none.
+	seriesCount := max(len(matrix), 1)
+	for _, summary := range result.Summary {
+		if window > 0 {
+			summary.FiringPercentage = 100 * summary.TotalFiringSecs / (window * float64(seriesCount))
+		}
+	}
+
+	sort.SliceStable(result.Series, func(i, j int) bool {
+		return firingSecs(result.Series[i]) > firingSecs(result.Series[j])
+	})
+	return result, nil
+}
+
+// evaluateSeverity returns the firing intervals of any of the conditions. If absentOnly is set
+// only NOT_EXISTS conditions are evaluated, otherwise they are skipped.
+func evaluateSeverity(
+	severity string,
+	conditions []*models.MonitorCondition,
+	grid []time.Time,
+	valueAt func(ts int64) (float64, bool),
+	absentOnly bool,
+) []*FiringInterval {
+	var intervals []*FiringInterval
+	for _, c := range conditions {
+		if c == nil || (c.Op == models.ConditionOpNOTEXISTS) != absentOnly {
+			continue
+		}
+		intervals = append(intervals, evaluateCondition(severity, c, grid, valueAt)...)
+	}
+	return mergeIntervals(intervals)
+}
+
+// evaluateCondition runs the sustain and resolve state machine of a single condition.
+func evaluateCondition(
+	severity string,
+	c *models.MonitorCondition,
+	grid []time.Time,
+	valueAt func(ts int64) (float64, bool),
+) []*FiringInterval {
+	var (
+		intervals      []*FiringInterval
+		current        *FiringInterval
+		pendingSince   *time.Time
+		resolvingSince *time.Time
+	)
+	sustain := time.Duration(c.SustainSecs) * time.Second
+	resolveSustain := time.Duration(c.ResolveSustainSecs) * time.Second
+	for i := range grid {
+		ts := grid[i]
+		v, ok := valueAt(ts.Unix())
+		if conditionHolds(c, v, ok) {
+			resolvingSince = nil
+			if current != nil {
+				continue
+			}
+			if pendingSince == nil {
+				pendingSince = &grid[i]
+			}
+			if ts.Sub(*pendingSince) >= sustain {
+				current = &FiringInterval{Severity: severity, Start: ts}
+			}
+			continue
+		}
+		pendingSince = nil
+		if current == nil {
+			continue
+		}
+		if resolvingSince == nil {
+			resolvingSince = &grid[i]
+		}
+		if ts.Sub(*resolvingSince) >= resolveSustain {
+			current.End = ts
+			current.DurationSecs = current.End.Sub(current.Start).Seconds()
+			intervals = append(intervals, current)
+			current = nil
+			resolvingSince = nil
+		}
+	}
+	if current != nil && len(grid) > 0 {
+		current.End = grid[len(grid)-1]
+		current.DurationSecs = current.End.Sub(current.Start).Seconds()
+		current.Ongoing = true
+		intervals = append(intervals, current)
+	}
+	return intervals
+}
+
+func conditionHolds(c *models.MonitorCondition, v float64, ok bool) bool {
+	switch c.Op {
+	case models.ConditionOpEXISTS:
+		return ok
+	case models.ConditionOpNOTEXISTS:
+		return !ok
+	}
+	if !ok {
+		return false
+	}
+	switch c.Op {
+	case models.ConditionOpGEQ:
+		return v >= c.Value
+	case models.ConditionOpGT:
+		return v > c.Value
+	case models.ConditionOpLEQ:
+		return v <= c.Value
+	case models.ConditionOpLT:
+		return v < c.Value
+	case models.ConditionOpEQ:
+		return v == c.Value
+	case models.ConditionOpNEQ:
+		return v != c.Value
+	}
+	return false
+}
+
+// seriesConditions returns the conditions per severity that apply to a series: those of the
+// first override whose label matchers all match, otherwise the defaults.
+func seriesConditions(conditions *models.MonitorSeriesConditions, lbls map[string]string) (map[string][]*models.MonitorCondition, error) {
+	if conditions == nil {
+		return nil, nil
+	}
+	severities := conditions.Defaults
+	if lbls != nil {
+		for i, o := range conditions.Overrides {
+			if o == nil {
+				continue
+			}
+			matched := true
+			for _, m := range o.LabelMatchers {
+				ok, err := matchesLabel(m, lbls)
+				if err != nil {
+					return nil, fmt.Errorf("series conditions override %d: %w", i, err)
+				}
+				if !ok {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				severities = o.SeverityConditions
+				break
+			}
+		}
+	}
+	out := make(map[string][]*models.MonitorCondition)
+	if severities == nil {
+		return out, nil
+	}
+	if severities.Critical != nil {
+		out[severityCritical] = severities.Critical.Conditions
+	}
+	if severities.Warn != nil {
+		out[severityWarn] = severities.Warn.Conditions
+	}
+	return out, nil
+}
+
+// mergeIntervals merges overlapping intervals of the same severity.
+func mergeIntervals(intervals []*FiringInterval) []*FiringInterval {
+	if len(intervals) < 2 {
+		return intervals
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+	merged := []*FiringInterval{intervals[0]}
+	for _, iv := range intervals[1:] {
+		last := merged[len(merged)-1]
+		if iv.Start.After(last.End) {
+			merged = append(merged, iv)
+			continue
+		}
+		if iv.End.After(last.End) {
+			last.End = iv.End
+			last.DurationSecs = last.End.Sub(last.Start).Seconds()
+		}
+		last.Ongoing = last.Ongoing || iv.Ongoing
+	}
+	return merged
+}
+
+// conditionThresholds returns the default condition values to draw on a chart.
+func conditionThresholds(conditions *models.MonitorSeriesConditions) []prometheus.Threshold {
+	if conditions == nil || conditions.Defaults == nil {
+		return nil
+	}
+	var thresholds []prometheus.Threshold
+	add := func(severity string, c *models.SeriesConditionsConditions, clr color.Color) {
+		if c == nil {
+			return
+		}
+		for _, cond := range c.Conditions {
+			if cond == nil || cond.Op == models.ConditionOpEXISTS || cond.Op == models.ConditionOpNOTEXISTS {
+				continue
+			}
+			thresholds = append(thresholds, prometheus.Threshold{
+				Name:  fmt.Sprintf("%s %s %v", severity, cond.Op, cond.Value),
+				Value: cond.Value,
+				Color: clr,
+			})
+		}
+	}
+	add(severityCritical, conditions.Defaults.Critical, nil)
+	add(severityWarn, conditions.Defaults.Warn, warnThresholdColor)
+	return thresholds
+}
+
+func stepGrid(start, end time.Time, step time.Duration) []time.Time {
+	var grid []time.Time
+	for ts := start.Truncate(time.Second); !ts.After(end); ts = ts.Add(step) {
+		grid = append(grid, ts)
+	}
+	return grid
+}
+
+func firingSecs(sb *SeriesBacktest) float64 {
+	var total float64
+	for _, iv := range sb.Intervals {
+		total += iv.DurationSecs
+	}
+	return total
+}
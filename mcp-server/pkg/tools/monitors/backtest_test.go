@@ -0,0 +1,208 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+var backtestStart = time.Unix(1700000000, 0).UTC()
+
+// testSeries builds a series with one value per minute starting at backtestStart. NaN values are
+// left out to simulate gaps.
+func testSeries(lbls model.Metric, values ...float64) *model.SampleStream {
+	s := &model.SampleStream{Metric: lbls}
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		s.Values = append(s.Values, model.SamplePair{
+			Timestamp: model.TimeFromUnix(backtestStart.Add(time.Duration(i) * time.Minute).Unix()),
+			Value:     model.SampleValue(v),
+		})
+	}
+	return s
+}
+
+func criticalConditions(conds ...*models.MonitorCondition) *models.MonitorSeriesConditions {
+	return &models.MonitorSeriesConditions{
+		Defaults: &models.SeriesConditionsSeverityConditions{
+			Critical: &models.SeriesConditionsConditions{Conditions: conds},
+		},
+	}
+}
+
+func TestEvaluateBacktest(t *testing.T) {
+	nan := math.NaN()
+
+	testCases := []struct {
+		name          string
+		values        []float64
+		conditions    *models.MonitorSeriesConditions
+		wantIntervals [][2]int
+		wantOngoing   bool
+	}{
+		{
+			name:          "fires while above threshold",
+			values:        []float64{1, 5, 6, 1, 1},
+			conditions:    criticalConditions(&models.MonitorCondition{Op: models.ConditionOpGT, Value: 4}),
+			wantIntervals: [][2]int{{1, 3}},
+		},
+		{
+			name:   "sustain delays firing",
+			values: []float64{5, 5, 5, 1, 5},
+			conditions: criticalConditions(&models.MonitorCondition{
+				Op: models.ConditionOpGEQ, Value: 5, SustainSecs: 120,
+			}),
+			wantIntervals: [][2]int{{2, 3}},
+		},
+		{
+			name:   "resolve sustain delays resolving",
+			values: []float64{5, 1, 5, 1, 1, 1},
+			conditions: criticalConditions(&models.MonitorCondition{
+				Op: models.ConditionOpGT, Value: 4, ResolveSustainSecs: 60,
+			}),
+			wantIntervals: [][2]int{{0, 4}},
+		},
+		{
+			name:          "still firing at the end of the window",
+			values:        []float64{1, 1, 9, 9, 9},
+			conditions:    criticalConditions(&models.MonitorCondition{Op: models.ConditionOpGT, Value: 4}),
+			wantIntervals: [][2]int{{2, 4}},
+			wantOngoing:   true,
+		},
+		{
+			name:          "gaps do not satisfy value conditions",
+			values:        []float64{9, nan, 9, 1, 1},
+			conditions:    criticalConditions(&models.MonitorCondition{Op: models.ConditionOpGT, Value: 4}),
+			wantIntervals: [][2]int{{0, 1}, {2, 3}},
+		},
+		{
+			name:   "any condition fires",
+			values: []float64{1, 9, 1, -9, 1},
+			conditions: criticalConditions(
+				&models.MonitorCondition{Op: models.ConditionOpGT, Value: 4},
+				&models.MonitorCondition{Op: models.ConditionOpLT, Value: -4},
+			),
+			wantIntervals: [][2]int{{1, 2}, {3, 4}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matrix := model.Matrix{testSeries(model.Metric{"service": "api"}, tc.values...)}
+			end := backtestStart.Add(time.Duration(len(tc.values)-1) * time.Minute)
+			result, err := evaluateBacktest(matrix, tc.conditions, backtestStart, end, time.Minute)
+			require.NoError(t, err)
+			require.Len(t, result.Series, 1)
+
+			var intervals [][2]int
+			ongoing := false
+			for _, iv := range result.Series[0].Intervals {
+				require.Equal(t, severityCritical, iv.Severity)
+				intervals = append(intervals, [2]int{
+					int(iv.Start.Sub(backtestStart) / time.Minute),
+					int(iv.End.Sub(backtestStart) / time.Minute),
+				})
+				ongoing = ongoing || iv.Ongoing
+			}
+			require.Equal(t, tc.wantIntervals, intervals)
+			require.Equal(t, tc.wantOngoing, ongoing)
+			require.Equal(t, len(tc.wantIntervals), result.Summary[severityCritical].Alerts)
+		})
+	}
+}
+
+func TestEvaluateBacktestOverridesAndAbsence(t *testing.T) {
+	nan := math.NaN()
+	conditions := &models.MonitorSeriesConditions{
+		Defaults: &models.SeriesConditionsSeverityConditions{
+			Warn: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+				{Op: models.ConditionOpGT, Value: 4},
+			}},
+			Critical: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+				{Op: models.ConditionOpNOTEXISTS},
+			}},
+		},
+		Overrides: []*models.MonitorSeriesConditionsOverride{
+			{
+				LabelMatchers: []*models.Configv1LabelMatcher{
+					{Name: "service", Type: models.Configv1LabelMatcherMatcherTypeEXACT, Value: "batch"},
+				},
+				SeverityConditions: &models.SeriesConditionsSeverityConditions{
+					Warn: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+						{Op: models.ConditionOpGT, Value: 100},
+					}},
+				},
+			},
+		},
+	}
+	matrix := model.Matrix{
+		testSeries(model.Metric{"service": "api"}, 9, 9, nan, 1),
+		testSeries(model.Metric{"service": "batch"}, 9, 9, nan, 1),
+	}
+	end := backtestStart.Add(3 * time.Minute)
+	result, err := evaluateBacktest(matrix, conditions, backtestStart, end, time.Minute)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, result.Summary[severityWarn].FiringSeries)
+	require.Equal(t, 1, result.Summary[severityCritical].Alerts)
+	// The NOT_EXISTS pseudo-series is not part of the denominator.
+	require.Equal(t, 60.0, result.Summary[severityCritical].TotalFiringSecs)
+	require.InDelta(t, 100.0/6, result.Summary[severityCritical].FiringPercentage, 1e-9)
+	require.Len(t, result.Series, 3)
+	for _, s := range result.Series {
+		switch s.Labels["service"] {
+		case "api":
+			require.Len(t, s.Intervals, 1)
+			require.Equal(t, severityWarn, s.Intervals[0].Severity)
+		case "batch":
+			require.Empty(t, s.Intervals)
+		default:
+			require.Len(t, s.Intervals, 1)
+			require.Equal(t, severityCritical, s.Intervals[0].Severity)
+			require.Equal(t, backtestStart.Add(2*time.Minute), s.Intervals[0].Start)
+		}
+	}
+}
+
+func TestInlineConditions(t *testing.T) {
+	request := func(args map[string]any) mcp.CallToolRequest {
+		r := mcp.CallToolRequest{}
+		r.Params.Arguments = args
+		return r
+	}
+
+	conditions, err := inlineConditions(request(map[string]any{"op": "GT", "threshold": 5.0, "sustain_secs": 60}), nil)
+	require.NoError(t, err)
+	require.Equal(t, criticalConditions(&models.MonitorCondition{Op: models.ConditionOpGT, Value: 5, SustainSecs: 60}), conditions)
+
+	_, err = inlineConditions(request(map[string]any{"op": "GT"}), nil)
+	require.ErrorContains(t, err, "threshold")
+
+	conditions, err = inlineConditions(request(map[string]any{"op": "NOT_EXISTS"}), nil)
+	require.NoError(t, err)
+	require.Equal(t, criticalConditions(&models.MonitorCondition{Op: models.ConditionOpNOTEXISTS}), conditions)
+
+	_, err = inlineConditions(request(map[string]any{"op": "GT", "threshold": 5.0}), criticalConditions())
+	require.ErrorContains(t, err, "only one of series_conditions or op")
+}
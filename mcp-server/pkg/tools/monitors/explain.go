@@ -0,0 +1,498 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/monitor"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notification_policy"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable/data_unstable"
+	dataunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/models"
+	datav1models "github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
+	stateunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/stateunstable/models"
+	"github.com/chronosphereio/chronosphere-mcp/generated/stateunstable/stateunstable/state_unstable"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+const (
+	// eventCategoryAlerts is the event category monitor state changes are recorded in.
+	eventCategoryAlerts = "alerts"
+	// monitorSlugEventLabel is the label alert events carry the slug of their monitor in.
+	monitorSlugEventLabel = "monitor_slug"
+
+	// timelineBuckets is the number of histogram buckets the event timeline is split into.
+	timelineBuckets = 60
+	minTimelineStep = 60 * time.Second
+	// maxTimelineEvents bounds the events listed in the timeline, keeping the most recent.
+	maxTimelineEvents = 100
+)
+
+// MonitorExplanation describes a monitor, what it is currently alerting on and who is notified.
+type MonitorExplanation struct {
+	Monitor      *MonitorSummary      `json:"monitor"`
+	State        string               `json:"state,omitempty"`
+	FiringSeries []*FiringSeries      `json:"firing_series"`
+	Timeline     *Timeline            `json:"timeline,omitempty"`
+	Notification *NotificationSummary `json:"notification"`
+	Warnings     []string             `json:"warnings,omitempty"`
+}
+
+// MonitorSummary is the part of a monitor definition relevant to why it fires.
+type MonitorSummary struct {
+	Slug           string                        `json:"slug"`
+	Name           string                        `json:"name,omitempty"`
+	QueryType      string                        `json:"query_type"`
+	Query          string                        `json:"query"`
+	IntervalSecs   int32                         `json:"interval_secs,omitempty"`
+	CollectionType string                        `json:"collection_type,omitempty"`
+	CollectionSlug string                        `json:"collection_slug,omitempty"`
+	Labels         map[string]string             `json:"labels,omitempty"`
+	Annotations    map[string]string             `json:"annotations,omitempty"`
+	SignalGrouping *models.MonitorSignalGrouping `json:"signal_grouping,omitempty"`
+	Conditions     []string                      `json:"conditions"`
+	Schedule       *models.MonitorSchedule       `json:"schedule,omitempty"`
+}
+
+// FiringSeries is a series the monitor is currently not passing on.
+type FiringSeries struct {
+	Severity     string            `json:"severity,omitempty"`
+	State        string            `json:"state"`
+	SignalLabels map[string]string `json:"signal_labels,omitempty"`
+	Labels       map[string]string `json:"labels"`
+	StartedAt    *time.Time        `json:"started_at,omitempty"`
+	DurationSecs float64           `json:"duration_secs,omitempty"`
+}
+
+// Timeline is the alert events of a monitor over a window, with their histogram.
+type Timeline struct {
+	Query       string            `json:"query"`
+	TotalEvents int               `json:"total_events"`
+	Events      []*TimelineEvent  `json:"events,omitempty"`
+	Buckets     []*TimelineBucket `json:"buckets,omitempty"`
+}
+
+// TimelineEvent is a single alert event of a monitor. State is set for events recording a
+// series starting to fire or resolving.
+type TimelineEvent struct {
+	ID         string            `json:"id,omitempty"`
+	HappenedAt time.Time         `json:"happened_at"`
+	Type       string            `json:"type,omitempty"`
+	State      string            `json:"state,omitempty"`
+	Severity   string            `json:"severity,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Title      string            `json:"title,omitempty"`
+}
+
+// TimelineBucket is the number of alert events in a time bucket. Empty buckets are omitted.
+type TimelineBucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Count int       `json:"count"`
+}
+
+// NotificationSummary is the resolved notification policy of a monitor and how it routes each
+// severity for the monitor labels.
+type NotificationSummary struct {
+	Chain    []*ChainLink `json:"chain"`
+	Critical *PolicyRoute `json:"critical,omitempty"`
+	Warn     *PolicyRoute `json:"warn,omitempty"`
+}
+
+func (t *Tools) explainMonitor(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "monitor_slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	timeRange, err := params.ParseTimeRange(request)
+	if err != nil {
+		return nil, err
+	}
+	eventQuery, err := params.String(request, "event_query", false, monitorEventQuery(slug))
+	if err != nil {
+		return nil, err
+	}
+	maxSeries, err := params.Int(request, "max_series", false, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.configAPI.Monitor.ReadMonitor(&monitor.ReadMonitorParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monitor %s: %s", slug, err)
+	}
+	m := resp.Payload.Monitor
+	explanation := &MonitorExplanation{
+		Monitor:      summarizeMonitor(m),
+		FiringSeries: []*FiringSeries{},
+	}
+
+	statusResp, err := t.api.StateUnstable.ListMonitorStatuses(state_unstable.NewListMonitorStatusesParams().
+		WithContext(ctx).
+		WithMonitorSlugs([]string{slug}).
+		WithIncludeSignalStatuses(ptr.To(true)).
+		WithIncludeSeriesStatuses(ptr.To(true)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitor statuses: %s", err)
+	}
+	for _, status := range statusResp.Payload.MonitorStatuses {
+		if status == nil || status.Slug != slug {
+			continue
+		}
+		explanation.State = string(status.State)
+		explanation.FiringSeries = firingSeries(status, time.Now())
+	}
+	if maxSeries > 0 && len(explanation.FiringSeries) > maxSeries {
+		explanation.Warnings = append(explanation.Warnings, fmt.Sprintf(
+			"%d series are firing; only the %d longest firing are listed", len(explanation.FiringSeries), maxSeries))
+		explanation.FiringSeries = explanation.FiringSeries[:maxSeries]
+	}
+
+	eventsResp, err := t.dataUnstableAPI.DataUnstable.GetEventsForMonitor(&data_unstable.GetEventsForMonitorParams{
+		Context:        ctx,
+		HappenedAfter:  (*strfmt.DateTime)(ptr.To(timeRange.Start)),
+		HappenedBefore: (*strfmt.DateTime)(ptr.To(timeRange.End)),
+		Query:          ptr.To(eventQuery),
+		StepSize:       ptr.To(timelineStep(timeRange)),
+	})
+	if err != nil {
+		// The timeline is supplementary, so the rest of the explanation is still returned.
+		explanation.Warnings = append(explanation.Warnings, fmt.Sprintf("failed to get events for monitor: %s", err))
+	} else {
+		explanation.Timeline = buildTimeline(eventQuery, eventsResp.Payload)
+	}
+	// The histogram only identifies events, so the events are listed to tell which series
+	// started firing or resolved.
+	events, truncated, err := t.listEvents(ctx, eventQuery, timeRange, defaultMaxEvents)
+	if err != nil {
+		explanation.Warnings = append(explanation.Warnings, err.Error())
+	} else {
+		if explanation.Timeline == nil {
+			explanation.Timeline = &Timeline{Query: eventQuery, TotalEvents: len(events)}
+		}
+		if truncated {
+			explanation.Warnings = append(explanation.Warnings, fmt.Sprintf(
+				"more than %d events in the time range; only the first %d are included", defaultMaxEvents, defaultMaxEvents))
+		}
+		var limited bool
+		explanation.Timeline.Events, limited = timelineEvents(events, maxTimelineEvents)
+		if limited {
+			explanation.Warnings = append(explanation.Warnings, fmt.Sprintf(
+				"%d events in the time range; only the %d most recent are listed", len(events), maxTimelineEvents))
+		}
+	}
+
+	notification, err := t.monitorNotification(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	explanation.Notification = notification
+	if notification.Critical == nil && notification.Warn == nil {
+		explanation.Warnings = append(explanation.Warnings, "no notification policy is configured, so no notifications are sent")
+	}
+
+	result := &tools.Result{
+		JSONContent: explanation,
+	}
+	if m.PrometheusQuery != "" {
+		result.ChronosphereLink = t.linkBuilder.MetricExplorer().
+			WithQuery(m.PrometheusQuery).
+			WithTimeRange(timeRange.Start, timeRange.End).
+			String()
+	}
+	return result, nil
+}
+
+// monitorNotification resolves the notification policy of a monitor and routes each severity
+// using the monitor labels.
+func (t *Tools) monitorNotification(ctx context.Context, m *models.Configv1Monitor) (*NotificationSummary, error) {
+	chain, err := t.resolveMonitorPolicy(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	summary := &NotificationSummary{Chain: chain}
+	policySlug := chain[len(chain)-1].NotificationPolicySlug
+	if policySlug == "" {
+		return summary, nil
+	}
+	resp, err := t.configAPI.NotificationPolicy.ReadNotificationPolicy(&notification_policy.ReadNotificationPolicyParams{
+		Context: ctx,
+		Slug:    policySlug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification policy %s: %s", policySlug, err)
+	}
+	notifiers := make(map[string]*RoutedNotifier)
+	for _, severity := range []string{severityCritical, severityWarn} {
+		route, err := routeAlert(resp.Payload.NotificationPolicy, m.Labels, severity)
+		if err != nil {
+			return nil, err
+		}
+		if route.notifierList != nil {
+			for _, notifierSlug := range route.notifierList.NotifierSlugs {
+				n, ok := notifiers[notifierSlug]
+				if !ok {
+					n = t.describeNotifier(ctx, notifierSlug)
+					notifiers[notifierSlug] = n
+				}
+				route.Notifiers = append(route.Notifiers, n)
+			}
+		}
+		if severity == severityCritical {
+			summary.Critical = route
+		} else {
+			summary.Warn = route
+		}
+	}
+	return summary, nil
+}
+
+// monitorEventQuery returns the event query selecting the alert events of a monitor.
+func monitorEventQuery(slug string) string {
+	return fmt.Sprintf("%s AND labels.%s = %q", alertEventQuery(), monitorSlugEventLabel, slug)
+}
+
+// alertEventQuery returns the event query selecting the alert events of all monitors.
+func alertEventQuery() string {
+	return fmt.Sprintf("category = %q", eventCategoryAlerts)
+}
+
+// timelineStep returns the histogram step size for a time range, formatted as a duration in
+// seconds.
+func timelineStep(timeRange *params.TimeRange) string {
+	step := timeRange.End.Sub(timeRange.Start) / timelineBuckets
+	if step < minTimelineStep {
+		step = minTimelineStep
+	}
+	return fmt.Sprintf("%ds", int64(step.Truncate(time.Second).Seconds()))
+}
+
+func summarizeMonitor(m *models.Configv1Monitor) *MonitorSummary {
+	s := &MonitorSummary{
+		Slug:           m.Slug,
+		Name:           m.Name,
+		IntervalSecs:   m.IntervalSecs,
+		CollectionSlug: m.CollectionSlug,
+		Labels:         m.Labels,
+		Annotations:    m.Annotations,
+		SignalGrouping: m.SignalGrouping,
+		Conditions:     describeConditions(m.SeriesConditions),
+		Schedule:       m.Schedule,
+	}
+	switch {
+	case m.PrometheusQuery != "":
+		s.QueryType, s.Query = "prometheus", m.PrometheusQuery
+	case m.GraphiteQuery != "":
+		s.QueryType, s.Query = "graphite", m.GraphiteQuery
+	case m.LoggingQuery != "":
+		s.QueryType, s.Query = "logging", m.LoggingQuery
+	}
+	if m.Collection != nil {
+		s.CollectionType = string(m.Collection.Type)
+		s.CollectionSlug = m.Collection.Slug
+	}
+	return s
+}
+
+// describeConditions renders series conditions as one line per severity, e.g.
+// "defaults critical: GT 100 for 300s".
+func describeConditions(conditions *models.MonitorSeriesConditions) []string {
+	var out []string
+	if conditions == nil {
+		return out
+	}
+	add := func(scope string, sc *models.SeriesConditionsSeverityConditions) {
+		if sc == nil {
+			return
+		}
+		for _, s := range []struct {
+			severity   string
+			conditions *models.SeriesConditionsConditions
+		}{
+			{severityCritical, sc.Critical},
+			{severityWarn, sc.Warn},
+		} {
+			if s.conditions == nil {
+				continue
+			}
+			for _, c := range s.conditions.Conditions {
+				if c == nil {
+					continue
+				}
+				out = append(out, fmt.Sprintf("%s %s: %s", scope, s.severity, describeCondition(c)))
+			}
+		}
+	}
+	add("defaults", conditions.Defaults)
+	for i, o := range conditions.Overrides {
+		if o == nil {
+			continue
+		}
+		matchers := make([]string, 0, len(o.LabelMatchers))
+		for _, m := range o.LabelMatchers {
+			matchers = append(matchers, matcherString(m))
+		}
+		add(fmt.Sprintf("overrides[%d] {%s}", i, strings.Join(matchers, ",")), o.SeverityConditions)
+	}
+	return out
+}
+
+func describeCondition(c *models.MonitorCondition) string {
+	var sb strings.Builder
+	sb.WriteString(string(c.Op))
+	if c.Op != models.ConditionOpEXISTS && c.Op != models.ConditionOpNOTEXISTS {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.FormatFloat(c.Value, 'g', -1, 64))
+	}
+	if c.SustainSecs > 0 {
+		fmt.Fprintf(&sb, " for %ds", c.SustainSecs)
+	}
+	if c.ResolveSustainSecs > 0 {
+		fmt.Fprintf(&sb, ", resolves after %ds", c.ResolveSustainSecs)
+	}
+	return sb.String()
+}
+
+// firingSeries returns the series of a monitor status that are not passing, longest firing first.
+func firingSeries(status *stateunstablemodels.StateunstableMonitorStatus, now time.Time) []*FiringSeries {
+	out := []*FiringSeries{}
+	for _, signal := range status.Signals {
+		if signal == nil {
+			continue
+		}
+		signalLabels := commonLabels(signal.SignalLabels)
+		for _, series := range signal.Series {
+			if series == nil || series.State == stateunstablemodels.StateunstableAlertingStateALERTINGSTATEPASS {
+				continue
+			}
+			fs := &FiringSeries{
+				Severity:     series.Severity,
+				State:        string(series.State),
+				SignalLabels: signalLabels,
+				Labels:       commonLabels(series.Labels),
+			}
+			if startedAt := time.Time(series.StartedAt); !startedAt.IsZero() {
+				fs.StartedAt = &startedAt
+				fs.DurationSecs = now.Sub(startedAt).Seconds()
+			}
+			out = append(out, fs)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].DurationSecs > out[j].DurationSecs
+	})
+	return out
+}
+
+func commonLabels(lbls []*stateunstablemodels.CommonLabel) map[string]string {
+	out := make(map[string]string, len(lbls))
+	for _, l := range lbls {
+		if l != nil {
+			out[l.Name] = l.Value
+		}
+	}
+	return out
+}
+
+// buildTimeline converts the event histogram of a monitor into a timeline without events. The
+// response holds one entry per event, each with the bucket it falls in, so buckets are
+// deduplicated.
+func buildTimeline(query string, resp *dataunstablemodels.DataunstableGetEventsForMonitorResponse) *Timeline {
+	timeline := &Timeline{Query: query}
+	if resp == nil {
+		return timeline
+	}
+	timeline.TotalEvents, _ = strconv.Atoi(resp.TotalEvents)
+	buckets := make(map[time.Time]*TimelineBucket)
+	for _, h := range resp.EventHistogramWithDetails {
+		if h == nil {
+			continue
+		}
+		if b := h.EventBucket; b != nil {
+			start := time.Time(b.StartTime)
+			if _, ok := buckets[start]; ok {
+				continue
+			}
+			bucket := &TimelineBucket{Start: start, End: time.Time(b.EndTime)}
+			for _, g := range b.Groups {
+				if g == nil {
+					continue
+				}
+				n, _ := strconv.Atoi(g.Count)
+				bucket.Count += n
+			}
+			buckets[start] = bucket
+		}
+	}
+	for _, b := range buckets {
+		if b.Count > 0 {
+			timeline.Buckets = append(timeline.Buckets, b)
+		}
+	}
+	sort.Slice(timeline.Buckets, func(i, j int) bool {
+		return timeline.Buckets[i].Start.Before(timeline.Buckets[j].Start)
+	})
+	if timeline.TotalEvents == 0 {
+		for _, h := range resp.EventHistogramWithDetails {
+			if h != nil && h.Event != nil {
+				timeline.TotalEvents++
+			}
+		}
+	}
+	return timeline
+}
+
+// timelineEvents returns the alert events in time order with the state they record and the
+// labels of their series. Only the limit most recent events are returned; it reports whether
+// events were left out.
+func timelineEvents(events []*datav1models.Datav1Event, limit int) ([]*TimelineEvent, bool) {
+	out := make([]*TimelineEvent, 0, len(events))
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		state, _ := eventState(e)
+		out = append(out, &TimelineEvent{
+			ID:         e.ID,
+			HappenedAt: time.Time(e.HappenedAt),
+			Type:       e.Type,
+			State:      state,
+			Severity:   e.Labels[severityEventLabel],
+			Labels:     seriesLabels(e.Labels),
+			Title:      e.Title,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].HappenedAt.Before(out[j].HappenedAt)
+	})
+	if limit > 0 && len(out) > limit {
+		return out[len(out)-limit:], true
+	}
+	return out, false
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	dataunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/models"
+	datav1models "github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
+	stateunstablemodels "github.com/chronosphereio/chronosphere-mcp/generated/stateunstable/models"
+)
+
+func TestDescribeConditions(t *testing.T) {
+	conditions := &models.MonitorSeriesConditions{
+		Defaults: &models.SeriesConditionsSeverityConditions{
+			Critical: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+				{Op: models.ConditionOpGT, Value: 100, SustainSecs: 300, ResolveSustainSecs: 60},
+			}},
+			Warn: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+				{Op: models.ConditionOpGT, Value: 50.5},
+			}},
+		},
+		Overrides: []*models.MonitorSeriesConditionsOverride{
+			{
+				LabelMatchers: []*models.Configv1LabelMatcher{
+					{Name: "env", Type: models.Configv1LabelMatcherMatcherTypeEXACT, Value: "dev"},
+				},
+				SeverityConditions: &models.SeriesConditionsSeverityConditions{
+					Critical: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+						{Op: models.ConditionOpNOTEXISTS, SustainSecs: 600},
+					}},
+				},
+			},
+		},
+	}
+	require.Equal(t, []string{
+		"defaults critical: GT 100 for 300s, resolves after 60s",
+		"defaults warn: GT 50.5",
+		`overrides[0] {env="dev"} critical: NOT_EXISTS for 600s`,
+	}, describeConditions(conditions))
+	require.Empty(t, describeConditions(nil))
+}
+
+func TestFiringSeries(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	status := &stateunstablemodels.StateunstableMonitorStatus{
+		Slug: "high-latency",
+		Signals: []*stateunstablemodels.MonitorStatusSignalStatus{
+			{
+				SignalLabels: []*stateunstablemodels.CommonLabel{{Name: "service", Value: "api"}},
+				Series: []*stateunstablemodels.SignalStatusSeriesStatus{
+					{
+						Labels:    []*stateunstablemodels.CommonLabel{{Name: "pod", Value: "api-1"}},
+						Severity:  severityWarn,
+						State:     stateunstablemodels.StateunstableAlertingStateALERTINGSTATEALERTING,
+						StartedAt: strfmt.DateTime(now.Add(-5 * time.Minute)),
+					},
+					{
+						Labels: []*stateunstablemodels.CommonLabel{{Name: "pod", Value: "api-2"}},
+						State:  stateunstablemodels.StateunstableAlertingStateALERTINGSTATEPASS,
+					},
+					{
+						Labels:    []*stateunstablemodels.CommonLabel{{Name: "pod", Value: "api-3"}},
+						Severity:  severityCritical,
+						State:     stateunstablemodels.StateunstableAlertingStateALERTINGSTATEALERTING,
+						StartedAt: strfmt.DateTime(now.Add(-time.Hour)),
+					},
+				},
+			},
+		},
+	}
+
+	series := firingSeries(status, now)
+	require.Len(t, series, 2)
+	require.Equal(t, map[string]string{"pod": "api-3"}, series[0].Labels)
+	require.Equal(t, map[string]string{"service": "api"}, series[0].SignalLabels)
+	require.Equal(t, 3600.0, series[0].DurationSecs)
+	require.Equal(t, map[string]string{"pod": "api-1"}, series[1].Labels)
+	require.Equal(t, 300.0, series[1].DurationSecs)
+}
+
+func TestBuildTimeline(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	bucket := func(offset time.Duration, count string) *dataunstablemodels.DataunstableEventBucket {
+		return &dataunstablemodels.DataunstableEventBucket{
+			StartTime: strfmt.DateTime(start.Add(offset)),
+			EndTime:   strfmt.DateTime(start.Add(offset + time.Minute)),
+			Groups:    []*dataunstablemodels.EventBucketGroup{{Count: count}},
+		}
+	}
+	event := func(id string, offset time.Duration) *dataunstablemodels.DataunstableEventDetails {
+		return &dataunstablemodels.DataunstableEventDetails{
+			ID:         id,
+			Category:   eventCategoryAlerts,
+			HappenedAt: strfmt.DateTime(start.Add(offset)),
+		}
+	}
+	resp := &dataunstablemodels.DataunstableGetEventsForMonitorResponse{
+		TotalEvents: "3",
+		EventHistogramWithDetails: []*dataunstablemodels.DataunstableEventHistogramWithDetails{
+			{Event: event("c", 5*time.Minute+10*time.Second), EventBucket: bucket(5*time.Minute, "1")},
+			{Event: event("a", 10*time.Second), EventBucket: bucket(0, "2")},
+			{Event: event("b", 20*time.Second), EventBucket: bucket(0, "2")},
+			{EventBucket: bucket(time.Minute, "0")},
+		},
+	}
+
+	timeline := buildTimeline("q", resp)
+	require.Equal(t, 3, timeline.TotalEvents)
+	require.Empty(t, timeline.Events)
+	require.Len(t, timeline.Buckets, 2)
+	require.Equal(t, 2, timeline.Buckets[0].Count)
+	require.Equal(t, start.Add(5*time.Minute), timeline.Buckets[1].Start)
+}
+
+func TestTimelineEvents(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := func(id string, mins int, typ string) *datav1models.Datav1Event {
+		return &datav1models.Datav1Event{
+			ID:         id,
+			Category:   eventCategoryAlerts,
+			Type:       typ,
+			HappenedAt: strfmt.DateTime(start.Add(time.Duration(mins) * time.Minute)),
+			Labels: map[string]string{
+				monitorSlugEventLabel: "high-latency",
+				severityEventLabel:    severityCritical,
+				"pod":                 "api-1",
+			},
+		}
+	}
+	events, limited := timelineEvents([]*datav1models.Datav1Event{
+		event("resolved", 7, eventTypeResolved),
+		event("firing", 2, eventTypeFiring),
+	}, maxTimelineEvents)
+	require.False(t, limited)
+	require.Equal(t, []*TimelineEvent{
+		{
+			ID:         "firing",
+			HappenedAt: start.Add(2 * time.Minute),
+			Type:       eventTypeFiring,
+			State:      stateFiring,
+			Severity:   severityCritical,
+			Labels:     map[string]string{"pod": "api-1"},
+		},
+		{
+			ID:         "resolved",
+			HappenedAt: start.Add(7 * time.Minute),
+			Type:       eventTypeResolved,
+			State:      stateResolved,
+			Severity:   severityCritical,
+			Labels:     map[string]string{"pod": "api-1"},
+		},
+	}, events)
+
+	events, limited = timelineEvents([]*datav1models.Datav1Event{
+		event("a", 1, eventTypeFiring),
+		event("b", 2, eventTypeResolved),
+		event("c", 3, eventTypeFiring),
+	}, 2)
+	require.True(t, limited)
+	require.Len(t, events, 2)
+	require.Equal(t, "b", events[0].ID)
+}
+
+func TestMonitorEventQuery(t *testing.T) {
+	require.Equal(t, `category = "alerts" AND labels.monitor_slug = "checkout-errors"`, monitorEventQuery("checkout-errors"))
+}
@@ -0,0 +1,497 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable/data_unstable"
+	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1/version1"
+	datav1models "github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+const (
+	stateFiring   = "firing"
+	stateResolved = "resolved"
+
+	severityEventLabel = "severity"
+
+	// Alert event types, which record whether a series started firing or resolved.
+	eventTypeFiring   = "alert_firing"
+	eventTypeResolved = "alert_resolved"
+
+	defaultFlapWindowSecs = 900
+	eventsPageSize        = 1000
+	defaultMaxEvents      = 10000
+	// monitorsPerEventQuery bounds the number of monitor slugs in a single event query.
+	monitorsPerEventQuery = 20
+)
+
+// HistoryStats summarizes the alert transitions of a monitor or series over a window.
+type HistoryStats struct {
+	Transitions     int     `json:"transitions"`
+	Alerts          int     `json:"alerts"`
+	TimeInAlertSecs float64 `json:"time_in_alert_secs"`
+	PercentInAlert  float64 `json:"percent_in_alert"`
+	// FlappingScore is the fraction of alerts that resolved within the flap window, from 0 to 1.
+	FlappingScore float64 `json:"flapping_score"`
+}
+
+// MonitorHistory is the alert history of a monitor over a window.
+type MonitorHistory struct {
+	MonitorSlug    string            `json:"monitor_slug"`
+	EventQuery     string            `json:"event_query"`
+	Start          time.Time         `json:"start"`
+	End            time.Time         `json:"end"`
+	FlapWindowSecs int               `json:"flap_window_secs"`
+	Histogram      []*TimelineBucket `json:"histogram,omitempty"`
+	HistoryStats
+	Series   []*SeriesHistory `json:"series"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// SeriesHistory is the alert history of a single series of a monitor.
+type SeriesHistory struct {
+	Labels map[string]string `json:"labels"`
+	HistoryStats
+	Intervals []*FiringInterval `json:"firing_intervals"`
+	Log       []*Transition     `json:"transitions_log,omitempty"`
+}
+
+// Transition is a state change of a series, derived from an alert event.
+type Transition struct {
+	Time     time.Time `json:"time"`
+	State    string    `json:"state"`
+	Severity string    `json:"severity,omitempty"`
+	Title    string    `json:"title,omitempty"`
+}
+
+// NoisyMonitors is the result of list_noisy_monitors.
+type NoisyMonitors struct {
+	MonitorsInScope    int             `json:"monitors_in_scope"`
+	MonitorsWithAlerts int             `json:"monitors_with_alerts"`
+	Start              time.Time       `json:"start"`
+	End                time.Time       `json:"end"`
+	FlapWindowSecs     int             `json:"flap_window_secs"`
+	Monitors           []*NoisyMonitor `json:"monitors"`
+	Warnings           []string        `json:"warnings,omitempty"`
+}
+
+// NoisyMonitor is the alert history summary of a monitor ranked by list_noisy_monitors.
+type NoisyMonitor struct {
+	Slug   string `json:"slug"`
+	Name   string `json:"name,omitempty"`
+	Series int    `json:"series"`
+	HistoryStats
+}
+
+func (t *Tools) getMonitorHistory(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "monitor_slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	timeRange, err := params.ParseTimeRange(request)
+	if err != nil {
+		return nil, err
+	}
+	eventQuery, err := params.String(request, "event_query", false, monitorEventQuery(slug))
+	if err != nil {
+		return nil, err
+	}
+	flapWindowSecs, err := params.Int(request, "flap_window_secs", false, defaultFlapWindowSecs)
+	if err != nil {
+		return nil, err
+	}
+	includeTransitions, err := params.Bool(request, "include_transitions", false, false)
+	if err != nil {
+		return nil, err
+	}
+	maxSeries, err := params.Int(request, "max_series", false, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &MonitorHistory{
+		MonitorSlug:    slug,
+		EventQuery:     eventQuery,
+		Start:          timeRange.Start,
+		End:            timeRange.End,
+		FlapWindowSecs: flapWindowSecs,
+	}
+	events, truncated, err := t.listEvents(ctx, eventQuery, timeRange, defaultMaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	if truncated {
+		history.Warnings = append(history.Warnings, fmt.Sprintf(
+			"more than %d events in the time range; only the first %d are included", defaultMaxEvents, defaultMaxEvents))
+	}
+	history.HistoryStats, history.Series = buildHistory(events, timeRange, time.Duration(flapWindowSecs)*time.Second)
+	if !includeTransitions {
+		for _, s := range history.Series {
+			s.Log = nil
+		}
+	}
+	if maxSeries > 0 && len(history.Series) > maxSeries {
+		history.Warnings = append(history.Warnings, fmt.Sprintf(
+			"%d series have transitions; only the %d with the most transitions are listed", len(history.Series), maxSeries))
+		history.Series = history.Series[:maxSeries]
+	}
+
+	histogram, err := t.dataUnstableAPI.DataUnstable.GetEventsForMonitor(&data_unstable.GetEventsForMonitorParams{
+		Context:        ctx,
+		HappenedAfter:  (*strfmt.DateTime)(ptr.To(timeRange.Start)),
+		HappenedBefore: (*strfmt.DateTime)(ptr.To(timeRange.End)),
+		Query:          ptr.To(eventQuery),
+		StepSize:       ptr.To(timelineStep(timeRange)),
+	})
+	if err != nil {
+		history.Warnings = append(history.Warnings, fmt.Sprintf("failed to get events for monitor: %s", err))
+	} else {
+		history.Histogram = buildTimeline(eventQuery, histogram.Payload).Buckets
+	}
+
+	return &tools.Result{
+		JSONContent: history,
+		ChronosphereLink: t.linkBuilder.EventExplorer().
+			WithQuery(eventQuery).
+			WithTimeRange(timeRange.Start, timeRange.End).
+			String(),
+	}, nil
+}
+
+func (t *Tools) listNoisyMonitors(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	teamSlugs, err := params.StringArray(request, "team_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	collectionSlugs, err := params.StringArray(request, "collection_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	lookbackSecs, err := params.Int(request, "lookback_secs", false, 7*24*60*60)
+	if err != nil {
+		return nil, err
+	}
+	flapWindowSecs, err := params.Int(request, "flap_window_secs", false, defaultFlapWindowSecs)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := params.Int(request, "limit", false, 20)
+	if err != nil {
+		return nil, err
+	}
+	if len(teamSlugs) == 0 && len(collectionSlugs) == 0 {
+		return nil, fmt.Errorf("at least one of team_slugs or collection_slugs must be provided")
+	}
+
+	monitors, err := configlist.Monitors(ctx, t.configAPI, configlist.Filter{
+		TeamSlugs:       teamSlugs,
+		CollectionSlugs: collectionSlugs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(monitors))
+	for _, m := range monitors {
+		names[m.Slug] = m.Name
+	}
+
+	end := time.Now()
+	timeRange := &params.TimeRange{Start: end.Add(-time.Duration(lookbackSecs) * time.Second), End: end}
+	slugs := slices.Sorted(maps.Keys(names))
+
+	// Events are queried for the monitors in scope, so that events of other monitors do not
+	// count towards the event limit.
+	var warnings []string
+	byMonitor := make(map[string][]*datav1models.Datav1Event)
+	for batch := range slices.Chunk(slugs, monitorsPerEventQuery) {
+		events, truncated, err := t.listEvents(ctx, monitorsEventQuery(batch), timeRange, defaultMaxEvents)
+		if err != nil {
+			return nil, err
+		}
+		if truncated {
+			warnings = append(warnings, fmt.Sprintf(
+				"more than %d alert events in the lookback for monitors %s; their counts only include the first %d",
+				defaultMaxEvents, strings.Join(batch, ", "), defaultMaxEvents))
+		}
+		for _, e := range events {
+			slug := e.Labels[monitorSlugEventLabel]
+			if _, ok := names[slug]; ok {
+				byMonitor[slug] = append(byMonitor[slug], e)
+			}
+		}
+	}
+
+	noisy := make([]*NoisyMonitor, 0, len(byMonitor))
+	for slug, monitorEvents := range byMonitor {
+		stats, series := buildHistory(monitorEvents, timeRange, time.Duration(flapWindowSecs)*time.Second)
+		noisy = append(noisy, &NoisyMonitor{
+			Slug:         slug,
+			Name:         names[slug],
+			Series:       len(series),
+			HistoryStats: stats,
+		})
+	}
+	sort.Slice(noisy, func(i, j int) bool {
+		if noisy[i].Transitions != noisy[j].Transitions {
+			return noisy[i].Transitions > noisy[j].Transitions
+		}
+		return noisy[i].Slug < noisy[j].Slug
+	})
+	result := &NoisyMonitors{
+		MonitorsInScope:    len(monitors),
+		MonitorsWithAlerts: len(noisy),
+		Start:              timeRange.Start,
+		End:                timeRange.End,
+		FlapWindowSecs:     flapWindowSecs,
+		Monitors:           noisy,
+		Warnings:           warnings,
+	}
+	if limit > 0 && len(result.Monitors) > limit {
+		result.Monitors = result.Monitors[:limit]
+	}
+	linkQuery := alertEventQuery()
+	if len(slugs) > 0 {
+		linkQuery = monitorsEventQuery(slugs)
+	}
+	return &tools.Result{
+		JSONContent: result,
+		ChronosphereLink: t.linkBuilder.EventExplorer().
+			WithQuery(linkQuery).
+			WithTimeRange(timeRange.Start, timeRange.End).
+			String(),
+	}, nil
+}
+
+// listEvents pages through the events matching the query, stopping once maxEvents have been
+// read. It reports whether more events were available.
+func (t *Tools) listEvents(
+	ctx context.Context,
+	query string,
+	timeRange *params.TimeRange,
+	maxEvents int,
+) ([]*datav1models.Datav1Event, bool, error) {
+	var (
+		events    []*datav1models.Datav1Event
+		pageToken *string
+	)
+	for {
+		resp, err := t.dataV1API.Version1.ListEvents(&version1.ListEventsParams{
+			Context:        ctx,
+			HappenedAfter:  (*strfmt.DateTime)(ptr.To(timeRange.Start)),
+			HappenedBefore: (*strfmt.DateTime)(ptr.To(timeRange.End)),
+			Query:          ptr.To(query),
+			PageMaxSize:    ptr.To(int64(eventsPageSize)),
+			PageToken:      pageToken,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list events: %s", err)
+		}
+		events = append(events, resp.Payload.Events...)
+		page := resp.Payload.Page
+		if page == nil || page.NextToken == "" {
+			return events, false, nil
+		}
+		if len(events) >= maxEvents {
+			return events[:maxEvents], true, nil
+		}
+		pageToken = ptr.To(page.NextToken)
+	}
+}
+
+// buildHistory groups alert events by series and derives the firing intervals of each series.
+// Series are ordered by number of transitions, most first.
+func buildHistory(
+	events []*datav1models.Datav1Event,
+	timeRange *params.TimeRange,
+	flapWindow time.Duration,
+) (HistoryStats, []*SeriesHistory) {
+	bySeries := make(map[string]*SeriesHistory)
+	var keys []string
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		state, ok := eventState(e)
+		if !ok {
+			continue
+		}
+		lbls := seriesLabels(e.Labels)
+		key := labelsKey(lbls)
+		s, ok := bySeries[key]
+		if !ok {
+			s = &SeriesHistory{Labels: lbls}
+			bySeries[key] = s
+			keys = append(keys, key)
+		}
+		s.Log = append(s.Log, &Transition{
+			Time:     time.Time(e.HappenedAt),
+			State:    state,
+			Severity: e.Labels[severityEventLabel],
+			Title:    e.Title,
+		})
+	}
+
+	var (
+		total      HistoryStats
+		all        []*FiringInterval
+		shortLived int
+		series     = make([]*SeriesHistory, 0, len(keys))
+	)
+	for _, key := range keys {
+		s := bySeries[key]
+		sort.SliceStable(s.Log, func(i, j int) bool {
+			return s.Log[i].Time.Before(s.Log[j].Time)
+		})
+		var short int
+		s.Intervals, s.Transitions = firingIntervals(s.Log, timeRange)
+		for _, iv := range s.Intervals {
+			s.TimeInAlertSecs += iv.DurationSecs
+			if !iv.Ongoing && iv.DurationSecs < flapWindow.Seconds() {
+				short++
+			}
+			all = append(all, &FiringInterval{Start: iv.Start, End: iv.End, DurationSecs: iv.DurationSecs})
+		}
+		s.Alerts = len(s.Intervals)
+		s.PercentInAlert = percentOf(s.TimeInAlertSecs, timeRange)
+		if s.Alerts > 0 {
+			s.FlappingScore = float64(short) / float64(s.Alerts)
+		}
+		total.Transitions += s.Transitions
+		total.Alerts += s.Alerts
+		shortLived += short
+		series = append(series, s)
+	}
+	for _, iv := range mergeIntervals(all) {
+		total.TimeInAlertSecs += iv.DurationSecs
+	}
+	total.PercentInAlert = percentOf(total.TimeInAlertSecs, timeRange)
+	if total.Alerts > 0 {
+		total.FlappingScore = float64(shortLived) / float64(total.Alerts)
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Transitions > series[j].Transitions
+	})
+	return total, series
+}
+
+// firingIntervals derives firing intervals from the ordered transitions of a series and counts
+// the state changes. A series first seen resolving was firing since the start of the window, and
+// a series still firing at the end of the window has an ongoing interval.
+func firingIntervals(transitions []*Transition, timeRange *params.TimeRange) ([]*FiringInterval, int) {
+	var (
+		intervals []*FiringInterval
+		open      *FiringInterval
+		state     string
+		changes   int
+	)
+	closeAt := func(end time.Time, ongoing bool) {
+		open.End = end
+		open.DurationSecs = end.Sub(open.Start).Seconds()
+		open.Ongoing = ongoing
+		intervals = append(intervals, open)
+		open = nil
+	}
+	for _, tr := range transitions {
+		if tr.State == state {
+			continue
+		}
+		changes++
+		state = tr.State
+		switch tr.State {
+		case stateFiring:
+			open = &FiringInterval{Severity: tr.Severity, Start: tr.Time}
+		case stateResolved:
+			if open == nil {
+				open = &FiringInterval{Severity: tr.Severity, Start: timeRange.Start}
+			}
+			closeAt(tr.Time, false)
+		}
+	}
+	if open != nil {
+		closeAt(timeRange.End, true)
+	}
+	return intervals, changes
+}
+
+// eventState returns whether an alert event records a series starting to fire or resolving,
+// based on its type. It returns false for events of other types.
+func eventState(e *datav1models.Datav1Event) (string, bool) {
+	switch strings.ToLower(e.Type) {
+	case eventTypeFiring:
+		return stateFiring, true
+	case eventTypeResolved:
+		return stateResolved, true
+	}
+	return "", false
+}
+
+// monitorsEventQuery returns the event query selecting the alert events of the monitors.
+func monitorsEventQuery(slugs []string) string {
+	quoted := make([]string, len(slugs))
+	for i, slug := range slugs {
+		quoted[i] = strconv.Quote(slug)
+	}
+	return fmt.Sprintf("%s AND labels.%s IN (%s)", alertEventQuery(), monitorSlugEventLabel, strings.Join(quoted, ", "))
+}
+
+// seriesLabels returns the labels of an alert event that identify the series, without the
+// labels describing the monitor and severity.
+func seriesLabels(lbls map[string]string) map[string]string {
+	out := make(map[string]string, len(lbls))
+	for k, v := range lbls {
+		if k == monitorSlugEventLabel || k == severityEventLabel {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func labelsKey(lbls map[string]string) string {
+	names := make([]string, 0, len(lbls))
+	for k := range lbls {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&sb, "%s=%q,", k, lbls[k])
+	}
+	return sb.String()
+}
+
+func percentOf(secs float64, timeRange *params.TimeRange) float64 {
+	window := timeRange.End.Sub(timeRange.Start).Seconds()
+	if window <= 0 {
+		return 0
+	}
+	return 100 * secs / window
+}
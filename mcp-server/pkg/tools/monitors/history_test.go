@@ -0,0 +1,125 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	datav1models "github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+func TestFiringIntervals(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeRange := &params.TimeRange{Start: start, End: start.Add(time.Hour)}
+	at := func(mins int, state string) *Transition {
+		return &Transition{Time: start.Add(time.Duration(mins) * time.Minute), State: state, Severity: severityCritical}
+	}
+
+	testCases := []struct {
+		name        string
+		transitions []*Transition
+		wantChanges int
+		wantSecs    []float64
+		wantOngoing []bool
+	}{
+		{
+			name:        "fires and resolves",
+			transitions: []*Transition{at(10, stateFiring), at(20, stateResolved)},
+			wantChanges: 2,
+			wantSecs:    []float64{600},
+			wantOngoing: []bool{false},
+		},
+		{
+			name:        "resolves first",
+			transitions: []*Transition{at(5, stateResolved), at(30, stateFiring)},
+			wantChanges: 2,
+			wantSecs:    []float64{300, 1800},
+			wantOngoing: []bool{false, true},
+		},
+		{
+			name:        "repeated firing events",
+			transitions: []*Transition{at(10, stateFiring), at(11, stateFiring), at(15, stateResolved)},
+			wantChanges: 2,
+			wantSecs:    []float64{300},
+			wantOngoing: []bool{false},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			intervals, changes := firingIntervals(tc.transitions, timeRange)
+			require.Equal(t, tc.wantChanges, changes)
+			require.Len(t, intervals, len(tc.wantSecs))
+			for i, iv := range intervals {
+				require.Equal(t, tc.wantSecs[i], iv.DurationSecs)
+				require.Equal(t, tc.wantOngoing[i], iv.Ongoing)
+			}
+		})
+	}
+}
+
+func TestBuildHistory(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeRange := &params.TimeRange{Start: start, End: start.Add(time.Hour)}
+	event := func(mins int, typ, pod string) *datav1models.Datav1Event {
+		return &datav1models.Datav1Event{
+			Category:   eventCategoryAlerts,
+			Type:       typ,
+			HappenedAt: strfmt.DateTime(start.Add(time.Duration(mins) * time.Minute)),
+			Labels: map[string]string{
+				monitorSlugEventLabel: "high-latency",
+				severityEventLabel:    severityCritical,
+				"pod":                 pod,
+			},
+		}
+	}
+	events := []*datav1models.Datav1Event{
+		// api-1 flaps twice within the flap window.
+		event(1, "alert_firing", "api-1"),
+		event(3, "alert_resolved", "api-1"),
+		event(5, "alert_firing", "api-1"),
+		event(7, "alert_resolved", "api-1"),
+		// api-2 fires for 40 minutes, overlapping with api-1.
+		event(20, "alert_resolved", "api-2"),
+		event(0, "alert_firing", "api-2"),
+		// Events of other types are not transitions, even if their title mentions resolving.
+		{Type: "alert_acknowledged", Title: "resolve pending", Labels: map[string]string{"pod": "api-3"}},
+	}
+
+	stats, series := buildHistory(events, timeRange, 5*time.Minute)
+	require.Len(t, series, 2)
+	require.Equal(t, map[string]string{"pod": "api-1"}, series[0].Labels)
+	require.Equal(t, 4, series[0].Transitions)
+	require.Equal(t, 2, series[0].Alerts)
+	require.Equal(t, 1.0, series[0].FlappingScore)
+	require.Equal(t, 2, series[1].Transitions)
+	require.Equal(t, 1200.0, series[1].TimeInAlertSecs)
+	require.Equal(t, 0.0, series[1].FlappingScore)
+
+	require.Equal(t, 6, stats.Transitions)
+	require.Equal(t, 3, stats.Alerts)
+	require.Equal(t, 1200.0, stats.TimeInAlertSecs)
+	require.InDelta(t, 100.0/3, stats.PercentInAlert, 1e-9)
+	require.InDelta(t, 2.0/3, stats.FlappingScore, 1e-9)
+}
+
+func TestMonitorsEventQuery(t *testing.T) {
+	require.Equal(t, `category = "alerts" AND labels.monitor_slug IN ("api-latency", "api-errors")`,
+		monitorsEventQuery([]string{"api-latency", "api-errors"}))
+}
@@ -12,38 +12,71 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package monitors provides tools for querying Chronosphere monitor statuses.
+// Package monitors provides tools for querying Chronosphere monitor statuses and
+// simulating how monitors alert.
 package monitors
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/dataunstable/dataunstable"
+	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1"
 	"github.com/chronosphereio/chronosphere-mcp/generated/stateunstable/stateunstable"
 	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"go.uber.org/zap"
 
 	"github.com/chronosphereio/chronosphere-mcp/generated/stateunstable/stateunstable/state_unstable"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/prometheus"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
 )
 
 var _ tools.MCPTools = (*Tools)(nil)
 
 // Tools represents the monitor tools.
 type Tools struct {
-	logger *zap.Logger
-	api    *stateunstable.StateUnstableAPI
+	logger          *zap.Logger
+	api             *stateunstable.StateUnstableAPI
+	configAPI       *configv1.ConfigV1API
+	dataUnstableAPI *dataunstable.DataUnstableAPI
+	dataV1API       *datav1.DataV1API
+	promAPI         v1.API
+	renderer        *prometheus.Renderer
+	linkBuilder     *links.Builder
 }
 
 // NewTools creates a new set of monitor tools.
-func NewTools(api *stateunstable.StateUnstableAPI, logger *zap.Logger) (*Tools, error) {
+func NewTools(
+	api *stateunstable.StateUnstableAPI,
+	configAPI *configv1.ConfigV1API,
+	dataUnstableAPI *dataunstable.DataUnstableAPI,
+	dataV1API *datav1.DataV1API,
+	promClient api.Client,
+	logger *zap.Logger,
+	linkBuilder *links.Builder,
+) (*Tools, error) {
+	renderer, err := prometheus.NewRenderer(prometheus.RendererOptions{})
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Info("monitor status tool configured")
 
 	return &Tools{
-		logger: logger,
-		api:    api,
+		logger:          logger,
+		api:             api,
+		configAPI:       configAPI,
+		dataUnstableAPI: dataUnstableAPI,
+		dataV1API:       dataV1API,
+		promAPI:         v1.NewAPI(promClient),
+		renderer:        renderer,
+		linkBuilder:     linkBuilder,
 	}, nil
 }
 
@@ -156,5 +189,211 @@ func (t *Tools) MCPTools() []tools.MCPTool {
 				}, nil
 			},
 		},
+		{
+			Metadata: tools.NewMetadata("simulate_notification_routing",
+				mcp.WithDescription(`Simulates who gets notified when a monitor fires with a given label set and severity.
+
+The notification policy is resolved the same way as for a firing alert: the monitor's own policy, otherwise the policy of the monitor's collection or service. Alternatively a collection, service, team or notification policy can be given directly. Overrides are evaluated in order against the alert labels; the first override whose matchers all match and that has notifiers for the severity is used, otherwise the policy defaults apply.
+
+Response fields:
+- alert_labels: Labels the routing was evaluated against (monitor labels merged with the given labels)
+- chain: Entities walked to find the notification policy
+- routes: Per notification policy, the matched route (defaults or overrides[i]), each override's matchers and whether they matched, and the resulting notifiers, repeat interval and group by labels`),
+				mcp.WithString("severity",
+					mcp.Description("Severity of the alert."),
+					mcp.Enum(severityCritical, severityWarn),
+					mcp.Required(),
+				),
+				mcp.WithString("monitor_slug",
+					mcp.Description("Slug of the monitor that fires."),
+				),
+				mcp.WithString("collection_slug",
+					mcp.Description("Slug of a collection to route through when no monitor is given."),
+				),
+				mcp.WithString("service_slug",
+					mcp.Description("Slug of a service to route through when no monitor is given."),
+				),
+				mcp.WithString("team_slug",
+					mcp.Description("Slug of a team whose notification policies are evaluated when no monitor, collection or service is given."),
+				),
+				mcp.WithString("notification_policy_slug",
+					mcp.Description("Slug of a notification policy to evaluate directly. Takes precedence over all other entities."),
+				),
+				mcp.WithObject("labels",
+					mcp.Description(`Alert labels, such as the labels of the firing series, e.g. {"service": "api", "env": "prod"}. These are merged over the monitor labels.`),
+					mcp.AdditionalProperties(map[string]any{"type": "string"}),
+				),
+			),
+			Handler: t.simulateNotificationRouting,
+		},
+		{
+			Metadata: tools.NewMetadata("backtest_monitor",
+				mcp.WithDescription(`Evaluates a monitor's conditions over a past window to show when it would have fired. Use this to tune thresholds before changing a monitor.
+
+The query is evaluated with a range query at the monitor interval (or step_seconds) and each condition is applied per series: a series starts firing once the condition has held for sustain_secs and resolves once it has not held for resolve_sustain_secs. Overrides are applied to the series whose labels match them. Conditions come from the monitor unless series_conditions or op and threshold are given, which makes it possible to try a different threshold on an existing monitor or to backtest a query without a monitor.
+
+Response fields:
+- summary: Per severity, number of firing series, number of alerts (firing intervals), total and percentage of time firing
+- series: Per series, the labels, min and max values and firing intervals, ordered by firing time
+- series_conditions: The conditions that were evaluated`),
+				mcp.WithString("monitor_slug",
+					mcp.Description("Slug of the monitor to backtest."),
+				),
+				mcp.WithString("query",
+					mcp.Description("PromQL query to backtest. Overrides the monitor's query if both are given."),
+				),
+				mcp.WithObject("series_conditions",
+					mcp.Description(`Conditions to evaluate, in the shape of a monitor's series_conditions, e.g. {"defaults": {"critical": {"conditions": [{"op": "GT", "value": 100, "sustain_secs": 300}]}}}. Overrides the monitor's conditions.`),
+				),
+				mcp.WithString("op",
+					mcp.Description("Shorthand for a single condition instead of series_conditions."),
+					mcp.Enum("GEQ", "GT", "LEQ", "LT", "EQ", "NEQ", "EXISTS", "NOT_EXISTS"),
+				),
+				mcp.WithNumber("threshold",
+					mcp.Description("Value of the shorthand condition. Required unless op is EXISTS or NOT_EXISTS."),
+				),
+				mcp.WithNumber("sustain_secs",
+					mcp.Description("How long the shorthand condition must hold before firing. Default is 0."),
+				),
+				mcp.WithNumber("resolve_sustain_secs",
+					mcp.Description("How long the shorthand condition must not hold before resolving. Default is 0."),
+				),
+				mcp.WithString("severity",
+					mcp.Description("Severity of the shorthand condition. Default is critical."),
+					mcp.Enum(severityCritical, severityWarn),
+				),
+				params.WithTimeRange(),
+				mcp.WithNumber("step_seconds",
+					mcp.Description("Evaluation interval in seconds. Defaults to the monitor interval, or 60."),
+				),
+				mcp.WithBoolean("render",
+					mcp.Description("Whether to render a chart of the query with the default thresholds. Default is false."),
+				),
+				mcp.WithNumber("max_series",
+					mcp.Description("Maximum number of series to list. Default is 50."),
+				),
+			),
+			Handler: t.backtestMonitor,
+		},
+		{
+			Metadata: tools.NewMetadata("recommend_monitor_thresholds",
+				mcp.WithDescription(`Recommends warn and critical thresholds for a PromQL query from its historical values. Use this when creating a monitor instead of guessing thresholds.
+
+Percentile baselines and a seasonal baseline (median and median absolute deviation per hour of day, or per hour of week for lookbacks of two weeks or more) are computed per series. Since a threshold applies to every series, options use the most extreme series baseline in the alerting direction, so no series alerts more often than its own baseline allows; quieter series may need overrides with tighter thresholds. Each candidate option is backtested over the lookback with the same semantics as backtest_monitor, and the most sensitive option whose critical alerts stay within max_alerts_per_week is recommended.
+
+Response fields:
+- baseline: Percentiles, min, max and seasonal thresholds, each taken from the most extreme series
+- series_baselines: The baseline of each series, most extreme first
+- options: Candidate warn and critical thresholds with how many alerts each would have produced, in total and per week
+- recommended: Name of the recommended option
+- series_conditions: The recommended conditions in the shape of a monitor's series_conditions, ready to use in a monitor`),
+				mcp.WithString("query",
+					mcp.Description("PromQL query the monitor will evaluate."),
+					mcp.Required(),
+				),
+				mcp.WithNumber("lookback_secs",
+					mcp.Description("How far back to analyze, in seconds. Default is 604800 (7 days)."),
+				),
+				mcp.WithString("direction",
+					mcp.Description("Whether to alert when values are above or below the threshold. Default is above."),
+					mcp.Enum(directionAbove, directionBelow),
+				),
+				mcp.WithNumber("sustain_secs",
+					mcp.Description("How long a condition must hold before firing. Default is 300."),
+				),
+				mcp.WithNumber("max_alerts_per_week",
+					mcp.Description("Target maximum number of critical alerts per week. Default is 1."),
+				),
+				mcp.WithNumber("step_seconds",
+					mcp.Description("Resolution of the analyzed data in seconds. Defaults to the lookback divided into 2000 points, at least 60."),
+				),
+			),
+			Handler: t.recommendMonitorThresholds,
+		},
+		{
+			Metadata: tools.NewMetadata("explain_monitor",
+				mcp.WithDescription(`Explains a monitor in one call: what it evaluates, what it is currently alerting on, how its state changed recently and who gets notified. Use this as the starting point when investigating an alert.
+
+Response fields:
+- monitor: Definition summary with the query, interval, owning collection, labels, signal grouping and conditions rendered as one line per severity
+- state: Current state of the monitor
+- firing_series: Series that are not passing, with their severity, state, signal and series labels and how long they have been in that state, longest first
+- timeline: Alert events of the monitor over the time range, oldest first, each with its type, the state it records (firing or resolved), severity and series labels, and the non-empty histogram buckets
+- notification: Entities walked to find the notification policy, and the notifiers the policy routes critical and warn alerts to for the monitor labels
+
+The response link opens the monitor query in the metrics explorer over the time range.`),
+				mcp.WithString("monitor_slug",
+					mcp.Description("Slug of the monitor to explain."),
+					mcp.Required(),
+				),
+				params.WithTimeRange(),
+				mcp.WithString("event_query",
+					mcp.Description(`Event query selecting the monitor's alert events for the timeline. Defaults to category = "alerts" AND labels.monitor_slug = "<monitor_slug>".`),
+				),
+				mcp.WithNumber("max_series",
+					mcp.Description("Maximum number of firing series to list. Default is 50."),
+				),
+			),
+			Handler: t.explainMonitor,
+		},
+		{
+			Metadata: tools.NewMetadata("get_monitor_history",
+				mcp.WithDescription(`Returns the alert history of a monitor over a time range, per monitor and per series. Use this to see how often and how long a monitor has been alerting, unlike list_monitor_statuses which only returns the current state.
+
+Transitions are derived from the monitor's alert events: each series starts firing on an event of type alert_firing and stops on an event of type alert_resolved. Events of other types are ignored. A series first seen resolving is treated as firing since the start of the range, and a series still firing at the end has an ongoing interval.
+
+Response fields:
+- transitions, alerts, time_in_alert_secs, percent_in_alert: Totals for the monitor; time in alert counts overlapping series once
+- flapping_score: Fraction of alerts that resolved within flap_window_secs, from 0 (stable) to 1 (every alert flapped)
+- histogram: Number of alert events per time bucket
+- series: Per series, the labels, the same statistics, firing intervals and optionally the transitions, ordered by number of transitions`),
+				mcp.WithString("monitor_slug",
+					mcp.Description("Slug of the monitor."),
+					mcp.Required(),
+				),
+				params.WithTimeRange(),
+				mcp.WithString("event_query",
+					mcp.Description(`Event query selecting the monitor's alert events. Defaults to category = "alerts" AND labels.monitor_slug = "<monitor_slug>".`),
+				),
+				mcp.WithNumber("flap_window_secs",
+					mcp.Description("Alerts that resolve within this many seconds count as flapping. Default is 900."),
+				),
+				mcp.WithBoolean("include_transitions",
+					mcp.Description("Whether to include every transition of each series. Default is false."),
+				),
+				mcp.WithNumber("max_series",
+					mcp.Description("Maximum number of series to list. Default is 50."),
+				),
+			),
+			Handler: t.getMonitorHistory,
+		},
+		{
+			Metadata: tools.NewMetadata("list_noisy_monitors",
+				mcp.WithDescription(`Ranks the monitors of teams or collections by how often they changed state. Use this for alert hygiene reviews to find monitors that alert too often or flap.
+
+Alert events of the monitors in scope over the lookback are grouped by monitor and series with the same semantics as get_monitor_history. Monitors without any alert events are not listed.
+
+Response fields:
+- monitors_in_scope: Number of monitors owned by the given teams and collections
+- monitors_with_alerts: Number of those monitors with at least one alert event
+- monitors: Per monitor, the number of alerting series, transitions, alerts, time in alert and flapping score, ordered by transitions`),
+				params.WithStringArray("team_slugs",
+					mcp.Description("Teams whose monitors are ranked."),
+				),
+				params.WithStringArray("collection_slugs",
+					mcp.Description("Collections whose monitors are ranked."),
+				),
+				mcp.WithNumber("lookback_secs",
+					mcp.Description("How far back to look, in seconds. Default is 604800 (7 days)."),
+				),
+				mcp.WithNumber("flap_window_secs",
+					mcp.Description("Alerts that resolve within this many seconds count as flapping. Default is 900."),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description("Maximum number of monitors to return. Default is 20."),
+				),
+			),
+			Handler: t.listNoisyMonitors,
+		},
 	}
 }
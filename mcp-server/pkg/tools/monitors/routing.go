@@ -0,0 +1,423 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/collection"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/monitor"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notification_policy"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notifier"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/service"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+const (
+	severityCritical = "critical"
+	severityWarn     = "warn"
+
+	routeDefaults = "defaults"
+)
+
+// RoutingSimulation is the result of routing an alert through notification policies.
+type RoutingSimulation struct {
+	Severity    string            `json:"severity"`
+	AlertLabels map[string]string `json:"alert_labels"`
+	Chain       []*ChainLink      `json:"chain"`
+	Routes      []*PolicyRoute    `json:"routes"`
+	Warnings    []string          `json:"warnings,omitempty"`
+}
+
+// ChainLink is one step taken while resolving the notification policy.
+type ChainLink struct {
+	EntityType             string `json:"entity_type"`
+	Slug                   string `json:"slug"`
+	Name                   string `json:"name,omitempty"`
+	NotificationPolicySlug string `json:"notification_policy_slug,omitempty"`
+}
+
+// PolicyRoute is how a notification policy routes the alert.
+type PolicyRoute struct {
+	NotificationPolicySlug string            `json:"notification_policy_slug"`
+	NotificationPolicyName string            `json:"notification_policy_name,omitempty"`
+	MatchedRoute           string            `json:"matched_route"`
+	Overrides              []*OverrideMatch  `json:"overrides,omitempty"`
+	Notifiers              []*RoutedNotifier `json:"notifiers"`
+	RepeatIntervalSecs     int32             `json:"repeat_interval_secs,omitempty"`
+	GroupByLabels          []string          `json:"group_by_labels,omitempty"`
+	notifierList           *models.RoutesNotifierList
+}
+
+// OverrideMatch describes whether a policy override matched the alert labels.
+type OverrideMatch struct {
+	Index             int      `json:"index"`
+	Matchers          []string `json:"matchers"`
+	Matched           bool     `json:"matched"`
+	HasSeverityRoute  bool     `json:"has_severity_route"`
+	UnmatchedMatchers []string `json:"unmatched_matchers,omitempty"`
+}
+
+// RoutedNotifier is a notifier an alert is sent to.
+type RoutedNotifier struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Discard bool   `json:"discard,omitempty"`
+}
+
+func (t *Tools) simulateNotificationRouting(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	severity, err := params.String(request, "severity", true, "")
+	if err != nil {
+		return nil, err
+	}
+	if severity != severityCritical && severity != severityWarn {
+		return nil, fmt.Errorf("severity must be one of %q or %q", severityCritical, severityWarn)
+	}
+	monitorSlug, err := params.String(request, "monitor_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	collectionSlug, err := params.String(request, "collection_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	serviceSlug, err := params.String(request, "service_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	teamSlug, err := params.String(request, "team_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	policySlug, err := params.String(request, "notification_policy_slug", false, "")
+	if err != nil {
+		return nil, err
+	}
+	lbls, err := params.Object[map[string]string](request, "labels", false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &RoutingSimulation{
+		Severity:    severity,
+		AlertLabels: make(map[string]string),
+	}
+
+	var policySlugs []string
+	switch {
+	case policySlug != "":
+		policySlugs = []string{policySlug}
+	case monitorSlug != "":
+		resp, err := t.configAPI.Monitor.ReadMonitor(&monitor.ReadMonitorParams{
+			Context: ctx,
+			Slug:    monitorSlug,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read monitor %s: %s", monitorSlug, err)
+		}
+		m := resp.Payload.Monitor
+		for k, v := range m.Labels {
+			sim.AlertLabels[k] = v
+		}
+		chain, err := t.resolveMonitorPolicy(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		sim.Chain = append(sim.Chain, chain...)
+		if slug := chain[len(chain)-1].NotificationPolicySlug; slug != "" {
+			policySlugs = []string{slug}
+		}
+	case collectionSlug != "" || serviceSlug != "":
+		link, err := t.resolveCollectionPolicy(ctx, collectionSlug, serviceSlug)
+		if err != nil {
+			return nil, err
+		}
+		sim.Chain = append(sim.Chain, link)
+		if link.NotificationPolicySlug != "" {
+			policySlugs = []string{link.NotificationPolicySlug}
+		}
+	case teamSlug != "":
+		policies, err := configlist.NotificationPolicies(ctx, t.configAPI, configlist.Filter{TeamSlugs: []string{teamSlug}})
+		if err != nil {
+			return nil, err
+		}
+		sim.Chain = append(sim.Chain, &ChainLink{EntityType: "team", Slug: teamSlug})
+		for _, p := range policies {
+			policySlugs = append(policySlugs, p.Slug)
+		}
+		if len(policySlugs) > 1 {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf(
+				"team %s owns %d notification policies; routing is shown for each of them", teamSlug, len(policySlugs)))
+		}
+	default:
+		return nil, fmt.Errorf("one of monitor_slug, collection_slug, service_slug, team_slug or notification_policy_slug must be provided")
+	}
+
+	// Labels provided by the caller, such as series labels, take precedence over monitor labels.
+	for k, v := range lbls {
+		sim.AlertLabels[k] = v
+	}
+
+	if len(policySlugs) == 0 {
+		sim.Warnings = append(sim.Warnings, "no notification policy is configured, so no notifications are sent")
+	}
+	notifiers := make(map[string]*RoutedNotifier)
+	for _, slug := range policySlugs {
+		resp, err := t.configAPI.NotificationPolicy.ReadNotificationPolicy(&notification_policy.ReadNotificationPolicyParams{
+			Context: ctx,
+			Slug:    slug,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notification policy %s: %s", slug, err)
+		}
+		route, err := routeAlert(resp.Payload.NotificationPolicy, sim.AlertLabels, severity)
+		if err != nil {
+			return nil, err
+		}
+		if route.notifierList != nil {
+			for _, notifierSlug := range route.notifierList.NotifierSlugs {
+				n, ok := notifiers[notifierSlug]
+				if !ok {
+					n = t.describeNotifier(ctx, notifierSlug)
+					notifiers[notifierSlug] = n
+				}
+				route.Notifiers = append(route.Notifiers, n)
+			}
+		}
+		if len(route.Notifiers) == 0 {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf(
+				"notification policy %s has no %s notifiers for these labels", slug, severity))
+		}
+		sim.Routes = append(sim.Routes, route)
+	}
+
+	return &tools.Result{
+		JSONContent: sim,
+	}, nil
+}
+
+// resolveMonitorPolicy returns the chain of entities walked to find the notification policy of
+// a monitor: the monitor itself, then its collection or service if the monitor has no policy.
+// The last link holds the resolved policy slug, if any.
+func (t *Tools) resolveMonitorPolicy(ctx context.Context, m *models.Configv1Monitor) ([]*ChainLink, error) {
+	chain := []*ChainLink{{
+		EntityType:             "monitor",
+		Slug:                   m.Slug,
+		Name:                   m.Name,
+		NotificationPolicySlug: m.NotificationPolicySlug,
+	}}
+	if m.NotificationPolicySlug != "" {
+		return chain, nil
+	}
+	collectionType := models.Configv1CollectionReferenceTypeSIMPLE
+	collectionSlug, serviceSlug := m.CollectionSlug, ""
+	if m.Collection != nil {
+		collectionSlug = m.Collection.Slug
+		collectionType = m.Collection.Type
+	}
+	if collectionType == models.Configv1CollectionReferenceTypeSERVICE {
+		serviceSlug, collectionSlug = collectionSlug, ""
+	}
+	link, err := t.resolveCollectionPolicy(ctx, collectionSlug, serviceSlug)
+	if err != nil {
+		return nil, err
+	}
+	if link != nil {
+		chain = append(chain, link)
+	}
+	return chain, nil
+}
+
+// resolveCollectionPolicy reads the collection or service that owns a monitor.
+func (t *Tools) resolveCollectionPolicy(ctx context.Context, collectionSlug, serviceSlug string) (*ChainLink, error) {
+	switch {
+	case serviceSlug != "":
+		resp, err := t.configAPI.Service.ReadService(&service.ReadServiceParams{
+			Context: ctx,
+			Slug:    serviceSlug,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service %s: %s", serviceSlug, err)
+		}
+		s := resp.Payload.Service
+		return &ChainLink{
+			EntityType:             "service",
+			Slug:                   s.Slug,
+			Name:                   s.Name,
+			NotificationPolicySlug: s.NotificationPolicySlug,
+		}, nil
+	case collectionSlug != "":
+		resp, err := t.configAPI.Collection.ReadCollection(&collection.ReadCollectionParams{
+			Context: ctx,
+			Slug:    collectionSlug,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collection %s: %s", collectionSlug, err)
+		}
+		c := resp.Payload.Collection
+		return &ChainLink{
+			EntityType:             "collection",
+			Slug:                   c.Slug,
+			Name:                   c.Name,
+			NotificationPolicySlug: c.NotificationPolicySlug,
+		}, nil
+	}
+	return nil, nil
+}
+
+// describeNotifier returns the name and type of a notifier. Failures are logged rather than
+// returned since the slug alone is still useful.
+func (t *Tools) describeNotifier(ctx context.Context, slug string) *RoutedNotifier {
+	n := &RoutedNotifier{Slug: slug}
+	resp, err := t.configAPI.Notifier.ReadNotifier(&notifier.ReadNotifierParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		t.logger.Warn("failed to read notifier", zap.String("slug", slug), zap.Error(err))
+		return n
+	}
+	if resp.Payload.Notifier == nil {
+		return n
+	}
+	n.Name = resp.Payload.Notifier.Name
+	n.Type = notifierType(resp.Payload.Notifier)
+	n.Discard = resp.Payload.Notifier.Discard
+	return n
+}
+
+func notifierType(n *models.Configv1Notifier) string {
+	switch {
+	case n.Email != nil:
+		return "email"
+	case n.OpsGenie != nil:
+		return "opsgenie"
+	case n.Pagerduty != nil:
+		return "pagerduty"
+	case n.Slack != nil:
+		return "slack"
+	case n.VictorOps != nil:
+		return "victorops"
+	case n.Webhook != nil:
+		return "webhook"
+	case n.Discard:
+		return "discard"
+	}
+	return ""
+}
+
+// routeAlert evaluates the policy routes for the alert labels and severity. The first override
+// whose matchers all match and that has notifiers for the severity is used, otherwise the
+// defaults are used.
+func routeAlert(policy *models.Configv1NotificationPolicy, lbls map[string]string, severity string) (*PolicyRoute, error) {
+	route := &PolicyRoute{
+		NotificationPolicySlug: policy.Slug,
+		NotificationPolicyName: policy.Name,
+	}
+	if policy.Routes == nil {
+		return route, nil
+	}
+	for i, o := range policy.Routes.Overrides {
+		if o == nil {
+			continue
+		}
+		match := &OverrideMatch{
+			Index:            i,
+			HasSeverityRoute: severityNotifiers(o.Notifiers, severity) != nil,
+		}
+		for _, m := range o.AlertLabelMatchers {
+			s := matcherString(m)
+			match.Matchers = append(match.Matchers, s)
+			ok, err := matchesLabel(m, lbls)
+			if err != nil {
+				return nil, fmt.Errorf("notification policy %s override %d: %w", policy.Slug, i, err)
+			}
+			if !ok {
+				match.UnmatchedMatchers = append(match.UnmatchedMatchers, s)
+			}
+		}
+		match.Matched = len(match.UnmatchedMatchers) == 0
+		route.Overrides = append(route.Overrides, match)
+		if match.Matched && match.HasSeverityRoute && route.notifierList == nil {
+			route.MatchedRoute = fmt.Sprintf("overrides[%d]", i)
+			route.notifierList = severityNotifiers(o.Notifiers, severity)
+		}
+	}
+	if route.notifierList == nil {
+		if list := severityNotifiers(policy.Routes.Defaults, severity); list != nil {
+			route.MatchedRoute = routeDefaults
+			route.notifierList = list
+		}
+	}
+	if route.notifierList != nil {
+		route.RepeatIntervalSecs = route.notifierList.RepeatIntervalSecs
+		if route.notifierList.GroupBy != nil {
+			route.GroupByLabels = route.notifierList.GroupBy.LabelNames
+		}
+	}
+	return route, nil
+}
+
+func severityNotifiers(n *models.RoutesSeverityNotifiers, severity string) *models.RoutesNotifierList {
+	if n == nil {
+		return nil
+	}
+	var list *models.RoutesNotifierList
+	switch severity {
+	case severityCritical:
+		list = n.Critical
+	case severityWarn:
+		list = n.Warn
+	}
+	if list == nil || len(list.NotifierSlugs) == 0 {
+		return nil
+	}
+	return list
+}
+
+// matchesLabel evaluates a label matcher. Regular expressions must match the whole value.
+func matchesLabel(m *models.Configv1LabelMatcher, lbls map[string]string) (bool, error) {
+	if m == nil {
+		return true, nil
+	}
+	v := lbls[m.Name]
+	if m.Type == models.Configv1LabelMatcherMatcherTypeREGEX {
+		re, err := regexp.Compile("^(?:" + m.Value + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid regex matcher %s: %w", matcherString(m), err)
+		}
+		return re.MatchString(v), nil
+	}
+	return v == m.Value, nil
+}
+
+func matcherString(m *models.Configv1LabelMatcher) string {
+	if m == nil {
+		return ""
+	}
+	if m.Type == models.Configv1LabelMatcherMatcherTypeREGEX {
+		return fmt.Sprintf("%s=~%q", m.Name, m.Value)
+	}
+	return fmt.Sprintf("%s=%q", m.Name, m.Value)
+}
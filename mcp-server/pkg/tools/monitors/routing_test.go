@@ -0,0 +1,123 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestRouteAlert(t *testing.T) {
+	policy := &models.Configv1NotificationPolicy{
+		Slug: "platform",
+		Routes: &models.NotificationPolicyRoutes{
+			Defaults: &models.RoutesSeverityNotifiers{
+				Critical: &models.RoutesNotifierList{NotifierSlugs: []string{"pagerduty-platform"}, RepeatIntervalSecs: 3600},
+				Warn:     &models.RoutesNotifierList{NotifierSlugs: []string{"slack-platform"}},
+			},
+			Overrides: []*models.NotificationPolicyRoutesOverride{
+				{
+					AlertLabelMatchers: []*models.Configv1LabelMatcher{
+						{Name: "env", Type: models.Configv1LabelMatcherMatcherTypeEXACT, Value: "dev"},
+					},
+					Notifiers: &models.RoutesSeverityNotifiers{
+						Critical: &models.RoutesNotifierList{NotifierSlugs: []string{"slack-dev"}},
+					},
+				},
+				{
+					AlertLabelMatchers: []*models.Configv1LabelMatcher{
+						{Name: "service", Type: models.Configv1LabelMatcherMatcherTypeREGEX, Value: "pay.*"},
+					},
+					Notifiers: &models.RoutesSeverityNotifiers{
+						Critical: &models.RoutesNotifierList{
+							NotifierSlugs:      []string{"pagerduty-payments"},
+							RepeatIntervalSecs: 600,
+							GroupBy:            &models.NotificationPolicyRoutesGroupBy{LabelNames: []string{"service"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		labels        map[string]string
+		severity      string
+		wantRoute     string
+		wantNotifiers []string
+		wantRepeat    int32
+		wantMatched   []bool
+	}{
+		{
+			name:          "defaults when no override matches",
+			labels:        map[string]string{"env": "prod", "service": "api"},
+			severity:      severityCritical,
+			wantRoute:     routeDefaults,
+			wantNotifiers: []string{"pagerduty-platform"},
+			wantRepeat:    3600,
+			wantMatched:   []bool{false, false},
+		},
+		{
+			name:          "first matching override wins",
+			labels:        map[string]string{"env": "dev", "service": "payments"},
+			severity:      severityCritical,
+			wantRoute:     "overrides[0]",
+			wantNotifiers: []string{"slack-dev"},
+			wantMatched:   []bool{true, true},
+		},
+		{
+			name:          "regex matcher must match the whole value",
+			labels:        map[string]string{"env": "prod", "service": "payments"},
+			severity:      severityCritical,
+			wantRoute:     "overrides[1]",
+			wantNotifiers: []string{"pagerduty-payments"},
+			wantRepeat:    600,
+			wantMatched:   []bool{false, true},
+		},
+		{
+			name:          "override without severity route falls back to defaults",
+			labels:        map[string]string{"env": "dev"},
+			severity:      severityWarn,
+			wantRoute:     routeDefaults,
+			wantNotifiers: []string{"slack-platform"},
+			wantMatched:   []bool{true, false},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			route, err := routeAlert(policy, tc.labels, tc.severity)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRoute, route.MatchedRoute)
+			require.Equal(t, tc.wantNotifiers, route.notifierList.NotifierSlugs)
+			require.Equal(t, tc.wantRepeat, route.RepeatIntervalSecs)
+			var matched []bool
+			for _, o := range route.Overrides {
+				matched = append(matched, o.Matched)
+			}
+			require.Equal(t, tc.wantMatched, matched)
+		})
+	}
+}
+
+func TestRouteAlertNoRoutes(t *testing.T) {
+	route, err := routeAlert(&models.Configv1NotificationPolicy{Slug: "empty"}, nil, severityCritical)
+	require.NoError(t, err)
+	require.Empty(t, route.MatchedRoute)
+	require.Nil(t, route.notifierList)
+}
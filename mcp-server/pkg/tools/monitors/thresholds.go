@@ -0,0 +1,451 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+)
+
+const (
+	directionAbove = "above"
+	directionBelow = "below"
+
+	defaultThresholdLookback = 7 * 24 * time.Hour
+	// targetThresholdPoints is the number of points per series queried when no step is given.
+	targetThresholdPoints = 2000
+
+	// seasonalWarnMADs and seasonalCriticalMADs are how many median absolute deviations from
+	// the seasonal median the seasonal thresholds are placed at.
+	seasonalWarnMADs     = 3
+	seasonalCriticalMADs = 5
+
+	// maxListedSeriesBaselines bounds the number of series baselines in the response.
+	maxListedSeriesBaselines = 20
+)
+
+// ThresholdRecommendation is the result of recommending monitor thresholds.
+type ThresholdRecommendation struct {
+	Query     string    `json:"query"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	StepSecs  int       `json:"step_secs"`
+	Direction string    `json:"direction"`
+	Series    int       `json:"series"`
+	// Baseline aggregates the baselines of all series, see aggregateBaselines.
+	Baseline        *Baseline                       `json:"baseline"`
+	SeriesBaselines []*SeriesBaseline               `json:"series_baselines"`
+	Options         []*ThresholdOption              `json:"options"`
+	Recommended     string                          `json:"recommended"`
+	Conditions      *models.MonitorSeriesConditions `json:"series_conditions"`
+	Warnings        []string                        `json:"warnings,omitempty"`
+}
+
+// Baseline holds the statistics of the values of a series over the lookback.
+type Baseline struct {
+	Min    float64 `json:"min"`
+	P01    float64 `json:"p0_1"`
+	P1     float64 `json:"p1"`
+	P5     float64 `json:"p5"`
+	P50    float64 `json:"p50"`
+	P95    float64 `json:"p95"`
+	P99    float64 `json:"p99"`
+	P999   float64 `json:"p99_9"`
+	Max    float64 `json:"max"`
+	Points int     `json:"points"`
+	// Seasonality is the bucket size used for the seasonal baseline, either hour_of_day or hour_of_week.
+	Seasonality string `json:"seasonality,omitempty"`
+	// SeasonalWarn and SeasonalCritical are the seasonal thresholds, if there is enough data.
+	SeasonalWarn     *float64 `json:"seasonal_warn,omitempty"`
+	SeasonalCritical *float64 `json:"seasonal_critical,omitempty"`
+}
+
+// SeriesBaseline is the baseline of a single series.
+type SeriesBaseline struct {
+	Labels   map[string]string `json:"labels"`
+	Baseline *Baseline         `json:"baseline"`
+}
+
+// ThresholdOption is a candidate pair of warn and critical thresholds with how often they
+// would have fired over the lookback.
+type ThresholdOption struct {
+	Name                  string  `json:"name"`
+	Description           string  `json:"description"`
+	Warn                  float64 `json:"warn"`
+	Critical              float64 `json:"critical"`
+	WarnAlerts            int     `json:"warn_alerts"`
+	CriticalAlerts        int     `json:"critical_alerts"`
+	WarnAlertsPerWeek     float64 `json:"warn_alerts_per_week"`
+	CriticalAlertsPerWeek float64 `json:"critical_alerts_per_week"`
+	CriticalFiringPercent float64 `json:"critical_firing_percentage"`
+}
+
+func (t *Tools) recommendMonitorThresholds(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	query, err := params.String(request, "query", true, "")
+	if err != nil {
+		return nil, err
+	}
+	lookbackSecs, err := params.Int(request, "lookback_secs", false, int(defaultThresholdLookback.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	direction, err := params.String(request, "direction", false, directionAbove)
+	if err != nil {
+		return nil, err
+	}
+	if direction != directionAbove && direction != directionBelow {
+		return nil, fmt.Errorf("direction must be one of %q or %q", directionAbove, directionBelow)
+	}
+	sustainSecs, err := params.Int(request, "sustain_secs", false, 300)
+	if err != nil {
+		return nil, err
+	}
+	maxAlertsPerWeek, err := params.Float(request, "max_alerts_per_week", false, 1)
+	if err != nil {
+		return nil, err
+	}
+	step, err := params.Int(request, "step_seconds", false, 0)
+	if err != nil {
+		return nil, err
+	}
+	if lookbackSecs <= 0 {
+		return nil, fmt.Errorf("lookback_secs must be positive")
+	}
+	end := time.Now()
+	lookback := time.Duration(lookbackSecs) * time.Second
+	if step <= 0 {
+		step = int(math.Max(defaultIntervalSecs, math.Ceil(lookback.Seconds()/targetThresholdPoints)))
+	}
+	timeRange := &params.TimeRange{Start: end.Add(-lookback), End: end}
+
+	// Fetch the data once; each option is backtested against the same matrix.
+	matrix, err := t.queryRange(ctx, query, timeRange, step)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := recommendThresholds(matrix, timeRange.Start, timeRange.End, time.Duration(step)*time.Second,
+		direction, int32(sustainSecs), maxAlertsPerWeek)
+	if err != nil {
+		return nil, err
+	}
+	rec.Query = query
+	rec.StepSecs = step
+
+	return &tools.Result{
+		JSONContent: rec,
+		ChronosphereLink: t.linkBuilder.MetricExplorer().
+			WithQuery(query).
+			WithTimeRange(timeRange.Start, timeRange.End).
+			String(),
+	}, nil
+}
+
+// recommendThresholds computes percentile and seasonal baselines of the matrix, backtests a
+// threshold option for each, and recommends the most sensitive option whose critical alerts
+// stay within maxAlertsPerWeek.
+func recommendThresholds(
+	matrix model.Matrix,
+	start, end time.Time,
+	step time.Duration,
+	direction string,
+	sustainSecs int32,
+	maxAlertsPerWeek float64,
+) (*ThresholdRecommendation, error) {
+	rec := &ThresholdRecommendation{
+		Start:     start,
+		End:       end,
+		Direction: direction,
+		Series:    len(matrix),
+	}
+	for _, series := range matrix {
+		b, ok := seriesBaseline(series, end.Sub(start), direction)
+		if !ok {
+			continue
+		}
+		lbls := make(map[string]string, len(series.Metric))
+		for k, v := range series.Metric {
+			lbls[string(k)] = string(v)
+		}
+		rec.SeriesBaselines = append(rec.SeriesBaselines, &SeriesBaseline{Labels: lbls, Baseline: b})
+	}
+	if len(rec.SeriesBaselines) == 0 {
+		return nil, fmt.Errorf("query returned no data over the lookback")
+	}
+	rec.Baseline = aggregateBaselines(rec.SeriesBaselines, direction)
+
+	op := models.ConditionOpGT
+	if direction == directionBelow {
+		op = models.ConditionOpLT
+	}
+	b := rec.Baseline
+	if direction == directionAbove {
+		rec.Options = append(rec.Options,
+			&ThresholdOption{Name: "p95_p99", Description: "warn at the 95th and critical at the 99th percentile", Warn: b.P95, Critical: b.P99},
+			&ThresholdOption{Name: "p99_p99_9", Description: "warn at the 99th and critical at the 99.9th percentile", Warn: b.P99, Critical: b.P999},
+		)
+		// 20% above a maximum of 0 is still 0, which would alert on any positive value.
+		if b.Max != 0 {
+			rec.Options = append(rec.Options,
+				&ThresholdOption{Name: "max", Description: "warn at the maximum and critical 20% above it", Warn: b.Max, Critical: b.Max + 0.2*math.Abs(b.Max)})
+		} else {
+			rec.Warnings = append(rec.Warnings, "the max option is left out since the maximum is 0")
+		}
+	} else {
+		rec.Options = append(rec.Options,
+			&ThresholdOption{Name: "p5_p1", Description: "warn at the 5th and critical at the 1st percentile", Warn: b.P5, Critical: b.P1},
+			&ThresholdOption{Name: "p1_p0_1", Description: "warn at the 1st and critical at the 0.1th percentile", Warn: b.P1, Critical: b.P01},
+		)
+		if b.Min != 0 {
+			rec.Options = append(rec.Options,
+				&ThresholdOption{Name: "min", Description: "warn at the minimum and critical 20% below it", Warn: b.Min, Critical: b.Min - 0.2*math.Abs(b.Min)})
+		} else {
+			rec.Warnings = append(rec.Warnings, "the min option is left out since the minimum is 0")
+		}
+	}
+
+	if b.SeasonalWarn != nil && b.SeasonalCritical != nil {
+		rec.Options = append(rec.Options, &ThresholdOption{
+			Name: "seasonal",
+			Description: fmt.Sprintf("warn and critical at %d and %d median absolute deviations from the %s median, at the most extreme bucket",
+				seasonalWarnMADs, seasonalCriticalMADs, b.Seasonality),
+			Warn:     *b.SeasonalWarn,
+			Critical: *b.SeasonalCritical,
+		})
+	} else {
+		rec.Warnings = append(rec.Warnings, "not enough data for a seasonal baseline")
+	}
+
+	// Series are listed most extreme first, since they constrain the thresholds.
+	sort.SliceStable(rec.SeriesBaselines, func(i, j int) bool {
+		if direction == directionAbove {
+			return rec.SeriesBaselines[i].Baseline.P99 > rec.SeriesBaselines[j].Baseline.P99
+		}
+		return rec.SeriesBaselines[i].Baseline.P1 < rec.SeriesBaselines[j].Baseline.P1
+	})
+	if len(rec.SeriesBaselines) > maxListedSeriesBaselines {
+		rec.Warnings = append(rec.Warnings, fmt.Sprintf(
+			"%d series have a baseline; only the %d most extreme are listed", len(rec.SeriesBaselines), maxListedSeriesBaselines))
+		rec.SeriesBaselines = rec.SeriesBaselines[:maxListedSeriesBaselines]
+	}
+
+	weeks := end.Sub(start).Hours() / (24 * 7)
+	for _, o := range rec.Options {
+		result, err := evaluateBacktest(matrix, optionConditions(op, o, sustainSecs), start, end, step)
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := result.Summary[severityWarn]; ok {
+			o.WarnAlerts = s.Alerts
+		}
+		if s, ok := result.Summary[severityCritical]; ok {
+			o.CriticalAlerts = s.Alerts
+			o.CriticalFiringPercent = s.FiringPercentage
+		}
+		if weeks > 0 {
+			o.WarnAlertsPerWeek = float64(o.WarnAlerts) / weeks
+			o.CriticalAlertsPerWeek = float64(o.CriticalAlerts) / weeks
+		}
+	}
+
+	// Options are most sensitive first when sorted by critical threshold in the alerting direction.
+	sort.SliceStable(rec.Options, func(i, j int) bool {
+		if direction == directionAbove {
+			return rec.Options[i].Critical < rec.Options[j].Critical
+		}
+		return rec.Options[i].Critical > rec.Options[j].Critical
+	})
+	chosen := rec.Options[len(rec.Options)-1]
+	for _, o := range rec.Options {
+		if o.CriticalAlertsPerWeek <= maxAlertsPerWeek {
+			chosen = o
+			break
+		}
+	}
+	if chosen.CriticalAlertsPerWeek > maxAlertsPerWeek {
+		rec.Warnings = append(rec.Warnings, fmt.Sprintf(
+			"no option stays within %v critical alerts per week; the most conservative option is recommended", maxAlertsPerWeek))
+	}
+	rec.Recommended = chosen.Name
+	rec.Conditions = optionConditions(op, chosen, sustainSecs)
+	return rec, nil
+}
+
+// seriesBaseline returns the percentile and seasonal baseline of a series. It returns false if
+// the series has no finite values.
+func seriesBaseline(series *model.SampleStream, lookback time.Duration, direction string) (*Baseline, bool) {
+	var values []float64
+	for _, p := range series.Values {
+		v := float64(p.Value)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+	sort.Float64s(values)
+	b := &Baseline{
+		Min:    values[0],
+		P01:    percentile(values, 0.001),
+		P1:     percentile(values, 0.01),
+		P5:     percentile(values, 0.05),
+		P50:    percentile(values, 0.5),
+		P95:    percentile(values, 0.95),
+		P99:    percentile(values, 0.99),
+		P999:   percentile(values, 0.999),
+		Max:    values[len(values)-1],
+		Points: len(values),
+	}
+	seasonality, warn, critical, ok := seasonalThresholds(series, lookback, direction)
+	b.Seasonality = seasonality
+	if ok {
+		b.SeasonalWarn, b.SeasonalCritical = &warn, &critical
+	}
+	return b, true
+}
+
+// aggregateBaselines combines per series baselines into the baseline of a single threshold
+// for all series. Each statistic is taken from the most extreme series in the alerting
+// direction, so that no series alerts more often than its own baseline allows. Points is the
+// total of all series.
+func aggregateBaselines(series []*SeriesBaseline, direction string) *Baseline {
+	extreme := math.Max
+	if direction == directionBelow {
+		extreme = math.Min
+	}
+	var agg *Baseline
+	for _, s := range series {
+		b := s.Baseline
+		if agg == nil {
+			c := *b
+			agg = &c
+			continue
+		}
+		agg.Min = extreme(agg.Min, b.Min)
+		agg.P01 = extreme(agg.P01, b.P01)
+		agg.P1 = extreme(agg.P1, b.P1)
+		agg.P5 = extreme(agg.P5, b.P5)
+		agg.P50 = extreme(agg.P50, b.P50)
+		agg.P95 = extreme(agg.P95, b.P95)
+		agg.P99 = extreme(agg.P99, b.P99)
+		agg.P999 = extreme(agg.P999, b.P999)
+		agg.Max = extreme(agg.Max, b.Max)
+		agg.Points += b.Points
+		agg.SeasonalWarn = extremePtr(extreme, agg.SeasonalWarn, b.SeasonalWarn)
+		agg.SeasonalCritical = extremePtr(extreme, agg.SeasonalCritical, b.SeasonalCritical)
+	}
+	return agg
+}
+
+// extremePtr applies extreme to two optional values.
+func extremePtr(extreme func(a, b float64) float64, a, b *float64) *float64 {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	v := extreme(*a, *b)
+	return &v
+}
+
+// seasonalThresholds buckets the values of a series by hour of day, or hour of week for
+// lookbacks of at least two weeks, and returns the most extreme median +/- k*MAD across buckets.
+func seasonalThresholds(series *model.SampleStream, lookback time.Duration, direction string) (string, float64, float64, bool) {
+	seasonality := "hour_of_day"
+	bucketOf := func(ts time.Time) int { return ts.UTC().Hour() }
+	if lookback >= 14*24*time.Hour {
+		seasonality = "hour_of_week"
+		bucketOf = func(ts time.Time) int { return int(ts.UTC().Weekday())*24 + ts.UTC().Hour() }
+	}
+	buckets := make(map[int][]float64)
+	for _, p := range series.Values {
+		v := float64(p.Value)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		b := bucketOf(p.Timestamp.Time())
+		buckets[b] = append(buckets[b], v)
+	}
+	var (
+		warn, critical float64
+		found          bool
+	)
+	sign := 1.0
+	if direction == directionBelow {
+		sign = -1
+	}
+	for _, values := range buckets {
+		if len(values) < 3 {
+			continue
+		}
+		sort.Float64s(values)
+		median := percentile(values, 0.5)
+		deviations := make([]float64, len(values))
+		for i, v := range values {
+			deviations[i] = math.Abs(v - median)
+		}
+		sort.Float64s(deviations)
+		mad := percentile(deviations, 0.5)
+		w := median + sign*seasonalWarnMADs*mad
+		c := median + sign*seasonalCriticalMADs*mad
+		if !found || sign*w > sign*warn {
+			warn = w
+		}
+		if !found || sign*c > sign*critical {
+			critical = c
+		}
+		found = true
+	}
+	return seasonality, warn, critical, found
+}
+
+func optionConditions(op models.ConditionOp, o *ThresholdOption, sustainSecs int32) *models.MonitorSeriesConditions {
+	return &models.MonitorSeriesConditions{
+		Defaults: &models.SeriesConditionsSeverityConditions{
+			Warn: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+				{Op: op, Value: o.Warn, SustainSecs: sustainSecs},
+			}},
+			Critical: &models.SeriesConditionsConditions{Conditions: []*models.MonitorCondition{
+				{Op: op, Value: o.Critical, SustainSecs: sustainSecs},
+			}},
+		},
+	}
+}
+
+// percentile returns the q-th quantile of sorted values using linear interpolation.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	require.Equal(t, 1.0, percentile(values, 0))
+	require.Equal(t, 3.0, percentile(values, 0.5))
+	require.Equal(t, 5.0, percentile(values, 1))
+	require.InDelta(t, 4.6, percentile(values, 0.9), 1e-9)
+}
+
+func TestRecommendThresholds(t *testing.T) {
+	// A week of hourly values cycling between 10 and 19 with two spikes.
+	var values []float64
+	for i := 0; i < 7*24; i++ {
+		values = append(values, float64(10+i%10))
+	}
+	values[50] = 100
+	values[120] = 100
+	matrix := model.Matrix{testSeries(model.Metric{"service": "api"}, values...)}
+	// testSeries spaces points a minute apart, so evaluate at the same resolution.
+	end := backtestStart.Add(time.Duration(len(values)-1) * time.Minute)
+
+	rec, err := recommendThresholds(matrix, backtestStart, end, time.Minute, directionAbove, 0, 1000)
+	require.NoError(t, err)
+	require.Equal(t, 10.0, rec.Baseline.Min)
+	require.Equal(t, 100.0, rec.Baseline.Max)
+	require.NotEmpty(t, rec.Options)
+
+	// Options are ordered from most to least sensitive.
+	for i := 1; i < len(rec.Options); i++ {
+		require.LessOrEqual(t, rec.Options[i-1].Critical, rec.Options[i].Critical)
+	}
+	// With a generous alert budget the most sensitive option is recommended.
+	require.Equal(t, rec.Options[0].Name, rec.Recommended)
+
+	critical := rec.Conditions.Defaults.Critical.Conditions[0]
+	require.Equal(t, models.ConditionOpGT, critical.Op)
+	require.Equal(t, rec.Options[0].Critical, critical.Value)
+
+	// The max option never fires.
+	for _, o := range rec.Options {
+		if o.Name == "max" {
+			require.Zero(t, o.CriticalAlerts)
+			require.Zero(t, o.WarnAlerts)
+		}
+	}
+
+	// With no alert budget an option that never fires is recommended.
+	rec, err = recommendThresholds(matrix, backtestStart, end, time.Minute, directionAbove, 0, 0)
+	require.NoError(t, err)
+	for _, o := range rec.Options {
+		if o.Name == rec.Recommended {
+			require.Zero(t, o.CriticalAlerts)
+		}
+	}
+}
+
+func TestRecommendThresholdsBelow(t *testing.T) {
+	values := []float64{50, 51, 52, 49, 48, 50, 5, 50, 51, 52}
+	matrix := model.Matrix{testSeries(model.Metric{}, values...)}
+	end := backtestStart.Add(time.Duration(len(values)-1) * time.Minute)
+
+	rec, err := recommendThresholds(matrix, backtestStart, end, time.Minute, directionBelow, 0, 1000)
+	require.NoError(t, err)
+	require.Equal(t, models.ConditionOpLT, rec.Conditions.Defaults.Warn.Conditions[0].Op)
+	for i := 1; i < len(rec.Options); i++ {
+		require.GreaterOrEqual(t, rec.Options[i-1].Critical, rec.Options[i].Critical)
+	}
+}
+
+func TestRecommendThresholdsNoData(t *testing.T) {
+	_, err := recommendThresholds(model.Matrix{}, backtestStart, backtestStart.Add(time.Hour), time.Minute, directionAbove, 0, 1)
+	require.ErrorContains(t, err, "no data")
+}
+
+func TestRecommendThresholdsPerSeries(t *testing.T) {
+	var quiet, loud []float64
+	for i := 0; i < 100; i++ {
+		quiet = append(quiet, float64(i%10))
+		loud = append(loud, float64(1000+i))
+	}
+	matrix := model.Matrix{
+		testSeries(model.Metric{"service": "quiet"}, quiet...),
+		testSeries(model.Metric{"service": "loud"}, loud...),
+	}
+	end := backtestStart.Add(99 * time.Minute)
+
+	rec, err := recommendThresholds(matrix, backtestStart, end, time.Minute, directionAbove, 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, rec.SeriesBaselines, 2)
+	require.Equal(t, "loud", rec.SeriesBaselines[0].Labels["service"])
+	require.Equal(t, "quiet", rec.SeriesBaselines[1].Labels["service"])
+	require.Equal(t, 9.0, rec.SeriesBaselines[1].Baseline.Max)
+
+	// The aggregate p99 is the loud series' own p99, not the p99 of both series pooled, which
+	// would be close to the loud series' maximum.
+	require.Equal(t, rec.SeriesBaselines[0].Baseline.P99, rec.Baseline.P99)
+	require.InDelta(t, 1098.01, rec.Baseline.P99, 1e-9)
+	require.Equal(t, 200, rec.Baseline.Points)
+}
+
+func TestRecommendThresholdsZeroMax(t *testing.T) {
+	values := []float64{0, -1, -2, 0, -1, -2, 0}
+	matrix := model.Matrix{testSeries(model.Metric{}, values...)}
+	end := backtestStart.Add(time.Duration(len(values)-1) * time.Minute)
+
+	rec, err := recommendThresholds(matrix, backtestStart, end, time.Minute, directionAbove, 0, 1000)
+	require.NoError(t, err)
+	for _, o := range rec.Options {
+		require.NotEqual(t, "max", o.Name)
+	}
+	require.Contains(t, rec.Warnings, "the max option is left out since the maximum is 0")
+}
@@ -0,0 +1,477 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configlist provides helpers which page through config API list endpoints
+// and return every matching entity.
+package configlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/bucket"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/classic_dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/collection"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/dataset"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/derived_label"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/derived_metric"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/drop_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/gcp_metrics_integration"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/grafana_dashboard"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_scale_action"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_scale_alert"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/mapping_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/monitor"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/muting_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notification_policy"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/notifier"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/recording_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/rollup_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/s_l_o"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/service_account"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/team"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_behavior"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_jaeger_remote_sampling_strategy"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_metrics_rule"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// maxPages guards against list endpoints that never stop returning page tokens.
+const maxPages = 1000
+
+// Filter restricts which entities are listed. Fields that do not apply to an entity are ignored.
+type Filter struct {
+	Slugs           []string
+	TeamSlugs       []string
+	CollectionSlugs []string
+	BucketSlugs     []string
+}
+
+// listAll calls fetch with successive page tokens until no next page token is returned.
+func listAll[T any](entity string, fetch func(pageToken *string) ([]T, *models.Configv1PageResult, error)) ([]T, error) {
+	var (
+		all   []T
+		token string
+	)
+	for i := 0; i < maxPages; i++ {
+		items, page, err := fetch(ptr.To(token))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %s", entity, err)
+		}
+		all = append(all, items...)
+		if page == nil || page.NextToken == "" {
+			return all, nil
+		}
+		token = page.NextToken
+	}
+	return nil, fmt.Errorf("failed to list %s: exceeded %d pages", entity, maxPages)
+}
+
+// Monitors returns all monitors matching the filter.
+func Monitors(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Monitor, error) {
+	return listAll("monitors", func(pageToken *string) ([]*models.Configv1Monitor, *models.Configv1PageResult, error) {
+		resp, err := api.Monitor.ListMonitors(&monitor.ListMonitorsParams{
+			Context:         ctx,
+			Slugs:           f.Slugs,
+			TeamSlugs:       f.TeamSlugs,
+			CollectionSlugs: f.CollectionSlugs,
+			BucketSlugs:     f.BucketSlugs,
+			PageToken:       pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Monitors, resp.Payload.Page, nil
+	})
+}
+
+// Dashboards returns all dashboards matching the filter, including their dashboard JSON.
+func Dashboards(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Dashboard, error) {
+	return listAll("dashboards", func(pageToken *string) ([]*models.Configv1Dashboard, *models.Configv1PageResult, error) {
+		resp, err := api.Dashboard.ListDashboards(&dashboard.ListDashboardsParams{
+			Context:              ctx,
+			Slugs:                f.Slugs,
+			CollectionSlugs:      f.CollectionSlugs,
+			IncludeDashboardJSON: ptr.To(true),
+			PageToken:            pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Dashboards, resp.Payload.Page, nil
+	})
+}
+
+// ClassicDashboards returns all classic dashboards matching the filter, including their
+// dashboard JSON.
+func ClassicDashboards(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1GrafanaDashboard, error) {
+	return listAll("classic dashboards", func(pageToken *string) ([]*models.Configv1GrafanaDashboard, *models.Configv1PageResult, error) {
+		resp, err := api.ClassicDashboard.ListClassicDashboards(&classic_dashboard.ListClassicDashboardsParams{
+			Context:              ctx,
+			Slugs:                f.Slugs,
+			CollectionSlugs:      f.CollectionSlugs,
+			BucketSlugs:          f.BucketSlugs,
+			IncludeDashboardJSON: ptr.To(true),
+			PageToken:            pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.ClassicDashboards, resp.Payload.Page, nil
+	})
+}
+
+// GrafanaDashboards returns all Grafana dashboards matching the filter, including their
+// dashboard JSON.
+func GrafanaDashboards(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1GrafanaDashboard, error) {
+	return listAll("grafana dashboards", func(pageToken *string) ([]*models.Configv1GrafanaDashboard, *models.Configv1PageResult, error) {
+		resp, err := api.GrafanaDashboard.ListGrafanaDashboards(&grafana_dashboard.ListGrafanaDashboardsParams{
+			Context:              ctx,
+			Slugs:                f.Slugs,
+			CollectionSlugs:      f.CollectionSlugs,
+			BucketSlugs:          f.BucketSlugs,
+			IncludeDashboardJSON: ptr.To(true),
+			PageToken:            pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.GrafanaDashboards, resp.Payload.Page, nil
+	})
+}
+
+// RecordingRules returns all recording rules matching the filter.
+func RecordingRules(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1RecordingRule, error) {
+	return listAll("recording rules", func(pageToken *string) ([]*models.Configv1RecordingRule, *models.Configv1PageResult, error) {
+		resp, err := api.RecordingRule.ListRecordingRules(&recording_rule.ListRecordingRulesParams{
+			Context:     ctx,
+			Slugs:       f.Slugs,
+			BucketSlugs: f.BucketSlugs,
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.RecordingRules, resp.Payload.Page, nil
+	})
+}
+
+// DropRules returns all drop rules matching the filter.
+func DropRules(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1DropRule, error) {
+	return listAll("drop rules", func(pageToken *string) ([]*models.Configv1DropRule, *models.Configv1PageResult, error) {
+		resp, err := api.DropRule.ListDropRules(&drop_rule.ListDropRulesParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.DropRules, resp.Payload.Page, nil
+	})
+}
+
+// MappingRules returns all mapping rules matching the filter.
+func MappingRules(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1MappingRule, error) {
+	return listAll("mapping rules", func(pageToken *string) ([]*models.Configv1MappingRule, *models.Configv1PageResult, error) {
+		resp, err := api.MappingRule.ListMappingRules(&mapping_rule.ListMappingRulesParams{
+			Context:     ctx,
+			Slugs:       f.Slugs,
+			BucketSlugs: f.BucketSlugs,
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.MappingRules, resp.Payload.Page, nil
+	})
+}
+
+// RollupRules returns all rollup rules matching the filter.
+func RollupRules(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1RollupRule, error) {
+	return listAll("rollup rules", func(pageToken *string) ([]*models.Configv1RollupRule, *models.Configv1PageResult, error) {
+		resp, err := api.RollupRule.ListRollupRules(&rollup_rule.ListRollupRulesParams{
+			Context:     ctx,
+			Slugs:       f.Slugs,
+			BucketSlugs: f.BucketSlugs,
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.RollupRules, resp.Payload.Page, nil
+	})
+}
+
+// NotificationPolicies returns all notification policies matching the filter.
+func NotificationPolicies(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1NotificationPolicy, error) {
+	return listAll("notification policies", func(pageToken *string) ([]*models.Configv1NotificationPolicy, *models.Configv1PageResult, error) {
+		resp, err := api.NotificationPolicy.ListNotificationPolicies(&notification_policy.ListNotificationPoliciesParams{
+			Context:     ctx,
+			Slugs:       f.Slugs,
+			TeamSlugs:   f.TeamSlugs,
+			BucketSlugs: f.BucketSlugs,
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.NotificationPolicies, resp.Payload.Page, nil
+	})
+}
+
+// Collections returns all collections matching the filter.
+func Collections(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Collection, error) {
+	return listAll("collections", func(pageToken *string) ([]*models.Configv1Collection, *models.Configv1PageResult, error) {
+		resp, err := api.Collection.ListCollections(&collection.ListCollectionsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			TeamSlugs: f.TeamSlugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Collections, resp.Payload.Page, nil
+	})
+}
+
+// SLOs returns all SLOs matching the filter.
+func SLOs(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1SLO, error) {
+	return listAll("SLOs", func(pageToken *string) ([]*models.Configv1SLO, *models.Configv1PageResult, error) {
+		resp, err := api.Slo.ListSLOs(&s_l_o.ListSLOsParams{
+			Context:         ctx,
+			Slugs:           f.Slugs,
+			CollectionSlugs: f.CollectionSlugs,
+			PageToken:       pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Slos, resp.Payload.Page, nil
+	})
+}
+
+// Buckets returns all buckets matching the filter.
+func Buckets(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Bucket, error) {
+	return listAll("buckets", func(pageToken *string) ([]*models.Configv1Bucket, *models.Configv1PageResult, error) {
+		resp, err := api.Bucket.ListBuckets(&bucket.ListBucketsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Buckets, resp.Payload.Page, nil
+	})
+}
+
+// Teams returns all teams matching the filter.
+func Teams(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Team, error) {
+	return listAll("teams", func(pageToken *string) ([]*models.Configv1Team, *models.Configv1PageResult, error) {
+		resp, err := api.Team.ListTeams(&team.ListTeamsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Teams, resp.Payload.Page, nil
+	})
+}
+
+// ServiceAccounts returns all service accounts matching the filter.
+func ServiceAccounts(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1ServiceAccount, error) {
+	return listAll("service accounts", func(pageToken *string) ([]*models.Configv1ServiceAccount, *models.Configv1PageResult, error) {
+		resp, err := api.ServiceAccount.ListServiceAccounts(&service_account.ListServiceAccountsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.ServiceAccounts, resp.Payload.Page, nil
+	})
+}
+
+// DerivedMetrics returns all derived metrics matching the filter.
+func DerivedMetrics(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1DerivedMetric, error) {
+	return listAll("derived metrics", func(pageToken *string) ([]*models.Configv1DerivedMetric, *models.Configv1PageResult, error) {
+		resp, err := api.DerivedMetric.ListDerivedMetrics(&derived_metric.ListDerivedMetricsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.DerivedMetrics, resp.Payload.Page, nil
+	})
+}
+
+// DerivedLabels returns all derived labels matching the filter.
+func DerivedLabels(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1DerivedLabel, error) {
+	return listAll("derived labels", func(pageToken *string) ([]*models.Configv1DerivedLabel, *models.Configv1PageResult, error) {
+		resp, err := api.DerivedLabel.ListDerivedLabels(&derived_label.ListDerivedLabelsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.DerivedLabels, resp.Payload.Page, nil
+	})
+}
+
+// Datasets returns all datasets matching the filter.
+func Datasets(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Dataset, error) {
+	return listAll("datasets", func(pageToken *string) ([]*models.Configv1Dataset, *models.Configv1PageResult, error) {
+		resp, err := api.Dataset.ListDatasets(&dataset.ListDatasetsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Datasets, resp.Payload.Page, nil
+	})
+}
+
+// LogScaleAlerts returns all LogScale alerts matching the filter.
+func LogScaleAlerts(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1LogScaleAlert, error) {
+	return listAll("log scale alerts", func(pageToken *string) ([]*models.Configv1LogScaleAlert, *models.Configv1PageResult, error) {
+		resp, err := api.LogScaleAlert.ListLogScaleAlerts(&log_scale_alert.ListLogScaleAlertsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.LogScaleAlerts, resp.Payload.Page, nil
+	})
+}
+
+// LogScaleActions returns all LogScale actions matching the filter.
+func LogScaleActions(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1LogScaleAction, error) {
+	return listAll("log scale actions", func(pageToken *string) ([]*models.Configv1LogScaleAction, *models.Configv1PageResult, error) {
+		resp, err := api.LogScaleAction.ListLogScaleActions(&log_scale_action.ListLogScaleActionsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.LogScaleActions, resp.Payload.Page, nil
+	})
+}
+
+// GcpMetricsIntegrations returns all GCP metrics integrations matching the filter.
+func GcpMetricsIntegrations(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1GcpMetricsIntegration, error) {
+	return listAll("gcp metrics integrations", func(pageToken *string) ([]*models.Configv1GcpMetricsIntegration, *models.Configv1PageResult, error) {
+		resp, err := api.GcpMetricsIntegration.ListGcpMetricsIntegrations(&gcp_metrics_integration.ListGcpMetricsIntegrationsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.GcpMetricsIntegrations, resp.Payload.Page, nil
+	})
+}
+
+// Notifiers returns all notifiers matching the filter.
+func Notifiers(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1Notifier, error) {
+	return listAll("notifiers", func(pageToken *string) ([]*models.Configv1Notifier, *models.Configv1PageResult, error) {
+		resp, err := api.Notifier.ListNotifiers(&notifier.ListNotifiersParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.Notifiers, resp.Payload.Page, nil
+	})
+}
+
+// MutingRules returns all muting rules matching the filter.
+func MutingRules(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1MutingRule, error) {
+	return listAll("muting rules", func(pageToken *string) ([]*models.Configv1MutingRule, *models.Configv1PageResult, error) {
+		resp, err := api.MutingRule.ListMutingRules(&muting_rule.ListMutingRulesParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.MutingRules, resp.Payload.Page, nil
+	})
+}
+
+// TraceBehaviors returns all trace behaviors matching the filter.
+func TraceBehaviors(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1TraceBehavior, error) {
+	return listAll("trace behaviors", func(pageToken *string) ([]*models.Configv1TraceBehavior, *models.Configv1PageResult, error) {
+		resp, err := api.TraceBehavior.ListTraceBehaviors(&trace_behavior.ListTraceBehaviorsParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.TraceBehaviors, resp.Payload.Page, nil
+	})
+}
+
+// TraceJaegerRemoteSamplingStrategies returns all Jaeger remote sampling strategies matching the filter.
+func TraceJaegerRemoteSamplingStrategies(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1TraceJaegerRemoteSamplingStrategy, error) {
+	return listAll("trace jaeger remote sampling strategies", func(pageToken *string) ([]*models.Configv1TraceJaegerRemoteSamplingStrategy, *models.Configv1PageResult, error) {
+		resp, err := api.TraceJaegerRemoteSamplingStrategy.ListTraceJaegerRemoteSamplingStrategies(&trace_jaeger_remote_sampling_strategy.ListTraceJaegerRemoteSamplingStrategiesParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.TraceJaegerRemoteSamplingStrategies, resp.Payload.Page, nil
+	})
+}
+
+// TraceMetricsRules returns all trace metrics rules matching the filter.
+func TraceMetricsRules(ctx context.Context, api *configv1.ConfigV1API, f Filter) ([]*models.Configv1TraceMetricsRule, error) {
+	return listAll("trace metrics rules", func(pageToken *string) ([]*models.Configv1TraceMetricsRule, *models.Configv1PageResult, error) {
+		resp, err := api.TraceMetricsRule.ListTraceMetricsRules(&trace_metrics_rule.ListTraceMetricsRulesParams{
+			Context:   ctx,
+			Slugs:     f.Slugs,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Payload.TraceMetricsRules, resp.Payload.Page, nil
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configowner resolves the collection, service or bucket owning a config entity.
+package configowner
+
+import (
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+// Kinds of entities owning config.
+const (
+	KindCollection = "collection"
+	KindService    = "service"
+	KindBucket     = "bucket"
+)
+
+// Owner is the collection, service or bucket owning a config entity.
+type Owner struct {
+	Kind string
+	Slug string
+}
+
+// String returns the owner as kind:slug, or an empty string if there is no owner.
+func (o Owner) String() string {
+	if o.Slug == "" {
+		return ""
+	}
+	return o.Kind + ":" + o.Slug
+}
+
+// Of returns the owner of an entity belonging to a collection, service or bucket. A collection
+// reference takes precedence over the legacy collection slug, which takes precedence over the
+// bucket. It returns the zero Owner if the entity has no owner.
+func Of(ref *models.Configv1CollectionReference, collectionSlug, bucketSlug string) Owner {
+	switch {
+	case ref != nil && ref.Slug != "":
+		if ref.Type == models.Configv1CollectionReferenceTypeSERVICE {
+			return Owner{Kind: KindService, Slug: ref.Slug}
+		}
+		return Owner{Kind: KindCollection, Slug: ref.Slug}
+	case collectionSlug != "":
+		return Owner{Kind: KindCollection, Slug: collectionSlug}
+	case bucketSlug != "":
+		return Owner{Kind: KindBucket, Slug: bucketSlug}
+	}
+	return Owner{}
+}
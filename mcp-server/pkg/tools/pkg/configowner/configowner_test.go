@@ -0,0 +1,67 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configowner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestOf(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ref            *models.Configv1CollectionReference
+		collectionSlug string
+		bucketSlug     string
+		want           Owner
+	}{
+		{
+			name: "collection reference",
+			ref:  &models.Configv1CollectionReference{Type: models.Configv1CollectionReferenceTypeSIMPLE, Slug: "api"},
+			want: Owner{Kind: KindCollection, Slug: "api"},
+		},
+		{
+			name:       "service reference",
+			ref:        &models.Configv1CollectionReference{Type: models.Configv1CollectionReferenceTypeSERVICE, Slug: "checkout"},
+			bucketSlug: "legacy",
+			want:       Owner{Kind: KindService, Slug: "checkout"},
+		},
+		{
+			name:           "collection slug",
+			collectionSlug: "api",
+			bucketSlug:     "legacy",
+			want:           Owner{Kind: KindCollection, Slug: "api"},
+		},
+		{
+			name:       "bucket",
+			bucketSlug: "legacy",
+			want:       Owner{Kind: KindBucket, Slug: "legacy"},
+		},
+		{
+			name: "no owner",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Of(tc.ref, tc.collectionSlug, tc.bucketSlug)
+			require.Equal(t, tc.want, got)
+		})
+	}
+	require.Equal(t, "service:checkout", Owner{Kind: KindService, Slug: "checkout"}.String())
+	require.Empty(t, Owner{}.String())
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kinds of parts of a Grafana dashboard that could not be fully converted.
+const (
+	IssuePanel    = "panel"
+	IssueQuery    = "query"
+	IssueVariable = "variable"
+	IssueUnit     = "unit"
+)
+
+const defaultDuration = "1h"
+
+// grafanaUnits maps Grafana units to their equivalent unit.
+var grafanaUnits = map[string]string{
+	"":             "",
+	"none":         "decimal",
+	"short":        "decimal",
+	"ns":           "nanoseconds",
+	"µs":           "microseconds",
+	"us":           "microseconds",
+	"ms":           "milliseconds",
+	"s":            "seconds",
+	"m":            "minutes",
+	"h":            "hours",
+	"d":            "days",
+	"dtdurationms": "milliseconds",
+	"dtdurations":  "seconds",
+	"percent":      "percent",
+	"percentunit":  "percent-decimal",
+	"bytes":        "bytes",
+	"decbytes":     "decbytes",
+	"Bps":          "bytes/sec",
+	"bps":          "bits/sec",
+	"reqps":        "requests/sec",
+	"ops":          "ops/sec",
+	"iops":         "ops/sec",
+	"rps":          "reads/sec",
+	"wps":          "writes/sec",
+	"pps":          "packets/sec",
+	"cps":          "counts/sec",
+}
+
+var (
+	// legacyVariableRe matches the deprecated [[var]] and [[var:format]] variable syntax.
+	legacyVariableRe = regexp.MustCompile(`\[\[(\w+)(?::\w+)?\]\]`)
+	labelValuesRe    = regexp.MustCompile(`^\s*label_values\(\s*(?:(.+?)\s*,\s*)?(\w+)\s*\)\s*$`)
+	labelNamesRe     = regexp.MustCompile(`^\s*label_names\(\s*\)\s*$`)
+	queryResultRe    = regexp.MustCompile(`^\s*query_result\((.+)\)\s*$`)
+)
+
+// ConversionIssue is a part of a Grafana dashboard that could not be fully converted.
+type ConversionIssue struct {
+	Kind   string `json:"kind"`
+	Panel  string `json:"panel,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ConvertGrafana converts a Grafana dashboard, returning the parts that could not be fully
+// converted. Panels and variables without an equivalent are left out; queries without PromQL
+// are left out of their panel.
+func ConvertGrafana(g *GrafanaDashboard) (*Dashboard, []*ConversionIssue) {
+	c := &converter{keys: make(map[string]bool)}
+	d := &Dashboard{
+		Kind:     KindDashboard,
+		Metadata: Metadata{Name: g.Title},
+		Spec: DashboardSpec{
+			Display:  &Display{Name: g.Title, Description: g.Description},
+			Duration: grafanaDuration(g.Time),
+			Panels:   make(map[string]*Panel),
+		},
+	}
+	for _, v := range g.Templating.List {
+		if v == nil {
+			continue
+		}
+		if converted := c.variable(v); converted != nil {
+			d.Spec.Variables = append(d.Spec.Variables, converted)
+		}
+	}
+	for _, group := range g.Groups() {
+		layout := &Layout{Kind: KindGrid}
+		if group.Title != "" {
+			layout.Spec.Display = &LayoutDisplay{Title: group.Title, Collapse: &Collapse{Open: !group.Collapsed}}
+		}
+		minY := -1
+		for _, p := range group.Panels {
+			if p.GridPos != nil && (minY < 0 || p.GridPos.Y < minY) {
+				minY = p.GridPos.Y
+			}
+		}
+		for i, p := range group.Panels {
+			panel := c.panel(p)
+			if panel == nil {
+				continue
+			}
+			key := c.key(p, i)
+			d.Spec.Panels[key] = panel
+			item := &LayoutItem{X: 0, Y: i * 8, Width: 12, Height: 8, Content: PanelReference(key)}
+			if p.GridPos != nil {
+				item.X, item.Y, item.Width, item.Height = p.GridPos.X, p.GridPos.Y-minY, p.GridPos.W, p.GridPos.H
+			}
+			layout.Spec.Items = append(layout.Spec.Items, item)
+		}
+		if len(layout.Spec.Items) > 0 {
+			d.Spec.Layouts = append(d.Spec.Layouts, layout)
+		}
+	}
+	return d, c.issues
+}
+
+type converter struct {
+	keys   map[string]bool
+	issues []*ConversionIssue
+}
+
+func (c *converter) issue(kind, panel, name, reason string) {
+	c.issues = append(c.issues, &ConversionIssue{Kind: kind, Panel: panel, Name: name, Reason: reason})
+}
+
+// key returns a unique panel key derived from the Grafana panel ID.
+func (c *converter) key(p *GrafanaPanel, index int) string {
+	base := fmt.Sprintf("panel-%d", p.ID)
+	if p.ID == 0 {
+		base = fmt.Sprintf("panel-%d", index)
+	}
+	key := base
+	for n := 2; c.keys[key]; n++ {
+		key = fmt.Sprintf("%s-%d", base, n)
+	}
+	c.keys[key] = true
+	return key
+}
+
+func (c *converter) panel(p *GrafanaPanel) *Panel {
+	title := panelTitle(p)
+	out := &Panel{Kind: KindPanel, Spec: PanelSpec{Display: Display{Name: p.Title, Description: p.Description}}}
+
+	if p.Type == GrafanaText {
+		out.Spec.Plugin = Plugin{Kind: KindMarkdown, Spec: map[string]any{"text": p.Text()}}
+		return out
+	}
+
+	unit, ok := grafanaUnits[p.Unit()]
+	if !ok {
+		c.issue(IssueUnit, title, p.Unit(), "unit has no equivalent; values are shown as decimals")
+		unit = "decimal"
+	}
+	format := map[string]any{}
+	if unit != "" {
+		format["unit"] = unit
+	}
+	switch p.Type {
+	case GrafanaGraph, GrafanaTimeSeries:
+		out.Spec.Plugin = Plugin{Kind: KindTimeSeriesChart, Spec: map[string]any{
+			"legend": map[string]any{"position": "bottom"},
+			"yAxis":  map[string]any{"format": format},
+		}}
+	case GrafanaSingleStat, GrafanaStat:
+		out.Spec.Plugin = Plugin{Kind: KindStatChart, Spec: map[string]any{"calculation": "last-number", "format": format}}
+	case GrafanaGauge:
+		out.Spec.Plugin = Plugin{Kind: KindGaugeChart, Spec: map[string]any{"calculation": "last-number", "format": format}}
+	case GrafanaBarGauge:
+		out.Spec.Plugin = Plugin{Kind: KindBarChart, Spec: map[string]any{"calculation": "last-number", "format": format}}
+	case GrafanaTable, GrafanaTableOld:
+		out.Spec.Plugin = Plugin{Kind: KindTable, Spec: map[string]any{}}
+	default:
+		c.issue(IssuePanel, title, p.Type, fmt.Sprintf("panel type %q has no equivalent", p.Type))
+		return nil
+	}
+
+	for _, t := range p.VisibleTargets() {
+		if t.Expr == "" {
+			c.issue(IssueQuery, title, t.RefID, "query has no PromQL expression")
+			continue
+		}
+		spec := map[string]any{"query": convertVariables(t.Expr)}
+		if t.LegendFormat != "" {
+			spec["seriesNameFormat"] = convertVariables(t.LegendFormat)
+		}
+		out.Spec.Queries = append(out.Spec.Queries, &Query{
+			Kind: KindTimeSeriesQuery,
+			Spec: QuerySpec{Plugin: Plugin{Kind: KindPrometheusTimeSeriesQuery, Spec: spec}},
+		})
+	}
+	if len(out.Spec.Queries) == 0 {
+		c.issue(IssuePanel, title, p.Type, "panel has no PromQL queries")
+		return nil
+	}
+	return out
+}
+
+func (c *converter) variable(v *GrafanaVariable) *Variable {
+	spec := VariableSpec{
+		Name:          v.Name,
+		AllowAllValue: v.IncludeAll,
+		AllowMultiple: v.Multi,
+	}
+	if v.Label != "" {
+		spec.Display = &Display{Name: v.Label}
+	}
+	if current := v.CurrentValues(); len(current) == 1 && !v.Multi {
+		spec.DefaultValue = current[0]
+	} else if len(current) > 0 {
+		spec.DefaultValue = current
+	}
+
+	query := v.QueryString()
+	switch v.Type {
+	case "query":
+		plugin, ok := queryVariablePlugin(query)
+		if !ok {
+			c.issue(IssueVariable, "", v.Name, fmt.Sprintf("query %q is not supported", query))
+			return nil
+		}
+		spec.Plugin = plugin
+	case "custom", "interval":
+		spec.Plugin = &Plugin{Kind: KindStaticListVariable, Spec: map[string]any{"values": customValues(query)}}
+	case "constant", "textbox":
+		value := query
+		if current := v.CurrentValues(); len(current) > 0 {
+			value = current[0]
+		}
+		return &Variable{Kind: KindTextVariable, Spec: VariableSpec{Name: v.Name, Display: spec.Display, Value: value}}
+	default:
+		c.issue(IssueVariable, "", v.Name, fmt.Sprintf("variable type %q has no equivalent", v.Type))
+		return nil
+	}
+	return &Variable{Kind: KindListVariable, Spec: spec}
+}
+
+// queryVariablePlugin converts the Prometheus query of a Grafana query variable.
+func queryVariablePlugin(query string) (*Plugin, bool) {
+	if m := labelValuesRe.FindStringSubmatch(query); m != nil {
+		spec := map[string]any{"labelName": m[2]}
+		if m[1] != "" {
+			spec["matchers"] = []string{convertVariables(m[1])}
+		}
+		return &Plugin{Kind: KindPrometheusLabelValuesVariable, Spec: spec}, true
+	}
+	if labelNamesRe.MatchString(query) {
+		return &Plugin{Kind: KindPrometheusLabelNamesVariable, Spec: map[string]any{}}, true
+	}
+	if m := queryResultRe.FindStringSubmatch(query); m != nil {
+		return &Plugin{Kind: KindPrometheusPromQLVariable, Spec: map[string]any{"expr": convertVariables(m[1])}}, true
+	}
+	return nil, false
+}
+
+// customValues returns the values of a custom variable, given as a comma separated list where
+// each entry is a value or "text : value".
+func customValues(query string) []string {
+	var values []string
+	for _, part := range strings.Split(query, ",") {
+		part = strings.TrimSpace(part)
+		if i := strings.Index(part, " : "); i >= 0 {
+			part = strings.TrimSpace(part[i+3:])
+		}
+		if part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// convertVariables rewrites the deprecated [[var]] syntax to $var.
+func convertVariables(s string) string {
+	return legacyVariableRe.ReplaceAllString(s, "$$$1")
+}
+
+// grafanaDuration converts a relative Grafana time range such as now-6h to a duration.
+func grafanaDuration(t *GrafanaTime) string {
+	if t == nil || t.To != "now" || !strings.HasPrefix(t.From, "now-") {
+		return defaultDuration
+	}
+	return strings.TrimPrefix(t.From, "now-")
+}
+
+func panelTitle(p *GrafanaPanel) string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return fmt.Sprintf("panel %d", p.ID)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertGrafana(t *testing.T) {
+	b, err := os.ReadFile("testdata/classic.json")
+	require.NoError(t, err)
+	g, err := ParseGrafana(string(b))
+	require.NoError(t, err)
+
+	grafanaGroups := g.Groups()
+	require.Len(t, grafanaGroups, 3)
+	require.Equal(t, "Traffic", grafanaGroups[1].Title)
+	require.Equal(t, []string{`sum by (pod) (rate(http_requests_total{env="[[env]]",pod=~"$pod"}[5m]))`}, grafanaGroups[1].Panels[0].PromQL())
+
+	converted, issues := ConvertGrafana(g)
+	require.Equal(t, []*ConversionIssue{
+		{Kind: IssueVariable, Name: "ds", Reason: `variable type "datasource" has no equivalent`},
+		{Kind: IssueQuery, Panel: "Requests", Name: "C", Reason: "query has no PromQL expression"},
+		{Kind: IssuePanel, Panel: "Latency heatmap", Name: "heatmap", Reason: `panel type "heatmap" has no equivalent`},
+		{Kind: IssueUnit, Panel: "Memory", Name: "furlongs", Reason: "unit has no equivalent; values are shown as decimals"},
+	}, issues)
+
+	// The converted dashboard must read back like any other dashboard.
+	out, err := json.Marshal(converted)
+	require.NoError(t, err)
+	d, err := Parse(string(out))
+	require.NoError(t, err)
+	require.Equal(t, "6h", d.Spec.Duration)
+
+	require.Len(t, d.Spec.Variables, 3)
+	env := d.Spec.Variables[0]
+	require.Equal(t, "Environment", env.Spec.Display.Name)
+	require.Equal(t, KindPrometheusLabelValuesVariable, env.Spec.Plugin.Kind)
+	require.Equal(t, "env", env.Spec.Plugin.Spec["labelName"])
+	require.Equal(t, []any{`up{job="api"}`}, env.Spec.Plugin.Spec["matchers"])
+	require.Equal(t, []string{"prod"}, env.Defaults())
+	pod := d.Spec.Variables[1]
+	require.True(t, pod.Spec.AllowAllValue)
+	require.True(t, pod.Spec.AllowMultiple)
+	require.Equal(t, []string{AllValue}, pod.Defaults())
+	quantile := d.Spec.Variables[2]
+	require.Equal(t, KindStaticListVariable, quantile.Spec.Plugin.Kind)
+	require.Equal(t, []any{"0.5", "0.99"}, quantile.Spec.Plugin.Spec["values"])
+
+	panels := d.OrderedPanels()
+	var (
+		keys   []string
+		groups []string
+	)
+	for _, p := range panels {
+		keys = append(keys, p.Key)
+		groups = append(groups, p.Group)
+	}
+	require.Equal(t, []string{"panel-1", "panel-3", "panel-6", "panel-7"}, keys)
+	require.Equal(t, []string{"", "Traffic", "Details", "Details"}, groups)
+
+	require.Equal(t, KindStatChart, panels[0].Panel.Spec.Plugin.Kind)
+	require.Equal(t, "seconds", panels[0].Panel.Unit())
+	require.Equal(t, KindTimeSeriesChart, panels[1].Panel.Spec.Plugin.Kind)
+	require.Equal(t, "requests/sec", panels[1].Panel.Unit())
+	require.Equal(t, []string{`sum by (pod) (rate(http_requests_total{env="$env",pod=~"$pod"}[5m]))`}, panels[1].Panel.PromQL())
+	require.Equal(t, "decimal", panels[2].Panel.Unit())
+	require.Equal(t, KindMarkdown, panels[3].Panel.Spec.Plugin.Kind)
+
+	require.Len(t, d.Spec.Layouts, 3)
+	require.Nil(t, d.Spec.Layouts[0].Spec.Display)
+	require.True(t, d.Spec.Layouts[1].Spec.Display.Collapse.Open)
+	require.False(t, d.Spec.Layouts[2].Spec.Display.Collapse.Open)
+	require.Equal(t, 8, d.Spec.Layouts[2].Spec.Items[1].Y)
+}
+
+func TestGrafanaLegacyRows(t *testing.T) {
+	g, err := ParseGrafana(`{"title": "Legacy", "rows": [{"title": "Row", "panels": [
+		{"id": 1, "type": "graph", "span": 6, "targets": [{"expr": "a"}]},
+		{"id": 2, "type": "graph", "span": 6, "targets": [{"expr": "b"}]},
+		{"id": 3, "type": "singlestat", "span": 4, "format": "percentunit", "targets": [{"expr": "c"}]}
+	]}]}`)
+	require.NoError(t, err)
+
+	groups := g.Groups()
+	require.Len(t, groups, 1)
+	require.Equal(t, &GrafanaGridPos{X: 12, Y: 0, W: 12, H: 8}, groups[0].Panels[1].GridPos)
+	require.Equal(t, &GrafanaGridPos{X: 0, Y: 8, W: 8, H: 8}, groups[0].Panels[2].GridPos)
+	require.Equal(t, "percentunit", groups[0].Panels[2].Unit())
+	require.Equal(t, []string{"c"}, groups[0].Panels[2].PromQL())
+}
+
+func TestQueryVariablePlugin(t *testing.T) {
+	testCases := []struct {
+		query string
+		want  *Plugin
+	}{
+		{query: "label_values(job)", want: &Plugin{Kind: KindPrometheusLabelValuesVariable, Spec: map[string]any{"labelName": "job"}}},
+		{query: "label_names()", want: &Plugin{Kind: KindPrometheusLabelNamesVariable, Spec: map[string]any{}}},
+		{query: "query_result(topk(5, up))", want: &Plugin{Kind: KindPrometheusPromQLVariable, Spec: map[string]any{"expr": "topk(5, up)"}}},
+		{query: "metrics(http_.*)"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			got, ok := queryVariablePlugin(tc.query)
+			require.Equal(t, tc.want != nil, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExtractGrafanaQueries(t *testing.T) {
+	b, err := os.ReadFile("testdata/classic.json")
+	require.NoError(t, err)
+	queries, err := ExtractGrafanaQueries(string(b))
+	require.NoError(t, err)
+	// Hidden and non-PromQL panel queries are left out, like in conversions. Variable queries
+	// contribute their series selectors.
+	require.Equal(t, []string{
+		`process_resident_memory_bytes`,
+		`sum by (le) (rate(http_request_duration_seconds_bucket[5m]))`,
+		`sum by (pod) (rate(http_requests_total{env="[[env]]",pod=~"$pod"}[5m]))`,
+		`time() - process_start_time_seconds{env="$env"}`,
+		`up{job="api"}`,
+	}, queries)
+
+	_, err = ExtractGrafanaQueries("{")
+	require.Error(t, err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dashboards extracts queries from dashboard definitions.
+package dashboards
+
+import "sort"
+
+// ExtractQueries returns the de-duplicated PromQL queries of the panels and variables of a
+// Chronosphere dashboard. Panel queries are the same queries that are shown and summarized.
+func ExtractQueries(dashboardJSON string) ([]string, error) {
+	if dashboardJSON == "" {
+		return nil, nil
+	}
+	d, err := Parse(dashboardJSON)
+	if err != nil {
+		return nil, err
+	}
+	var queries []string
+	for _, p := range d.Spec.Panels {
+		if p != nil {
+			queries = append(queries, p.PromQL()...)
+		}
+	}
+	for _, v := range d.Spec.Variables {
+		if v != nil {
+			queries = append(queries, v.PromQL()...)
+		}
+	}
+	return uniqueSorted(queries), nil
+}
+
+// ExtractGrafanaQueries returns the de-duplicated PromQL queries of the panels and query
+// variables of a classic or Grafana dashboard, leaving out hidden panel queries like summaries
+// and conversions do.
+func ExtractGrafanaQueries(dashboardJSON string) ([]string, error) {
+	if dashboardJSON == "" {
+		return nil, nil
+	}
+	d, err := ParseGrafana(dashboardJSON)
+	if err != nil {
+		return nil, err
+	}
+	var queries []string
+	for _, g := range d.Groups() {
+		for _, p := range g.Panels {
+			queries = append(queries, p.PromQL()...)
+		}
+	}
+	for _, v := range d.Templating.List {
+		if v != nil {
+			queries = append(queries, v.PromQL()...)
+		}
+	}
+	return uniqueSorted(queries), nil
+}
+
+func uniqueSorted(queries []string) []string {
+	seen := make(map[string]struct{}, len(queries))
+	unique := make([]string, 0, len(queries))
+	for _, q := range queries {
+		if _, ok := seen[q]; !ok {
+			seen[q] = struct{}{}
+			unique = append(unique, q)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
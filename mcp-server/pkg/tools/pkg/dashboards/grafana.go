@@ -0,0 +1,308 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Grafana panel types.
+const (
+	GrafanaRow        = "row"
+	GrafanaGraph      = "graph"
+	GrafanaTimeSeries = "timeseries"
+	GrafanaSingleStat = "singlestat"
+	GrafanaStat       = "stat"
+	GrafanaGauge      = "gauge"
+	GrafanaBarGauge   = "bargauge"
+	GrafanaTable      = "table"
+	GrafanaTableOld   = "table-old"
+	GrafanaText       = "text"
+)
+
+// GrafanaDashboard is a Grafana dashboard definition, as stored in the dashboard_json of
+// classic and Grafana dashboards. Fields not needed to read or convert dashboards are not
+// modelled.
+type GrafanaDashboard struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Time        *GrafanaTime        `json:"time,omitempty"`
+	Templating  GrafanaTemplating   `json:"templating"`
+	Panels      []*GrafanaPanel     `json:"panels,omitempty"`
+	Rows        []*GrafanaLegacyRow `json:"rows,omitempty"`
+}
+
+// GrafanaTime is the default time range of a dashboard, e.g. now-6h to now.
+type GrafanaTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GrafanaTemplating holds the variables of a dashboard.
+type GrafanaTemplating struct {
+	List []*GrafanaVariable `json:"list"`
+}
+
+// GrafanaVariable is a dashboard variable. Query is a string or, in newer dashboards, an
+// object with the query in its query field.
+type GrafanaVariable struct {
+	Name       string          `json:"name"`
+	Label      string          `json:"label,omitempty"`
+	Type       string          `json:"type"`
+	Query      any             `json:"query,omitempty"`
+	Definition string          `json:"definition,omitempty"`
+	Current    *GrafanaCurrent `json:"current,omitempty"`
+	Multi      bool            `json:"multi,omitempty"`
+	IncludeAll bool            `json:"includeAll,omitempty"`
+}
+
+// GrafanaCurrent is the selected value of a variable, a string or a list of strings.
+type GrafanaCurrent struct {
+	Value any `json:"value"`
+}
+
+// GrafanaPanel is a panel. Row panels hold their panels in Panels when collapsed and are
+// followed by them otherwise.
+type GrafanaPanel struct {
+	ID          int                 `json:"id,omitempty"`
+	Type        string              `json:"type"`
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	GridPos     *GrafanaGridPos     `json:"gridPos,omitempty"`
+	Span        float64             `json:"span,omitempty"`
+	Collapsed   bool                `json:"collapsed,omitempty"`
+	Panels      []*GrafanaPanel     `json:"panels,omitempty"`
+	Targets     []*GrafanaTarget    `json:"targets,omitempty"`
+	FieldConfig *GrafanaFieldConfig `json:"fieldConfig,omitempty"`
+	Format      string              `json:"format,omitempty"`
+	YAxes       []*GrafanaAxis      `json:"yaxes,omitempty"`
+	Content     string              `json:"content,omitempty"`
+	Options     map[string]any      `json:"options,omitempty"`
+}
+
+// GrafanaGridPos is the position of a panel on the 24 column grid.
+type GrafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// GrafanaLegacyRow is a row of dashboards predating grid positions.
+type GrafanaLegacyRow struct {
+	Title     string          `json:"title,omitempty"`
+	Collapse  bool            `json:"collapse,omitempty"`
+	ShowTitle bool            `json:"showTitle,omitempty"`
+	Panels    []*GrafanaPanel `json:"panels"`
+}
+
+// GrafanaTarget is a panel query.
+type GrafanaTarget struct {
+	RefID        string `json:"refId,omitempty"`
+	Expr         string `json:"expr,omitempty"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+	Hide         bool   `json:"hide,omitempty"`
+}
+
+// GrafanaFieldConfig holds the field defaults of newer panel types.
+type GrafanaFieldConfig struct {
+	Defaults GrafanaFieldDefaults `json:"defaults"`
+}
+
+// GrafanaFieldDefaults holds the unit values are formatted with.
+type GrafanaFieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+// GrafanaAxis is a y-axis of graph panels.
+type GrafanaAxis struct {
+	Format string `json:"format,omitempty"`
+}
+
+// GrafanaGroup is a group of panels in layout order, with the title of the row they are in.
+type GrafanaGroup struct {
+	Title     string
+	Collapsed bool
+	Panels    []*GrafanaPanel
+}
+
+// ParseGrafana parses the dashboard JSON of a classic or Grafana dashboard.
+func ParseGrafana(dashboardJSON string) (*GrafanaDashboard, error) {
+	var d GrafanaDashboard
+	if err := json.Unmarshal([]byte(dashboardJSON), &d); err != nil {
+		return nil, fmt.Errorf("failed to parse Grafana dashboard JSON: %s", err)
+	}
+	return &d, nil
+}
+
+// Groups returns the panels of the dashboard grouped by row, in layout order. Panels before
+// the first row form a group without a title. Legacy rows are given grid positions.
+func (d *GrafanaDashboard) Groups() []*GrafanaGroup {
+	if len(d.Rows) > 0 {
+		return legacyGroups(d.Rows)
+	}
+	var (
+		groups  []*GrafanaGroup
+		current = &GrafanaGroup{}
+	)
+	for _, p := range d.Panels {
+		if p == nil {
+			continue
+		}
+		if p.Type != GrafanaRow {
+			current.Panels = append(current.Panels, p)
+			continue
+		}
+		if len(current.Panels) > 0 || current.Title != "" {
+			groups = append(groups, current)
+		}
+		current = &GrafanaGroup{Title: p.Title, Collapsed: p.Collapsed}
+		// Collapsed rows hold their panels instead of being followed by them.
+		current.Panels = append(current.Panels, p.Panels...)
+	}
+	if len(current.Panels) > 0 || current.Title != "" {
+		groups = append(groups, current)
+	}
+	return groups
+}
+
+// legacyGroups converts legacy rows, where panels have a span of 12 columns per row instead
+// of a grid position.
+func legacyGroups(rows []*GrafanaLegacyRow) []*GrafanaGroup {
+	const legacyHeight = 8
+	groups := make([]*GrafanaGroup, 0, len(rows))
+	for _, r := range rows {
+		if r == nil {
+			continue
+		}
+		g := &GrafanaGroup{Title: r.Title, Collapsed: r.Collapse}
+		x, y := 0, 0
+		for _, p := range r.Panels {
+			if p == nil {
+				continue
+			}
+			span := int(p.Span)
+			if span <= 0 || span > 12 {
+				span = 12
+			}
+			if x+span*2 > 24 {
+				x, y = 0, y+legacyHeight
+			}
+			p.GridPos = &GrafanaGridPos{X: x, Y: y, W: span * 2, H: legacyHeight}
+			x += span * 2
+			g.Panels = append(g.Panels, p)
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// Unit returns the unit values of the panel are formatted with, if any.
+func (p *GrafanaPanel) Unit() string {
+	switch {
+	case p.FieldConfig != nil && p.FieldConfig.Defaults.Unit != "":
+		return p.FieldConfig.Defaults.Unit
+	case p.Format != "":
+		return p.Format
+	case len(p.YAxes) > 0 && p.YAxes[0] != nil:
+		return p.YAxes[0].Format
+	}
+	return ""
+}
+
+// VisibleTargets returns the queries of the panel, leaving out hidden queries.
+func (p *GrafanaPanel) VisibleTargets() []*GrafanaTarget {
+	var targets []*GrafanaTarget
+	for _, t := range p.Targets {
+		if t != nil && !t.Hide {
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// PromQL returns the PromQL queries of the panel, leaving out hidden queries.
+func (p *GrafanaPanel) PromQL() []string {
+	var queries []string
+	for _, t := range p.VisibleTargets() {
+		if t.Expr != "" {
+			queries = append(queries, t.Expr)
+		}
+	}
+	return queries
+}
+
+// Text returns the content of text panels.
+func (p *GrafanaPanel) Text() string {
+	if c, ok := p.Options["content"].(string); ok {
+		return c
+	}
+	return p.Content
+}
+
+// QueryString returns the query of the variable.
+func (v *GrafanaVariable) QueryString() string {
+	switch q := v.Query.(type) {
+	case string:
+		return q
+	case map[string]any:
+		if s, ok := q["query"].(string); ok {
+			return s
+		}
+	}
+	return v.Definition
+}
+
+// PromQL returns the PromQL queries of a query variable: the series selector of label_values
+// and the query of query_result. Other variables and queries, e.g. label_names(), have none.
+func (v *GrafanaVariable) PromQL() []string {
+	if v.Type != "query" {
+		return nil
+	}
+	query := v.QueryString()
+	if m := labelValuesRe.FindStringSubmatch(query); m != nil {
+		if m[1] == "" {
+			return nil
+		}
+		return []string{m[1]}
+	}
+	if m := queryResultRe.FindStringSubmatch(query); m != nil {
+		return []string{m[1]}
+	}
+	return nil
+}
+
+// CurrentValues returns the selected values of the variable.
+func (v *GrafanaVariable) CurrentValues() []string {
+	if v.Current == nil {
+		return nil
+	}
+	switch c := v.Current.Value.(type) {
+	case string:
+		if c != "" {
+			return []string{c}
+		}
+	case []any:
+		var out []string
+		for _, s := range c {
+			if str, ok := s.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Plugin kinds used by Chronosphere dashboards.
+const (
+	KindDashboard = "Dashboard"
+	KindPanel     = "Panel"
+	KindGrid      = "Grid"
+
+	KindTimeSeriesQuery           = "TimeSeriesQuery"
+	KindPrometheusTimeSeriesQuery = "PrometheusTimeSeriesQuery"
+
+	KindTimeSeriesChart = "TimeSeriesChart"
+	KindStatChart       = "StatChart"
+	KindGaugeChart      = "GaugeChart"
+	KindBarChart        = "BarChart"
+	KindTable           = "Table"
+	KindMarkdown        = "Markdown"
+
+	KindListVariable                  = "ListVariable"
+	KindTextVariable                  = "TextVariable"
+	KindPrometheusLabelValuesVariable = "PrometheusLabelValuesVariable"
+	KindPrometheusLabelNamesVariable  = "PrometheusLabelNamesVariable"
+	KindPrometheusPromQLVariable      = "PrometheusPromQLVariable"
+	KindStaticListVariable            = "StaticListVariable"
+)
+
+// AllValue is the default value of list variables that select every value.
+const AllValue = "$__all"
+
+// Dashboard is a Perses style dashboard definition, as stored in the dashboard_json of a
+// Chronosphere dashboard. Fields not needed to read or build dashboards are not modelled.
+type Dashboard struct {
+	Kind     string        `json:"kind"`
+	Metadata Metadata      `json:"metadata"`
+	Spec     DashboardSpec `json:"spec"`
+}
+
+// Metadata identifies a dashboard.
+type Metadata struct {
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+}
+
+// DashboardSpec is the content of a dashboard.
+type DashboardSpec struct {
+	Display   *Display          `json:"display,omitempty"`
+	Duration  string            `json:"duration,omitempty"`
+	Variables []*Variable       `json:"variables,omitempty"`
+	Panels    map[string]*Panel `json:"panels"`
+	Layouts   []*Layout         `json:"layouts,omitempty"`
+}
+
+// Display is the name and description of a dashboard, panel or variable.
+type Display struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Plugin is a kind with a kind specific spec.
+type Plugin struct {
+	Kind string         `json:"kind"`
+	Spec map[string]any `json:"spec"`
+}
+
+// Variable is a dashboard variable.
+type Variable struct {
+	Kind string       `json:"kind"`
+	Spec VariableSpec `json:"spec"`
+}
+
+// VariableSpec is the definition of a variable. Value is only set for text variables.
+type VariableSpec struct {
+	Name          string   `json:"name"`
+	Display       *Display `json:"display,omitempty"`
+	Value         string   `json:"value,omitempty"`
+	DefaultValue  any      `json:"defaultValue,omitempty"`
+	AllowAllValue bool     `json:"allowAllValue,omitempty"`
+	AllowMultiple bool     `json:"allowMultiple,omitempty"`
+	Plugin        *Plugin  `json:"plugin,omitempty"`
+}
+
+// Panel is a dashboard panel.
+type Panel struct {
+	Kind string    `json:"kind"`
+	Spec PanelSpec `json:"spec"`
+}
+
+// PanelSpec is the definition of a panel.
+type PanelSpec struct {
+	Display Display  `json:"display"`
+	Plugin  Plugin   `json:"plugin"`
+	Queries []*Query `json:"queries,omitempty"`
+}
+
+// Query is a panel query.
+type Query struct {
+	Kind string    `json:"kind"`
+	Spec QuerySpec `json:"spec"`
+}
+
+// QuerySpec is the definition of a panel query.
+type QuerySpec struct {
+	Plugin Plugin `json:"plugin"`
+}
+
+// Layout positions panels in a collapsible group.
+type Layout struct {
+	Kind string     `json:"kind"`
+	Spec LayoutSpec `json:"spec"`
+}
+
+// LayoutSpec is the definition of a layout.
+type LayoutSpec struct {
+	Display *LayoutDisplay `json:"display,omitempty"`
+	Items   []*LayoutItem  `json:"items"`
+}
+
+// LayoutDisplay is the title of a layout group.
+type LayoutDisplay struct {
+	Title    string    `json:"title"`
+	Collapse *Collapse `json:"collapse,omitempty"`
+}
+
+// Collapse is whether a layout group is expanded.
+type Collapse struct {
+	Open bool `json:"open"`
+}
+
+// LayoutItem is the position of a panel within a layout.
+type LayoutItem struct {
+	X       int       `json:"x"`
+	Y       int       `json:"y"`
+	Width   int       `json:"width"`
+	Height  int       `json:"height"`
+	Content Reference `json:"content"`
+}
+
+// Reference is a JSON reference to a panel, e.g. #/spec/panels/requests.
+type Reference struct {
+	Ref string `json:"$ref"`
+}
+
+// PanelRef is a panel with its key and the title of the layout group it is in.
+type PanelRef struct {
+	Key   string
+	Group string
+	Panel *Panel
+}
+
+const panelRefPrefix = "#/spec/panels/"
+
+// PanelReference returns the layout reference to the panel with the given key.
+func PanelReference(key string) Reference {
+	return Reference{Ref: panelRefPrefix + key}
+}
+
+// Parse parses the dashboard JSON of a Chronosphere dashboard.
+func Parse(dashboardJSON string) (*Dashboard, error) {
+	var d Dashboard
+	if err := json.Unmarshal([]byte(dashboardJSON), &d); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard JSON: %s", err)
+	}
+	return &d, nil
+}
+
+// OrderedPanels returns the panels in layout order, top to bottom and left to right within
+// each group. Panels not referenced by any layout come last, ordered by key. Null panels and
+// layout items are skipped.
+func (d *Dashboard) OrderedPanels() []*PanelRef {
+	var (
+		refs []*PanelRef
+		seen = make(map[string]bool)
+	)
+	for _, l := range d.Spec.Layouts {
+		if l == nil {
+			continue
+		}
+		group := ""
+		if l.Spec.Display != nil {
+			group = l.Spec.Display.Title
+		}
+		var items []*LayoutItem
+		for _, item := range l.Spec.Items {
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Y != items[j].Y {
+				return items[i].Y < items[j].Y
+			}
+			return items[i].X < items[j].X
+		})
+		for _, item := range items {
+			key := strings.TrimPrefix(item.Content.Ref, panelRefPrefix)
+			p, ok := d.Spec.Panels[key]
+			if !ok || p == nil || seen[key] {
+				continue
+			}
+			seen[key] = true
+			refs = append(refs, &PanelRef{Key: key, Group: group, Panel: p})
+		}
+	}
+	var rest []string
+	for key, p := range d.Spec.Panels {
+		if p != nil && !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	for _, key := range rest {
+		refs = append(refs, &PanelRef{Key: key, Panel: d.Spec.Panels[key]})
+	}
+	return refs
+}
+
+// PromQL returns the PromQL queries of the panel.
+func (p *Panel) PromQL() []string {
+	var queries []string
+	for _, q := range p.Spec.Queries {
+		if q == nil {
+			continue
+		}
+		if s, ok := q.Spec.Plugin.Spec["query"].(string); ok && s != "" {
+			queries = append(queries, s)
+		}
+	}
+	return queries
+}
+
+// Unit returns the unit values of the panel are formatted with, if any.
+func (p *Panel) Unit() string {
+	spec := p.Spec.Plugin.Spec
+	if y, ok := spec["yAxis"].(map[string]any); ok {
+		spec = y
+	}
+	if f, ok := spec["format"].(map[string]any); ok {
+		if u, ok := f["unit"].(string); ok {
+			return u
+		}
+	}
+	return ""
+}
+
+// PromQL returns the PromQL queries of the variable: the expression of PromQL variables and the
+// series selectors label variables look up values with.
+func (v *Variable) PromQL() []string {
+	if v.Spec.Plugin == nil {
+		return nil
+	}
+	spec := v.Spec.Plugin.Spec
+	switch v.Spec.Plugin.Kind {
+	case KindPrometheusPromQLVariable:
+		if s, ok := spec["expr"].(string); ok && s != "" {
+			return []string{s}
+		}
+	case KindPrometheusLabelValuesVariable, KindPrometheusLabelNamesVariable:
+		var queries []string
+		matchers, _ := spec["matchers"].([]any)
+		for _, m := range matchers {
+			if s, ok := m.(string); ok && s != "" {
+				queries = append(queries, s)
+			}
+		}
+		return queries
+	}
+	return nil
+}
+
+// Defaults returns the default values of the variable. List variables defaulting to all
+// values return AllValue.
+func (v *Variable) Defaults() []string {
+	if v.Kind == KindTextVariable {
+		return []string{v.Spec.Value}
+	}
+	switch d := v.Spec.DefaultValue.(type) {
+	case string:
+		if d != "" {
+			return []string{d}
+		}
+	case []any:
+		var out []string
+		for _, s := range d {
+			if str, ok := s.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboards
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	b, err := os.ReadFile("testdata/checkout.json")
+	require.NoError(t, err)
+	d, err := Parse(string(b))
+	require.NoError(t, err)
+	require.Equal(t, "Checkout", d.Spec.Display.Name)
+
+	panels := d.OrderedPanels()
+	require.Len(t, panels, 3)
+	require.Equal(t, "requests", panels[0].Key)
+	require.Equal(t, "Golden signals", panels[0].Group)
+	require.Equal(t, "latency", panels[1].Key)
+	require.Equal(t, "notes", panels[2].Key)
+	require.Empty(t, panels[2].Group)
+
+	require.Equal(t, "requests/sec", panels[0].Panel.Unit())
+	require.Equal(t, "seconds", panels[1].Panel.Unit())
+	require.Equal(t, []string{`sum(rate(http_requests_total{env="$env",pod=~"$pod"}[5m]))`}, panels[0].Panel.PromQL())
+	require.Empty(t, panels[2].Panel.PromQL())
+
+	require.Len(t, d.Spec.Variables, 3)
+	require.Equal(t, []string{"prod"}, d.Spec.Variables[0].Defaults())
+	require.Equal(t, []string{AllValue}, d.Spec.Variables[1].Defaults())
+	require.Equal(t, []string{"/cart"}, d.Spec.Variables[2].Defaults())
+
+	queries, err := ExtractQueries(string(b))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket{env="$env"}[5m])))`,
+		`sum(rate(http_requests_total{env="$env",pod=~"$pod"}[5m]))`,
+		`up{job="checkout"}`,
+	}, queries, "variable matchers are included")
+}
+
+func TestOrderedPanelsSkipsNulls(t *testing.T) {
+	d, err := Parse(`{"spec": {
+		"panels": {"a": {"kind": "Panel"}, "x": null},
+		"layouts": [null, {"kind": "Grid", "spec": {"items": [null, {"content": {"$ref": "#/spec/panels/x"}}, {"content": {"$ref": "#/spec/panels/a"}}]}}]
+	}}`)
+	require.NoError(t, err)
+	panels := d.OrderedPanels()
+	require.Len(t, panels, 1)
+	require.Equal(t, "a", panels[0].Key)
+}
+
+func TestVariablePromQL(t *testing.T) {
+	d, err := Parse(`{"spec": {"variables": [
+		{"kind": "ListVariable", "spec": {"name": "job", "plugin": {"kind": "PrometheusPromQLVariable", "spec": {"expr": "count by (job) (up)"}}}},
+		{"kind": "ListVariable", "spec": {"name": "label", "plugin": {"kind": "PrometheusLabelNamesVariable", "spec": {"matchers": ["up"]}}}},
+		{"kind": "ListVariable", "spec": {"name": "static", "plugin": {"kind": "StaticListVariable", "spec": {"values": ["a"]}}}},
+		{"kind": "TextVariable", "spec": {"name": "text", "value": "x"}}
+	]}}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"count by (job) (up)"}, d.Spec.Variables[0].PromQL())
+	require.Equal(t, []string{"up"}, d.Spec.Variables[1].PromQL())
+	require.Empty(t, d.Spec.Variables[2].PromQL())
+	require.Empty(t, d.Spec.Variables[3].PromQL())
+
+	g, err := ParseGrafana(`{"templating": {"list": [
+		{"name": "job", "type": "query", "query": "label_values(up, job)"},
+		{"name": "pod", "type": "query", "query": "label_values(pod)"},
+		{"name": "top", "type": "query", "query": {"query": "query_result(topk(5, up))"}},
+		{"name": "names", "type": "query", "query": "label_names()"},
+		{"name": "env", "type": "custom", "query": "prod,dev"}
+	]}}`)
+	require.NoError(t, err)
+	var queries [][]string
+	for _, v := range g.Templating.List {
+		queries = append(queries, v.PromQL())
+	}
+	require.Equal(t, [][]string{{"up"}, nil, {"topk(5, up)"}, nil, nil}, queries)
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filters evaluates Chronosphere label filters, such as the ones used by drop,
+// rollup and mapping rules, against label sets.
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+// MetricNameLabel is the label holding the metric name.
+const MetricNameLabel = "__name__"
+
+// Filter is a single compiled label filter.
+type Filter struct {
+	Name      string
+	ValueGlob string
+
+	negated bool
+	// pattern is the glob translated to an unanchored RE2 expression.
+	pattern string
+	re      *regexp.Regexp
+}
+
+// Filters is a set of label filters which must all match.
+type Filters []Filter
+
+// Parse compiles a label filter. A leading "!" negates the glob.
+func Parse(name, valueGlob string) (Filter, error) {
+	if name == "" {
+		return Filter{}, fmt.Errorf("filter label name cannot be empty")
+	}
+	glob := valueGlob
+	negated := strings.HasPrefix(glob, "!")
+	if negated {
+		glob = glob[1:]
+	}
+	pattern, err := globToRegexp(glob)
+	if err != nil {
+		return Filter{}, fmt.Errorf("invalid glob %q for label %s: %w", valueGlob, name, err)
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return Filter{}, fmt.Errorf("invalid glob %q for label %s: %w", valueGlob, name, err)
+	}
+	return Filter{
+		Name:      name,
+		ValueGlob: valueGlob,
+		negated:   negated,
+		pattern:   pattern,
+		re:        re,
+	}, nil
+}
+
+// ParseString compiles a filter in the "name:value_glob" form used by chronoctl and Terraform.
+func ParseString(s string) (Filter, error) {
+	name, glob, ok := strings.Cut(s, ":")
+	if !ok {
+		return Filter{}, fmt.Errorf("filter %q must be in the form name:value_glob", s)
+	}
+	return Parse(strings.TrimSpace(name), strings.TrimSpace(glob))
+}
+
+// ParseStrings compiles a list of filters in the "name:value_glob" form.
+func ParseStrings(ss []string) (Filters, error) {
+	fs := make(Filters, 0, len(ss))
+	for _, s := range ss {
+		f, err := ParseString(s)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+// FromModels compiles the label filters of a config entity.
+func FromModels(lfs []*models.Configv1LabelFilter) (Filters, error) {
+	fs := make(Filters, 0, len(lfs))
+	for _, lf := range lfs {
+		if lf == nil {
+			continue
+		}
+		f, err := Parse(lf.Name, lf.ValueGlob)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+// Matches returns whether the filter matches the label set. A label that is not present
+// only matches a negated filter.
+func (f Filter) Matches(lbls map[string]string) bool {
+	v, ok := lbls[f.Name]
+	if !ok {
+		return f.negated
+	}
+	return f.re.MatchString(v) != f.negated
+}
+
+// MatchesValue returns whether the filter matches the given label value.
+func (f Filter) MatchesValue(v string) bool {
+	return f.re.MatchString(v) != f.negated
+}
+
+// Matcher returns the filter as a PromQL label matcher, e.g. service=~"api-.*".
+func (f Filter) Matcher() string {
+	op := "=~"
+	if f.negated {
+		op = "!~"
+	}
+	if isLiteral(f.ValueGlob) {
+		// Use an equality matcher when the glob contains no wildcards.
+		op = "="
+		if f.negated {
+			op = "!="
+		}
+		return f.Name + op + strconv.Quote(strings.TrimPrefix(f.ValueGlob, "!"))
+	}
+	return f.Name + op + strconv.Quote(f.pattern)
+}
+
+// String returns the filter in the "name:value_glob" form.
+func (f Filter) String() string {
+	return f.Name + ":" + f.ValueGlob
+}
+
+// Matches returns whether all filters match the label set.
+func (fs Filters) Matches(lbls map[string]string) bool {
+	for _, f := range fs {
+		if !f.Matches(lbls) {
+			return false
+		}
+	}
+	return true
+}
+
+// Unmatched returns the filters that do not match the label set.
+func (fs Filters) Unmatched(lbls map[string]string) Filters {
+	var unmatched Filters
+	for _, f := range fs {
+		if !f.Matches(lbls) {
+			unmatched = append(unmatched, f)
+		}
+	}
+	return unmatched
+}
+
+// MetricName returns the filter on the metric name, if any.
+func (fs Filters) MetricName() (Filter, bool) {
+	for _, f := range fs {
+		if f.Name == MetricNameLabel {
+			return f, true
+		}
+	}
+	return Filter{}, false
+}
+
+// Selector returns a PromQL series selector equivalent to the filters, e.g.
+// {__name__=~"http_.*",service="api"}.
+func (fs Filters) Selector() string {
+	sorted := make(Filters, len(fs))
+	copy(sorted, fs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		// Keep __name__ first for readability.
+		return sorted[i].Name == MetricNameLabel && sorted[j].Name != MetricNameLabel
+	})
+	matchers := make([]string, 0, len(sorted))
+	for _, f := range sorted {
+		matchers = append(matchers, f.Matcher())
+	}
+	return "{" + strings.Join(matchers, ",") + "}"
+}
+
+// Strings returns the filters in the "name:value_glob" form.
+func (fs Filters) Strings() []string {
+	ss := make([]string, 0, len(fs))
+	for _, f := range fs {
+		ss = append(ss, f.String())
+	}
+	return ss
+}
+
+func isLiteral(glob string) bool {
+	return !strings.ContainsAny(strings.TrimPrefix(glob, "!"), "*?[]{}\\")
+}
+
+// globToRegexp translates a glob into an RE2 expression. Supported syntax:
+//   - "*" matches any sequence of characters
+//   - "?" matches any single character
+//   - "[abc]", "[a-z]" and "[!abc]" match character ranges
+//   - "{a,b}" matches any of the comma separated alternatives
+func globToRegexp(glob string) (string, error) {
+	var (
+		sb    strings.Builder
+		depth int
+	)
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated character range")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case '{':
+			depth++
+			sb.WriteString("(?:")
+		case '}':
+			if depth == 0 {
+				return "", fmt.Errorf("unexpected }")
+			}
+			depth--
+			sb.WriteString(")")
+		case ',':
+			if depth > 0 {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if depth != 0 {
+		return "", fmt.Errorf("unterminated {")
+	}
+	return sb.String(), nil
+}
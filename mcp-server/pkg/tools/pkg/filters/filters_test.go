@@ -0,0 +1,158 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatches(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+		labels map[string]string
+		want   bool
+	}{
+		{
+			name:   "literal match",
+			filter: "service:api",
+			labels: map[string]string{"service": "api"},
+			want:   true,
+		},
+		{
+			name:   "literal mismatch",
+			filter: "service:api",
+			labels: map[string]string{"service": "web"},
+			want:   false,
+		},
+		{
+			name:   "star glob",
+			filter: "__name__:http_*",
+			labels: map[string]string{"__name__": "http_requests_total"},
+			want:   true,
+		},
+		{
+			name:   "question mark glob",
+			filter: "code:5??",
+			labels: map[string]string{"code": "503"},
+			want:   true,
+		},
+		{
+			name:   "character range",
+			filter: "code:[45]*",
+			labels: map[string]string{"code": "404"},
+			want:   true,
+		},
+		{
+			name:   "negated character range",
+			filter: "code:[!45]*",
+			labels: map[string]string{"code": "404"},
+			want:   false,
+		},
+		{
+			name:   "alternatives",
+			filter: "env:{prod,staging}",
+			labels: map[string]string{"env": "staging"},
+			want:   true,
+		},
+		{
+			name:   "negated glob",
+			filter: "env:!prod*",
+			labels: map[string]string{"env": "dev"},
+			want:   true,
+		},
+		{
+			name:   "missing label",
+			filter: "env:prod",
+			labels: map[string]string{},
+			want:   false,
+		},
+		{
+			name:   "missing label with negated glob",
+			filter: "env:!prod",
+			labels: map[string]string{},
+			want:   true,
+		},
+		{
+			name:   "regexp metacharacters are literal",
+			filter: "path:/api/v1.0",
+			labels: map[string]string{"path": "/api/v1x0"},
+			want:   false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseString(tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, f.Matches(tc.labels))
+		})
+	}
+}
+
+func TestParseStringErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		filter  string
+		wantErr string
+	}{
+		{
+			name:    "missing separator",
+			filter:  "service",
+			wantErr: `filter "service" must be in the form name:value_glob`,
+		},
+		{
+			name:    "empty name",
+			filter:  ":api",
+			wantErr: "filter label name cannot be empty",
+		},
+		{
+			name:    "unterminated range",
+			filter:  "code:[45",
+			wantErr: "unterminated character range",
+		},
+		{
+			name:    "unterminated alternatives",
+			filter:  "env:{prod,dev",
+			wantErr: "unterminated {",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseString(tc.filter)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestFiltersSelector(t *testing.T) {
+	fs, err := ParseStrings([]string{"service:api", "__name__:http_*", "env:!prod", "code:{4,5}*"})
+	require.NoError(t, err)
+	require.Equal(t, `{__name__=~"http_.*",service="api",env!="prod",code=~"(?:4|5).*"}`, fs.Selector())
+}
+
+func TestFiltersUnmatched(t *testing.T) {
+	fs, err := ParseStrings([]string{"__name__:http_*", "service:api"})
+	require.NoError(t, err)
+
+	lbls := map[string]string{"__name__": "http_requests_total", "service": "web"}
+	require.False(t, fs.Matches(lbls))
+	require.Equal(t, []string{"service:api"}, fs.Unmatched(lbls).Strings())
+
+	name, ok := fs.MetricName()
+	require.True(t, ok)
+	require.Equal(t, "http_*", name.ValueGlob)
+}
@@ -671,6 +671,58 @@ func TestObjectArray(t *testing.T) {
 	}
 }
 
+func TestParseTimeRangeWithRetention(t *testing.T) {
+	retention := 7 * 24 * time.Hour
+	testCases := []struct {
+		name      string
+		args      map[string]interface{}
+		retention time.Duration
+		wantErr   string
+	}{
+		{
+			name:      "default range",
+			args:      map[string]interface{}{},
+			retention: retention,
+		},
+		{
+			name:      "within retention",
+			args:      map[string]interface{}{"start": time.Now().Add(-6 * 24 * time.Hour).Format(time.RFC3339)},
+			retention: retention,
+		},
+		{
+			name:      "before retention",
+			args:      map[string]interface{}{"start": time.Now().Add(-30 * 24 * time.Hour).Format(time.RFC3339)},
+			retention: retention,
+			wantErr:   "outside the data retention of 7 days",
+		},
+		{
+			name: "longer than retention",
+			args: map[string]interface{}{
+				"start": time.Now().Add(-6 * 24 * time.Hour).Format(time.RFC3339),
+				"end":   time.Now().Add(2 * 24 * time.Hour).Format(time.RFC3339),
+			},
+			retention: retention,
+			wantErr:   "longer than the data retention of 7 days",
+		},
+		{
+			name: "no retention",
+			args: map[string]interface{}{"start": time.Now().Add(-30 * 24 * time.Hour).Format(time.RFC3339)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeRange, err := ParseTimeRangeWithRetention(createRequestWithArgs(tc.args), tc.retention)
+			if tc.wantErr != "" {
+				requireErrorResultContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, timeRange.Start.Before(timeRange.End))
+		})
+	}
+}
+
 // Helper function to create a CallToolRequest with specified arguments
 func createRequestWithArgs(args map[string]interface{}) mcp.CallToolRequest {
 	request := mcp.CallToolRequest{}
@@ -15,6 +15,7 @@
 package params
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -52,3 +53,26 @@ func ParseTimeRange(request mcp.CallToolRequest) (*TimeRange, error) {
 		End:   end,
 	}, nil
 }
+
+// ParseTimeRangeWithRetention parses start and end time parameters like ParseTimeRange, and
+// rejects ranges that start before the retention period since no data is kept there, or that
+// are longer than the retention, which is the longest range queries may cover. A zero
+// retention does not limit the range.
+func ParseTimeRangeWithRetention(request mcp.CallToolRequest, retention time.Duration) (*TimeRange, error) {
+	timeRange, err := ParseTimeRange(request)
+	if err != nil {
+		return nil, err
+	}
+	if retention <= 0 {
+		return timeRange, nil
+	}
+	if earliest := time.Now().Add(-retention); timeRange.Start.Before(earliest) {
+		return nil, fmt.Errorf("start %s is outside the data retention of %.0f days, use a start after %s",
+			timeRange.Start.Format(time.RFC3339), retention.Hours()/24, earliest.Format(time.RFC3339))
+	}
+	if length := timeRange.End.Sub(timeRange.Start); length > retention {
+		return nil, fmt.Errorf("time range of %s is longer than the data retention of %.0f days, use a shorter range",
+			length, retention.Hours()/24)
+	}
+	return timeRange, nil
+}
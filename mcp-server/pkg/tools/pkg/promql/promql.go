@@ -0,0 +1,322 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package promql provides utilities for inspecting PromQL queries stored in Chronosphere config.
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// functions contains the standard PromQL functions plus the Chronosphere custom functions,
+// so queries using them can still be parsed.
+var functions = func() map[string]*parser.Function {
+	fns := make(map[string]*parser.Function, len(parser.Functions)+10)
+	for name, fn := range parser.Functions {
+		fns[name] = fn
+	}
+	fns["cardinality_estimate"] = &parser.Function{
+		Name:       "cardinality_estimate",
+		ArgTypes:   []parser.ValueType{parser.ValueTypeVector},
+		ReturnType: parser.ValueTypeVector,
+	}
+	fns["sum_per_second"] = &parser.Function{
+		Name:       "sum_per_second",
+		ArgTypes:   []parser.ValueType{parser.ValueTypeMatrix},
+		ReturnType: parser.ValueTypeVector,
+	}
+	for _, prefix := range []string{"head", "tail"} {
+		for _, agg := range []string{"avg", "max", "min", "sum"} {
+			name := prefix + "_" + agg
+			fns[name] = &parser.Function{
+				Name:       name,
+				ArgTypes:   []parser.ValueType{parser.ValueTypeVector, parser.ValueTypeScalar},
+				ReturnType: parser.ValueTypeVector,
+			}
+		}
+	}
+	return fns
+}()
+
+// Parse parses a PromQL expression, accepting Chronosphere custom functions.
+func Parse(query string) (parser.Expr, error) {
+	p := parser.NewParser(query, parser.WithFunctions(functions))
+	defer p.Close()
+	return p.ParseExpr()
+}
+
+var (
+	// variableRe matches dashboard variables in the $var, ${var} and [[var]] forms.
+	variableRe = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)(?::[^}]*)?\}|\$([a-zA-Z_][a-zA-Z0-9_]*)|\[\[([a-zA-Z_][a-zA-Z0-9_]*)\]\]`)
+
+	// variableMatcherRe matches equality and inequality matchers whose value references a
+	// dashboard variable.
+	variableMatcherRe = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*\s*)(!?)=(\s*"[^"]*(?:\$|\[\[)[^"]*")`)
+
+	// identifierRe matches tokens that could be metric names.
+	identifierRe = regexp.MustCompile(`[a-zA-Z_:][a-zA-Z0-9_:]*`)
+)
+
+// intervalVariables are built-in dashboard variables that must be substituted with a duration.
+var intervalVariables = map[string]string{
+	"__interval":      "1m",
+	"__rate_interval": "5m",
+	"__range":         "1h",
+	"interval":        "1m",
+}
+
+// VariablePlaceholder is substituted for dashboard variables that have no value.
+const VariablePlaceholder = "placeholder"
+
+// ReplaceVariables substitutes dashboard variables in the query with the given values.
+// Built-in interval variables default to a sensible duration, and any other variable
+// without a value is replaced with VariablePlaceholder so the query remains parseable.
+func ReplaceVariables(query string, values map[string]string) string {
+	return variableRe.ReplaceAllStringFunc(query, func(match string) string {
+		groups := variableRe.FindStringSubmatch(match)
+		name := groups[1] + groups[2] + groups[3]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		if v, ok := intervalVariables[name]; ok {
+			return v
+		}
+		return VariablePlaceholder
+	})
+}
+
+// ReplaceVariablesAsRegex substitutes dashboard variables like ReplaceVariables, after turning
+// equality matchers that reference a variable into regex matchers. Values are used as
+// regular expressions, so a variable can select several values, e.g. "api|web", or all
+// values with ".*".
+func ReplaceVariablesAsRegex(query string, values map[string]string) string {
+	query = variableMatcherRe.ReplaceAllStringFunc(query, func(match string) string {
+		groups := variableMatcherRe.FindStringSubmatch(match)
+		if groups[2] == "!" {
+			return groups[1] + "!~" + groups[3]
+		}
+		return groups[1] + "=~" + groups[3]
+	})
+	return ReplaceVariables(query, values)
+}
+
+// HasVariables returns whether the query references any dashboard variables.
+func HasVariables(query string) bool {
+	return variableRe.MatchString(query)
+}
+
+// Selectors returns the label matchers of every vector selector in the query.
+func Selectors(query string) ([][]*labels.Matcher, error) {
+	expr, err := Parse(ReplaceVariables(query, nil))
+	if err != nil {
+		return nil, err
+	}
+	return parser.ExtractSelectors(expr), nil
+}
+
+// SeriesLabels parses a series in selector form, e.g. http_requests_total{job="api"},
+// into its label set. Only equality matchers are allowed.
+func SeriesLabels(series string) (map[string]string, error) {
+	expr, err := Parse(series)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse series %q: %w", series, err)
+	}
+	vs, ok := expr.(*parser.VectorSelector)
+	if !ok {
+		return nil, fmt.Errorf("series %q must be a single series selector", series)
+	}
+	lbls := make(map[string]string, len(vs.LabelMatchers))
+	for _, m := range vs.LabelMatchers {
+		if m.Type != labels.MatchEqual {
+			return nil, fmt.Errorf("series %q must only use equality matchers, got %s", series, m)
+		}
+		lbls[m.Name] = m.Value
+	}
+	return lbls, nil
+}
+
+// WithMatchers adds the matchers to every vector selector in the query and returns the
+// resulting query.
+func WithMatchers(query string, matchers []*labels.Matcher) (string, error) {
+	if len(matchers) == 0 {
+		return query, nil
+	}
+	expr, err := Parse(query)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse query %q: %w", query, err)
+	}
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if vs, ok := node.(*parser.VectorSelector); ok {
+			vs.LabelMatchers = append(vs.LabelMatchers, matchers...)
+		}
+		return nil
+	})
+	return expr.String(), nil
+}
+
+// MetricNames returns the sorted, de-duplicated metric names referenced by the query.
+// If the query cannot be parsed, identifiers are extracted lexically instead, so the
+// result may contain false positives for unparseable queries.
+func MetricNames(query string) []string {
+	selectors, err := Selectors(query)
+	if err != nil {
+		return lexicalMetricNames(query)
+	}
+	names := make(map[string]struct{})
+	for _, matchers := range selectors {
+		for _, m := range matchers {
+			if m.Name == labels.MetricName && m.Type == labels.MatchEqual {
+				names[m.Value] = struct{}{}
+			}
+		}
+	}
+	return sortedKeys(names)
+}
+
+// LabelNames returns the sorted, de-duplicated label names referenced by the query,
+// either in selectors or in grouping and vector matching clauses.
+func LabelNames(query string) ([]string, error) {
+	expr, err := Parse(ReplaceVariables(query, nil))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{})
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			for _, m := range n.LabelMatchers {
+				if m.Name != labels.MetricName {
+					names[m.Name] = struct{}{}
+				}
+			}
+		case *parser.AggregateExpr:
+			for _, l := range n.Grouping {
+				names[l] = struct{}{}
+			}
+		case *parser.BinaryExpr:
+			if n.VectorMatching != nil {
+				for _, l := range n.VectorMatching.MatchingLabels {
+					names[l] = struct{}{}
+				}
+				for _, l := range n.VectorMatching.Include {
+					names[l] = struct{}{}
+				}
+			}
+		}
+		return nil
+	})
+	return sortedKeys(names), nil
+}
+
+// MatchesMetric returns whether any selector in the query could select the given metric name.
+// Selectors matching on __name__ with regular expressions are evaluated against the name.
+func MatchesMetric(query string, metric string) bool {
+	selectors, err := Selectors(query)
+	if err != nil {
+		for _, name := range lexicalMetricNames(query) {
+			if name == metric {
+				return true
+			}
+		}
+		return false
+	}
+	for _, matchers := range selectors {
+		for _, m := range matchers {
+			if m.Name == labels.MetricName && m.Matches(metric) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lexicalMetricNames extracts identifiers outside of string literals that are not
+// followed by an opening parenthesis and are not PromQL keywords.
+func lexicalMetricNames(query string) []string {
+	stripped := stripStrings(ReplaceVariables(query, nil))
+	names := make(map[string]struct{})
+	for _, loc := range identifierRe.FindAllStringIndex(stripped, -1) {
+		name := stripped[loc[0]:loc[1]]
+		if _, ok := keywords[strings.ToLower(name)]; ok || name == VariablePlaceholder {
+			continue
+		}
+		if _, ok := functions[name]; ok {
+			continue
+		}
+		if loc[0] > 0 && strings.ContainsAny(stripped[loc[0]-1:loc[0]], "0123456789.[") {
+			// Part of a number or a duration such as 5m.
+			continue
+		}
+		rest := strings.TrimLeft(stripped[loc[1]:], " \t\n")
+		if strings.HasPrefix(rest, "(") || strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, "!=") || strings.HasPrefix(rest, "!~") {
+			continue
+		}
+		names[name] = struct{}{}
+	}
+	return sortedKeys(names)
+}
+
+// stripStrings blanks out quoted string literals and the contents of grouping clauses.
+func stripStrings(query string) string {
+	var (
+		sb      strings.Builder
+		quote   rune
+		escaped bool
+	)
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == quote:
+				quote = 0
+			}
+			sb.WriteRune(' ')
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+			sb.WriteRune(' ')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return groupingRe.ReplaceAllString(sb.String(), " ")
+}
+
+var groupingRe = regexp.MustCompile(`(?i)\b(by|without|on|ignoring|group_left|group_right)\s*\([^)]*\)`)
+
+var keywords = map[string]struct{}{
+	"by": {}, "without": {}, "on": {}, "ignoring": {}, "group_left": {}, "group_right": {},
+	"bool": {}, "offset": {}, "and": {}, "or": {}, "unless": {}, "atan2": {},
+	"sum": {}, "avg": {}, "min": {}, "max": {}, "count": {}, "stddev": {}, "stdvar": {},
+	"topk": {}, "bottomk": {}, "quantile": {}, "count_values": {}, "group": {},
+	"inf": {}, "nan": {}, "start": {}, "end": {},
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceVariables(t *testing.T) {
+	testCases := []struct {
+		name   string
+		query  string
+		values map[string]string
+		want   string
+	}{
+		{
+			name:   "dollar form",
+			query:  `rate(http_requests_total{service="$service"}[5m])`,
+			values: map[string]string{"service": "api"},
+			want:   `rate(http_requests_total{service="api"}[5m])`,
+		},
+		{
+			name:   "brace form with format",
+			query:  `up{env=~"${env:regex}"}`,
+			values: map[string]string{"env": "prod|dev"},
+			want:   `up{env=~"prod|dev"}`,
+		},
+		{
+			name:  "bracket form without value",
+			query: `up{env="[[env]]"}`,
+			want:  `up{env="placeholder"}`,
+		},
+		{
+			name:  "interval defaults",
+			query: `rate(up[$__rate_interval])`,
+			want:  `rate(up[5m])`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, ReplaceVariables(tc.query, tc.values))
+		})
+	}
+}
+
+func TestMetricNames(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "binary expression",
+			query: `sum(rate(errors_total{job="api"}[5m])) / sum(rate(requests_total{job="api"}[5m]))`,
+			want:  []string{"errors_total", "requests_total"},
+		},
+		{
+			name:  "chronosphere function",
+			query: `cardinality_estimate(up)`,
+			want:  []string{"up"},
+		},
+		{
+			name:  "dashboard variables",
+			query: `sum by ($group) (rate(http_requests_total{service="$service"}[$__rate_interval]))`,
+			want:  []string{"http_requests_total"},
+		},
+		{
+			name:  "unparseable query falls back to lexical scan",
+			query: `sum by (job) (rate(http_requests_total{job="api"}[5m]) +`,
+			want:  []string{"http_requests_total"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, MetricNames(tc.query))
+		})
+	}
+}
+
+func TestLabelNames(t *testing.T) {
+	names, err := LabelNames(`sum by (service) (rate(requests_total{code=~"5.."}[5m])) / on (service) group_left (team) up`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"code", "service", "team"}, names)
+}
+
+func TestMatchesMetric(t *testing.T) {
+	require.True(t, MatchesMetric(`rate(http_requests_total[5m])`, "http_requests_total"))
+	require.True(t, MatchesMetric(`{__name__=~"http_.*"}`, "http_requests_total"))
+	require.False(t, MatchesMetric(`rate(http_requests_total[5m])`, "http_errors_total"))
+}
+
+func TestSeriesLabels(t *testing.T) {
+	lbls, err := SeriesLabels(`http_requests_total{job="api",code="200"}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"__name__": "http_requests_total", "job": "api", "code": "200"}, lbls)
+
+	_, err = SeriesLabels(`http_requests_total{job=~"api.*"}`)
+	require.ErrorContains(t, err, "must only use equality matchers")
+
+	_, err = SeriesLabels(`rate(http_requests_total[5m])`)
+	require.ErrorContains(t, err, "must be a single series selector")
+}
+
+func TestWithMatchers(t *testing.T) {
+	matchers := []*labels.Matcher{
+		labels.MustNewMatcher(labels.MatchNotRegexp, "cluster", "dev.*"),
+	}
+	got, err := WithMatchers(`sum(rate(http_requests_total{code!~"5.."}[5m])) / sum(rate(http_requests_total[5m]))`, matchers)
+	require.NoError(t, err)
+	require.Equal(t,
+		`sum(rate(http_requests_total{cluster!~"dev.*",code!~"5.."}[5m])) / sum(rate(http_requests_total{cluster!~"dev.*"}[5m]))`,
+		got)
+
+	got, err = WithMatchers("up", nil)
+	require.NoError(t, err)
+	require.Equal(t, "up", got)
+}
+
+func TestReplaceVariablesAsRegex(t *testing.T) {
+	values := map[string]string{"service": "api|web", "env": ".*"}
+	require.Equal(t,
+		`sum(rate(http_requests_total{service=~"api|web",env!~".*",code="500"}[5m]))`,
+		ReplaceVariablesAsRegex(`sum(rate(http_requests_total{service="$service",env!="${env}",code="500"}[$__rate_interval]))`, values))
+	require.Equal(t,
+		`up{service=~"api|web"}`,
+		ReplaceVariablesAsRegex(`up{service=~"$service"}`, values))
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import "slices"
+
+// Credentials redacts the fields that hold credentials in any API entity.
+var Credentials = New(CredentialFields...)
+
+// Notifiers redacts the credentials of notifiers. PagerDuty keys identify the integration and
+// allow sending events to it, while the VictorOps routing key only routes. Webhook URLs embed
+// their secret in the path, so only their host is kept. Proxy URLs in http_config may embed a
+// user and password, which are redacted like in any other URL.
+var Notifiers = New(append(slices.Clone(CredentialFields),
+	"pagerduty.routing_key",
+	"pagerduty.service_key",
+)...).WithURLs(
+	"slack.api_url",
+	"victor_ops.api_url",
+	"webhook.url",
+)
+
+// LogScaleActions redacts the credentials of LogScale actions. Webhook headers commonly carry
+// authorization, so they are redacted as a whole.
+var LogScaleActions = New(append(slices.Clone(CredentialFields),
+	"humio_action.ingest_token",
+	"ops_genie_action.ops_genie_key",
+	"pager_duty_action.routing_key",
+	"slack_post_message_action.api_token",
+	"webhook_action.headers",
+)...).WithURLs(
+	"slack_action.url",
+	"victor_ops_action.notify_url",
+	"webhook_action.url",
+)
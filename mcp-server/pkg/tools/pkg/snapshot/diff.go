@@ -0,0 +1,204 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxTextValueLength bounds how much of a changed value is shown in text diffs.
+const maxTextValueLength = 120
+
+// Diff is the difference between two snapshots.
+type Diff struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Added    []*EntityChange `json:"added"`
+	Removed  []*EntityChange `json:"removed"`
+	Modified []*EntityChange `json:"modified"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// EntityChange is an entity that was added, removed or modified.
+type EntityChange struct {
+	EntityType string `json:"entity_type"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name,omitempty"`
+	// Changes are the changed fields of a modified entity.
+	Changes []*FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is a changed field. Old is unset if the field was added and New if it was removed.
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// Empty returns whether nothing changed.
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Compare returns the entities added, removed and modified between the snapshots. If
+// entityTypes is set only those entity types are compared. Entity types that failed to list
+// or are not included in either snapshot are skipped, since their entities would otherwise
+// all appear changed.
+func Compare(from, to *Snapshot, entityTypes []string) *Diff {
+	d := &Diff{From: from.Name, To: to.Name}
+	for _, et := range EntityTypes {
+		if len(entityTypes) > 0 && !slices.Contains(entityTypes, et.Name) {
+			continue
+		}
+		if slices.Contains(from.FailedTypes, et.Name) || slices.Contains(to.FailedTypes, et.Name) {
+			d.Warnings = append(d.Warnings, fmt.Sprintf("%s entities were not compared since they could not be listed", et.Name))
+			continue
+		}
+		fromEntities, inFrom := from.Entities[et.Name]
+		toEntities, inTo := to.Entities[et.Name]
+		if inFrom != inTo {
+			// Snapshots taken before the entity type was included do not have it at all.
+			missing := from.Name
+			if inFrom {
+				missing = to.Name
+			}
+			d.Warnings = append(d.Warnings, fmt.Sprintf("%s entities were not compared since snapshot %s does not include them", et.Name, missing))
+			continue
+		}
+		for _, slug := range sortedKeys(fromEntities) {
+			if _, ok := toEntities[slug]; !ok {
+				d.Removed = append(d.Removed, newEntityChange(et.Name, slug, fromEntities[slug]))
+			}
+		}
+		for _, slug := range sortedKeys(toEntities) {
+			fields := toEntities[slug]
+			old, ok := fromEntities[slug]
+			if !ok {
+				d.Added = append(d.Added, newEntityChange(et.Name, slug, fields))
+				continue
+			}
+			var changes []*FieldChange
+			diffValues("", old, fields, &changes)
+			if len(changes) > 0 {
+				c := newEntityChange(et.Name, slug, fields)
+				c.Changes = changes
+				d.Modified = append(d.Modified, c)
+			}
+		}
+	}
+	return d
+}
+
+func newEntityChange(entityType, slug string, fields map[string]any) *EntityChange {
+	name, _ := fields["name"].(string)
+	return &EntityChange{EntityType: entityType, Slug: slug, Name: name}
+}
+
+// diffValues appends the changes between two values to changes. Objects are compared field by
+// field and lists element by element; anything else is compared as a whole.
+func diffValues(path string, old, new any, changes *[]*FieldChange) {
+	switch o := old.(type) {
+	case map[string]any:
+		if n, ok := new.(map[string]any); ok {
+			keys := sortedKeys(o)
+			for k := range n {
+				if _, ok := o[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				diffValues(joinPath(path, k), o[k], n[k], changes)
+			}
+			return
+		}
+	case []any:
+		if n, ok := new.([]any); ok {
+			for i := 0; i < max(len(o), len(n)); i++ {
+				var ov, nv any
+				if i < len(o) {
+					ov = o[i]
+				}
+				if i < len(n) {
+					nv = n[i]
+				}
+				diffValues(path+"["+strconv.Itoa(i)+"]", ov, nv, changes)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, &FieldChange{Path: path, Old: old, New: new})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Text renders the diff for humans, one line per added or removed entity and per changed field.
+func (d *Diff) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s -> %s: %d added, %d removed, %d modified\n", d.From, d.To, len(d.Added), len(d.Removed), len(d.Modified))
+	for _, c := range d.Added {
+		fmt.Fprintf(&sb, "+ %s %s\n", c.EntityType, c.Slug)
+	}
+	for _, c := range d.Removed {
+		fmt.Fprintf(&sb, "- %s %s\n", c.EntityType, c.Slug)
+	}
+	for _, c := range d.Modified {
+		fmt.Fprintf(&sb, "~ %s %s\n", c.EntityType, c.Slug)
+		for _, f := range c.Changes {
+			fmt.Fprintf(&sb, "    %s: %s -> %s\n", f.Path, textValue(f.Old), textValue(f.New))
+		}
+	}
+	for _, w := range d.Warnings {
+		fmt.Fprintf(&sb, "warning: %s\n", w)
+	}
+	return sb.String()
+}
+
+func textValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	s := string(b)
+	if len(s) > maxTextValueLength {
+		s = s[:maxTextValueLength] + "..."
+	}
+	return s
+}
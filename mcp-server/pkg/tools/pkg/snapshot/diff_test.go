@@ -0,0 +1,70 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	from := testSnapshot("20261018T000000Z", map[string]map[string]any{
+		"cpu":     {"slug": "cpu", "name": "CPU", "labels": map[string]any{"env": "prod"}},
+		"removed": {"slug": "removed", "name": "Removed"},
+		"errors": {"slug": "errors", "series_conditions": map[string]any{"conditions": []any{
+			map[string]any{"op": "GT", "value": float64(1)},
+		}}},
+	})
+	from.Entities["slo"] = map[string]map[string]any{"a": {"slug": "a"}}
+	to := testSnapshot("20261019T000000Z", map[string]map[string]any{
+		"cpu":   {"slug": "cpu", "name": "CPU", "labels": map[string]any{"env": "prod"}},
+		"added": {"slug": "added", "name": "Added"},
+		"errors": {"slug": "errors", "series_conditions": map[string]any{"conditions": []any{
+			map[string]any{"op": "GT", "value": float64(2)},
+			map[string]any{"op": "LT", "value": float64(0)},
+		}}},
+	})
+	to.FailedTypes = []string{"slo"}
+	to.Entities["notifier"] = map[string]map[string]any{"ops": {"slug": "ops"}}
+
+	diff := Compare(from, to, nil)
+	require.Equal(t, &Diff{
+		From:    from.Name,
+		To:      to.Name,
+		Added:   []*EntityChange{{EntityType: "monitor", Slug: "added", Name: "Added"}},
+		Removed: []*EntityChange{{EntityType: "monitor", Slug: "removed", Name: "Removed"}},
+		Modified: []*EntityChange{{EntityType: "monitor", Slug: "errors", Changes: []*FieldChange{
+			{Path: "series_conditions.conditions[0].value", Old: float64(1), New: float64(2)},
+			{Path: "series_conditions.conditions[1]", New: map[string]any{"op": "LT", "value": float64(0)}},
+		}}},
+		Warnings: []string{
+			"notifier entities were not compared since snapshot 20261018T000000Z does not include them",
+			"slo entities were not compared since they could not be listed",
+		},
+	}, diff)
+
+	require.Equal(t, `20261018T000000Z -> 20261019T000000Z: 1 added, 1 removed, 1 modified
++ monitor added
+- monitor removed
+~ monitor errors
+    series_conditions.conditions[0].value: 1 -> 2
+    series_conditions.conditions[1]: (unset) -> {"op":"LT","value":0}
+warning: notifier entities were not compared since snapshot 20261018T000000Z does not include them
+warning: slo entities were not compared since they could not be listed
+`, diff.Text())
+
+	require.True(t, Compare(from, to, []string{"team"}).Empty())
+}
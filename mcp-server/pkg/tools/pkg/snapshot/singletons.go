@@ -0,0 +1,106 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_allocation_config"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/log_ingest_config"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/otel_metrics_ingestion"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/resource_pools"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_behavior_config"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_tail_sampling_rules"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+// Singleton config entities exist at most once per tenant and have no slug. They are read with
+// their read endpoints and stored under the name of their entity type. A singleton that is not
+// configured is read as nil.
+
+func readResourcePools(ctx context.Context, api *configv1.ConfigV1API) (*models.Configv1ResourcePools, error) {
+	resp, err := api.ResourcePools.ReadResourcePools(&resource_pools.ReadResourcePoolsParams{Context: ctx})
+	var notFound *resource_pools.ReadResourcePoolsNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read resource pools: %s", err)
+	}
+	return resp.Payload.ResourcePools, nil
+}
+
+func readOtelMetricsIngestion(ctx context.Context, api *configv1.ConfigV1API) (*models.Configv1OtelMetricsIngestion, error) {
+	resp, err := api.OtelMetricsIngestion.ReadOtelMetricsIngestion(&otel_metrics_ingestion.ReadOtelMetricsIngestionParams{Context: ctx})
+	var notFound *otel_metrics_ingestion.ReadOtelMetricsIngestionNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read otel metrics ingestion: %s", err)
+	}
+	return resp.Payload.OtelMetricsIngestion, nil
+}
+
+func readTraceBehaviorConfig(ctx context.Context, api *configv1.ConfigV1API) (*models.Configv1TraceBehaviorConfig, error) {
+	resp, err := api.TraceBehaviorConfig.ReadTraceBehaviorConfig(&trace_behavior_config.ReadTraceBehaviorConfigParams{Context: ctx})
+	var notFound *trace_behavior_config.ReadTraceBehaviorConfigNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read trace behavior config: %s", err)
+	}
+	return resp.Payload.TraceBehaviorConfig, nil
+}
+
+func readTraceTailSamplingRules(ctx context.Context, api *configv1.ConfigV1API) (*models.Configv1TraceTailSamplingRules, error) {
+	resp, err := api.TraceTailSamplingRules.ReadTraceTailSamplingRules(&trace_tail_sampling_rules.ReadTraceTailSamplingRulesParams{Context: ctx})
+	var notFound *trace_tail_sampling_rules.ReadTraceTailSamplingRulesNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read trace tail sampling rules: %s", err)
+	}
+	return resp.Payload.TraceTailSamplingRules, nil
+}
+
+func readLogAllocationConfig(ctx context.Context, api *configv1.ConfigV1API) (*models.Configv1LogAllocationConfig, error) {
+	resp, err := api.LogAllocationConfig.ReadLogAllocationConfig(&log_allocation_config.ReadLogAllocationConfigParams{Context: ctx})
+	var notFound *log_allocation_config.ReadLogAllocationConfigNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read log allocation config: %s", err)
+	}
+	return resp.Payload.LogAllocationConfig, nil
+}
+
+func readLogIngestConfig(ctx context.Context, api *configv1.ConfigV1API) (*models.Configv1LogIngestConfig, error) {
+	resp, err := api.LogIngestConfig.ReadLogIngestConfig(&log_ingest_config.ReadLogIngestConfigParams{Context: ctx})
+	var notFound *log_ingest_config.ReadLogIngestConfigNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read log ingest config: %s", err)
+	}
+	return resp.Payload.LogIngestConfig, nil
+}
@@ -0,0 +1,465 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot takes point in time copies of config entities, stores them in a directory
+// and compares them to detect config drift.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/redact"
+)
+
+const (
+	// Live refers to the current config rather than a stored snapshot.
+	Live = "live"
+	// Latest refers to the most recent stored snapshot.
+	Latest = "latest"
+	// Previous refers to the stored snapshot before the most recent one.
+	Previous = "previous"
+
+	// nameFormat is the time format of snapshot names, which sort chronologically.
+	nameFormat = "20060102T150405Z"
+	// manifestFile holds the metadata of a stored snapshot.
+	manifestFile = "snapshot.json"
+)
+
+// serverFields are set by the server and not part of the config of an entity.
+var serverFields = []string{"created_at", "updated_at"}
+
+// embeddedJSONFields hold JSON documents as strings, which are parsed so they can be compared
+// field by field.
+var embeddedJSONFields = []string{"dashboard_json"}
+
+// EntityType is a type of config entity included in snapshots.
+type EntityType struct {
+	Name string
+	list func(ctx context.Context, api *configv1.ConfigV1API) ([]any, error)
+	// singleton entity types have at most one entity without a slug, which is stored under
+	// the name of the entity type.
+	singleton bool
+	// redactor redacts secrets before entities are stored. Defaults to redact.Credentials.
+	redactor *redact.Redactor
+}
+
+// EntityTypes are the entity types included in snapshots, which are all config entities that
+// can be listed or read without knowing their slug. Services are not included since they
+// cannot be listed.
+var EntityTypes = []EntityType{
+	{Name: "team", list: listAs(configlist.Teams)},
+	{Name: "bucket", list: listAs(configlist.Buckets)},
+	{Name: "notification_policy", list: listAs(configlist.NotificationPolicies)},
+	{Name: "notifier", list: listAs(configlist.Notifiers), redactor: redact.Notifiers},
+	{Name: "muting_rule", list: listAs(configlist.MutingRules)},
+	{Name: "collection", list: listAs(configlist.Collections)},
+	{Name: "dashboard", list: listAs(configlist.Dashboards)},
+	{Name: "classic_dashboard", list: listAs(configlist.ClassicDashboards)},
+	{Name: "grafana_dashboard", list: listAs(configlist.GrafanaDashboards)},
+	{Name: "monitor", list: listAs(configlist.Monitors)},
+	{Name: "slo", list: listAs(configlist.SLOs)},
+	{Name: "recording_rule", list: listAs(configlist.RecordingRules)},
+	{Name: "drop_rule", list: listAs(configlist.DropRules)},
+	{Name: "rollup_rule", list: listAs(configlist.RollupRules)},
+	{Name: "mapping_rule", list: listAs(configlist.MappingRules)},
+	{Name: "derived_metric", list: listAs(configlist.DerivedMetrics)},
+	{Name: "derived_label", list: listAs(configlist.DerivedLabels)},
+	{Name: "dataset", list: listAs(configlist.Datasets)},
+	{Name: "service_account", list: listAs(configlist.ServiceAccounts)},
+	{Name: "log_scale_alert", list: listAs(configlist.LogScaleAlerts)},
+	{Name: "log_scale_action", list: listAs(configlist.LogScaleActions), redactor: redact.LogScaleActions},
+	{Name: "gcp_metrics_integration", list: listAs(configlist.GcpMetricsIntegrations)},
+	{Name: "trace_behavior", list: listAs(configlist.TraceBehaviors)},
+	{Name: "trace_jaeger_remote_sampling_strategy", list: listAs(configlist.TraceJaegerRemoteSamplingStrategies)},
+	{Name: "trace_metrics_rule", list: listAs(configlist.TraceMetricsRules)},
+	{Name: "resource_pools", list: readAs(readResourcePools), singleton: true},
+	{Name: "otel_metrics_ingestion", list: readAs(readOtelMetricsIngestion), singleton: true},
+	{Name: "trace_behavior_config", list: readAs(readTraceBehaviorConfig), singleton: true},
+	{Name: "trace_tail_sampling_rules", list: readAs(readTraceTailSamplingRules), singleton: true},
+	{Name: "log_allocation_config", list: readAs(readLogAllocationConfig), singleton: true},
+	{Name: "log_ingest_config", list: readAs(readLogIngestConfig), singleton: true},
+}
+
+// EntityTypeNames returns the names of the entity types included in snapshots.
+func EntityTypeNames() []string {
+	names := make([]string, 0, len(EntityTypes))
+	for _, et := range EntityTypes {
+		names = append(names, et.Name)
+	}
+	return names
+}
+
+func listAs[T any](list func(context.Context, *configv1.ConfigV1API, configlist.Filter) ([]T, error)) func(context.Context, *configv1.ConfigV1API) ([]any, error) {
+	return func(ctx context.Context, api *configv1.ConfigV1API) ([]any, error) {
+		items, err := list(ctx, api, configlist.Filter{})
+		if err != nil {
+			return nil, err
+		}
+		entities := make([]any, 0, len(items))
+		for _, item := range items {
+			entities = append(entities, item)
+		}
+		return entities, nil
+	}
+}
+
+func readAs[T any](read func(context.Context, *configv1.ConfigV1API) (*T, error)) func(context.Context, *configv1.ConfigV1API) ([]any, error) {
+	return func(ctx context.Context, api *configv1.ConfigV1API) ([]any, error) {
+		item, err := read(ctx, api)
+		if err != nil || item == nil {
+			return nil, err
+		}
+		return []any{item}, nil
+	}
+}
+
+// Snapshot is a point in time copy of config entities.
+type Snapshot struct {
+	Name    string
+	TakenAt time.Time
+	// Entities holds the normalized fields of each entity by entity type and slug.
+	Entities map[string]map[string]map[string]any
+	// FailedTypes are the entity types that could not be listed.
+	FailedTypes []string
+	Warnings    []string
+}
+
+// Info describes a stored snapshot.
+type Info struct {
+	Name        string         `json:"name"`
+	TakenAt     time.Time      `json:"taken_at"`
+	Counts      map[string]int `json:"counts"`
+	FailedTypes []string       `json:"failed_types,omitempty"`
+	Warnings    []string       `json:"warnings,omitempty"`
+}
+
+// Info returns the metadata of the snapshot.
+func (s *Snapshot) Info() *Info {
+	counts := make(map[string]int, len(s.Entities))
+	for entityType, entities := range s.Entities {
+		counts[entityType] = len(entities)
+	}
+	return &Info{Name: s.Name, TakenAt: s.TakenAt, Counts: counts, FailedTypes: s.FailedTypes, Warnings: s.Warnings}
+}
+
+// Take lists every entity and returns a snapshot named after the current time. Secrets are
+// redacted, so changes to them are not detected. Entity types that fail to list are recorded
+// as failed; Take only fails if every entity type failed.
+func Take(ctx context.Context, api *configv1.ConfigV1API, now time.Time) (*Snapshot, error) {
+	s := &Snapshot{
+		Name:     now.UTC().Format(nameFormat),
+		TakenAt:  now.UTC(),
+		Entities: make(map[string]map[string]map[string]any, len(EntityTypes)),
+	}
+	var errs []string
+	for _, et := range EntityTypes {
+		items, err := et.list(ctx, api)
+		if err != nil {
+			s.FailedTypes = append(s.FailedTypes, et.Name)
+			errs = append(errs, err.Error())
+			continue
+		}
+		entities, warnings := et.entities(items)
+		s.Warnings = append(s.Warnings, warnings...)
+		s.Entities[et.Name] = entities
+	}
+	if len(s.FailedTypes) == len(EntityTypes) {
+		return nil, fmt.Errorf("failed to take snapshot: %s", strings.Join(errs, "; "))
+	}
+	s.Warnings = append(s.Warnings, errs...)
+	return s, nil
+}
+
+// entities redacts and normalizes the listed entities of the entity type by slug.
+func (et EntityType) entities(items []any) (map[string]map[string]any, []string) {
+	var warnings []string
+	redactor := et.redactor
+	if redactor == nil {
+		redactor = redact.Credentials
+	}
+	entities := make(map[string]map[string]any, len(items))
+	for _, item := range items {
+		redacted, _, err := redactor.Redact(item)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to redact %s: %s", et.Name, err))
+			continue
+		}
+		fields, err := Normalize(redacted)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to normalize %s: %s", et.Name, err))
+			continue
+		}
+		slug, _ := fields["slug"].(string)
+		if et.singleton {
+			slug = et.Name
+		}
+		if slug == "" {
+			warnings = append(warnings, fmt.Sprintf("skipped %s without a slug", et.Name))
+			continue
+		}
+		entities[slug] = fields
+	}
+	return entities, warnings
+}
+
+// Fields returns the fields of a config API model as they are written in config files, without
+// server managed fields and unset lists.
+func Fields(model any) (map[string]any, error) {
+	b, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for _, f := range serverFields {
+		delete(fields, f)
+	}
+	pruneNulls(fields)
+	return fields, nil
+}
+
+// Normalize returns the fields of a config API model with embedded JSON documents parsed, so
+// entities can be stored and compared field by field.
+func Normalize(model any) (map[string]any, error) {
+	fields, err := Fields(model)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range embeddedJSONFields {
+		s, ok := fields[f].(string)
+		if !ok || s == "" {
+			continue
+		}
+		var doc any
+		if err := json.Unmarshal([]byte(s), &doc); err == nil {
+			fields[f] = doc
+		}
+	}
+	return fields, nil
+}
+
+// pruneNulls removes null fields, which the config API models use for unset lists.
+func pruneNulls(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if field == nil {
+				delete(v, k)
+				continue
+			}
+			pruneNulls(field)
+		}
+	case []any:
+		for _, item := range v {
+			pruneNulls(item)
+		}
+	}
+}
+
+// Write stores the snapshot in dir as one JSON file per entity, in a directory per entity type.
+// The snapshot is written to a temporary directory first so partial snapshots are never listed.
+func Write(dir string, s *Snapshot) error {
+	tmp := filepath.Join(dir, "."+s.Name+".tmp")
+	if err := os.RemoveAll(tmp); err != nil {
+		return fmt.Errorf("failed to write snapshot: %s", err)
+	}
+	if err := write(tmp, s); err != nil {
+		_ = os.RemoveAll(tmp)
+		return fmt.Errorf("failed to write snapshot: %s", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, s.Name)); err != nil {
+		_ = os.RemoveAll(tmp)
+		return fmt.Errorf("failed to write snapshot: %s", err)
+	}
+	return nil
+}
+
+func write(dir string, s *Snapshot) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	for entityType, entities := range s.Entities {
+		typeDir := filepath.Join(dir, entityType)
+		if err := os.MkdirAll(typeDir, 0o750); err != nil {
+			return err
+		}
+		for slug, fields := range entities {
+			if err := writeJSON(filepath.Join(typeDir, url.PathEscape(slug)+".json"), fields); err != nil {
+				return err
+			}
+		}
+	}
+	return writeJSON(filepath.Join(dir, manifestFile), s.Info())
+}
+
+func writeJSON(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
+
+// List returns the snapshots stored in dir, oldest first.
+func List(dir string) ([]*Info, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %s", err)
+	}
+	var infos []*Info
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, e.Name(), manifestFile)) //nolint:gosec
+		if err != nil {
+			continue
+		}
+		var info Info
+		if err := json.Unmarshal(b, &info); err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %s: %s", e.Name(), err)
+		}
+		info.Name = e.Name()
+		infos = append(infos, &info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// Resolve returns the name of the stored snapshot ref refers to, which is a snapshot name,
+// Latest or Previous.
+func Resolve(dir, ref string) (string, error) {
+	if ref != Latest && ref != Previous {
+		return ref, nil
+	}
+	infos, err := List(dir)
+	if err != nil {
+		return "", err
+	}
+	i := len(infos) - 1
+	if ref == Previous {
+		i--
+	}
+	if i < 0 {
+		return "", fmt.Errorf("no %s snapshot in %s, %d snapshots are stored", ref, dir, len(infos))
+	}
+	return infos[i].Name, nil
+}
+
+// Load reads the stored snapshot with the name.
+func Load(dir, name string) (*Snapshot, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid snapshot name %q", name)
+	}
+	snapshotDir := filepath.Join(dir, name)
+	b, err := os.ReadFile(filepath.Join(snapshotDir, manifestFile)) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %s", name, err)
+	}
+	var info Info
+	if err := json.Unmarshal(b, &info); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %s", name, err)
+	}
+
+	s := &Snapshot{
+		Name:        name,
+		TakenAt:     info.TakenAt,
+		Entities:    make(map[string]map[string]map[string]any, len(info.Counts)),
+		FailedTypes: info.FailedTypes,
+		Warnings:    info.Warnings,
+	}
+	for entityType := range info.Counts {
+		files, err := filepath.Glob(filepath.Join(snapshotDir, entityType, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %s: %s", name, err)
+		}
+		entities := make(map[string]map[string]any, len(files))
+		for _, f := range files {
+			b, err := os.ReadFile(f) //nolint:gosec
+			if err != nil {
+				return nil, fmt.Errorf("failed to read snapshot %s: %s", name, err)
+			}
+			var fields map[string]any
+			if err := json.Unmarshal(b, &fields); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %s", f, err)
+			}
+			// Entities are stored by slug, which singletons do not have in their fields.
+			slug, err := url.PathUnescape(strings.TrimSuffix(filepath.Base(f), ".json"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %s", f, err)
+			}
+			entities[slug] = fields
+		}
+		s.Entities[entityType] = entities
+	}
+	return s, nil
+}
+
+// Prune removes the oldest snapshots in dir so that at most retain snapshots are kept, and
+// returns the names of the removed snapshots. Nothing is removed if retain is not positive.
+func Prune(dir string, retain int) ([]string, error) {
+	if retain <= 0 {
+		return nil, nil
+	}
+	infos, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for len(infos) > retain {
+		if err := os.RemoveAll(filepath.Join(dir, infos[0].Name)); err != nil {
+			return removed, fmt.Errorf("failed to remove snapshot %s: %s", infos[0].Name, err)
+		}
+		removed = append(removed, infos[0].Name)
+		infos = infos[1:]
+	}
+	return removed, nil
+}
+
+// Open returns the snapshot ref refers to, taking a snapshot of the current config for Live
+// and loading a stored snapshot otherwise.
+func Open(ctx context.Context, api *configv1.ConfigV1API, dir, ref string) (*Snapshot, error) {
+	if ref == Live {
+		s, err := Take(ctx, api, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		s.Name = Live
+		return s, nil
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("no snapshot directory configured to load snapshot %s from", ref)
+	}
+	name, err := Resolve(dir, ref)
+	if err != nil {
+		return nil, err
+	}
+	return Load(dir, name)
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/redact"
+)
+
+func TestNormalize(t *testing.T) {
+	fields, err := Normalize(&models.Configv1Dashboard{
+		Slug:          "api",
+		Name:          "API",
+		CreatedAt:     strfmt.DateTime(time.Unix(1700000000, 0)),
+		DashboardJSON: `{"kind": "Dashboard", "spec": {"panels": {}}}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"slug": "api",
+		"name": "API",
+		"dashboard_json": map[string]any{
+			"kind": "Dashboard",
+			"spec": map[string]any{"panels": map[string]any{}},
+		},
+	}, fields)
+
+	fields, err = Normalize(&models.Configv1Monitor{
+		Slug:             "cpu",
+		SeriesConditions: &models.MonitorSeriesConditions{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"slug":              "cpu",
+		"series_conditions": map[string]any{},
+	}, fields, "unset lists are dropped")
+}
+
+func TestEntities(t *testing.T) {
+	notifiers := EntityTypes[slices.IndexFunc(EntityTypes, func(et EntityType) bool { return et.Name == "notifier" })]
+	entities, warnings := notifiers.entities([]any{
+		&models.Configv1Notifier{
+			Slug:      "pager",
+			Pagerduty: &models.NotifierPagerdutyConfig{RoutingKey: "secret-key"},
+		},
+		&models.Configv1Notifier{
+			Slug:    "hook",
+			Webhook: &models.NotifierWebhookConfig{URL: "https://hooks.example.com/services/secret"},
+		},
+		&models.Configv1Notifier{Name: "no slug"},
+	})
+	require.Equal(t, map[string]map[string]any{
+		"pager": {"slug": "pager", "pagerduty": map[string]any{"routing_key": redact.Redacted}},
+		"hook":  {"slug": "hook", "webhook": map[string]any{"url": "https://hooks.example.com/" + redact.Redacted}},
+	}, entities)
+	require.Equal(t, []string{"skipped notifier without a slug"}, warnings)
+
+	pools := EntityTypes[slices.IndexFunc(EntityTypes, func(et EntityType) bool { return et.Name == "resource_pools" })]
+	entities, warnings = pools.entities([]any{&models.Configv1ResourcePools{
+		Pools: []*models.ResourcePoolsPool{{Name: "infra"}},
+	}})
+	require.Equal(t, map[string]map[string]any{
+		"resource_pools": {"pools": []any{map[string]any{"name": "infra"}}},
+	}, entities, "singletons are stored under their entity type")
+	require.Empty(t, warnings)
+}
+
+func testSnapshot(name string, monitors map[string]map[string]any) *Snapshot {
+	takenAt, _ := time.Parse(nameFormat, name)
+	return &Snapshot{
+		Name:     name,
+		TakenAt:  takenAt,
+		Entities: map[string]map[string]map[string]any{"monitor": monitors, "team": {}},
+	}
+}
+
+func TestWriteLoadPrune(t *testing.T) {
+	dir := t.TempDir()
+	first := testSnapshot("20261018T000000Z", map[string]map[string]any{
+		"cpu": {"slug": "cpu", "name": "CPU", "interval_secs": float64(60)},
+	})
+	second := testSnapshot("20261019T000000Z", map[string]map[string]any{
+		"cpu":       {"slug": "cpu", "name": "CPU", "interval_secs": float64(30)},
+		"team/odd?": {"slug": "team/odd?"},
+	})
+	second.Entities["resource_pools"] = map[string]map[string]any{"resource_pools": {"pools": []any{}}}
+	second.FailedTypes = []string{"slo"}
+	require.NoError(t, Write(dir, first))
+	require.NoError(t, Write(dir, second))
+
+	infos, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, "20261018T000000Z", infos[0].Name)
+	require.Equal(t, map[string]int{"monitor": 2, "resource_pools": 1, "team": 0}, infos[1].Counts)
+
+	for ref, expected := range map[string]string{Latest: second.Name, Previous: first.Name, first.Name: first.Name} {
+		name, err := Resolve(dir, ref)
+		require.NoError(t, err)
+		require.Equal(t, expected, name)
+	}
+
+	loaded, err := Load(dir, second.Name)
+	require.NoError(t, err)
+	require.Equal(t, second, loaded)
+
+	_, err = Load(dir, "../etc")
+	require.Error(t, err)
+
+	pruned, err := Prune(dir, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{first.Name}, pruned)
+	_, err = Resolve(dir, Previous)
+	require.Error(t, err)
+}
@@ -0,0 +1,339 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracefilter evaluates trace search filters, such as the ones used by tail sampling
+// rules and trace datasets, against a single span.
+package tracefilter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+// Span is the span filters are evaluated against. The trace is assumed to consist of this span
+// only.
+type Span struct {
+	Service         string
+	Operation       string
+	ParentService   string
+	ParentOperation string
+	// Attributes are the tags of the span. Attributes that are not given are treated as not set.
+	Attributes map[string]string
+	// DurationSecs is nil when the duration is not known.
+	DurationSecs *float64
+	Error        bool
+	IsRootSpan   bool
+}
+
+// Result is the outcome of evaluating a trace search filter against a span.
+type Result struct {
+	// Unmatched are the conditions the span does not satisfy.
+	Unmatched []string
+	// Undetermined are conditions that depend on fields of the span which are not known.
+	Undetermined []string
+}
+
+// Matched returns whether the span satisfies every condition.
+func (r Result) Matched() bool {
+	return len(r.Unmatched) == 0 && len(r.Undetermined) == 0
+}
+
+// MayMatch returns whether the span satisfies every condition that could be evaluated, but
+// some conditions could not.
+func (r Result) MayMatch() bool {
+	return len(r.Unmatched) == 0 && len(r.Undetermined) > 0
+}
+
+// Evaluate evaluates a trace search filter against a trace consisting of the span only. A nil
+// filter matches every span.
+func Evaluate(filter *models.Configv1TraceSearchFilter, span *Span) Result {
+	e := &evaluator{span: span}
+	e.evaluate(filter)
+	return Result{Unmatched: e.unmatched, Undetermined: e.undetermined}
+}
+
+// evaluator collects the conditions of a trace search filter which the span does not satisfy
+// or which can not be evaluated without more information.
+type evaluator struct {
+	span         *Span
+	unmatched    []string
+	undetermined []string
+}
+
+func (e *evaluator) evaluate(filter *models.Configv1TraceSearchFilter) {
+	if filter == nil {
+		return
+	}
+	if tf := filter.Trace; tf != nil {
+		if tf.Duration != nil {
+			if e.span.DurationSecs == nil {
+				e.undetermined = append(e.undetermined, durationCondition("trace duration", tf.Duration))
+			} else if !durationMatches(tf.Duration, *e.span.DurationSecs) {
+				e.unmatched = append(e.unmatched, durationCondition("trace duration", tf.Duration))
+			}
+		}
+		if tf.Error != nil && tf.Error.Value != e.span.Error {
+			e.unmatched = append(e.unmatched, fmt.Sprintf("trace error = %t", tf.Error.Value))
+		}
+	}
+	for i, sf := range filter.Span {
+		if sf == nil {
+			continue
+		}
+		e.evaluateSpanFilter(i, sf)
+	}
+}
+
+// evaluateSpanFilter evaluates a span filter, which for INCLUDE filters requires the trace to
+// have matching spans, and for EXCLUDE filters requires it to have none.
+func (e *evaluator) evaluateSpanFilter(index int, sf *models.TraceSearchFilterSpanFilter) {
+	spanEval := &evaluator{span: e.span}
+	spanEval.evaluateSpan(sf)
+	matched := len(spanEval.unmatched) == 0 && len(spanEval.undetermined) == 0
+	undetermined := len(spanEval.unmatched) == 0 && len(spanEval.undetermined) > 0
+
+	prefix := fmt.Sprintf("span filter %d: ", index)
+	if sf.MatchType == models.SpanFilterSpanFilterMatchTypeEXCLUDE {
+		switch {
+		case matched:
+			e.unmatched = append(e.unmatched, prefix+"excludes traces with spans matching "+spanFilterConditions(sf))
+		case undetermined:
+			for _, c := range spanEval.undetermined {
+				e.undetermined = append(e.undetermined, prefix+"not "+c)
+			}
+		}
+		return
+	}
+
+	minCount, maxCount := int32(1), int32(0)
+	if sf.SpanCount != nil {
+		minCount, maxCount = sf.SpanCount.Min, sf.SpanCount.Max
+	}
+	countMatches := func(count int32) bool {
+		return count >= minCount && (maxCount == 0 || count <= maxCount)
+	}
+	// Only the given span is evaluated, so at most one span matches.
+	switch {
+	case !countMatches(0) && !countMatches(1):
+		e.unmatched = append(e.unmatched, prefix+spanCountCondition(minCount, maxCount)+", but only the given span is evaluated")
+	case countMatches(0), matched:
+	case undetermined:
+		for _, c := range spanEval.undetermined {
+			e.undetermined = append(e.undetermined, prefix+c)
+		}
+	default:
+		for _, c := range spanEval.unmatched {
+			e.unmatched = append(e.unmatched, prefix+c)
+		}
+	}
+}
+
+// evaluateSpan evaluates the conditions of a span filter against the span.
+func (e *evaluator) evaluateSpan(sf *models.TraceSearchFilterSpanFilter) {
+	e.evaluateString("service", sf.Service, e.span.Service, true)
+	e.evaluateString("operation", sf.Operation, e.span.Operation, e.span.Operation != "")
+	// Root spans have no parent, so their parent service and operation are empty.
+	e.evaluateString("parent_service", sf.ParentService, e.span.ParentService, e.span.ParentService != "" || e.span.IsRootSpan)
+	e.evaluateString("parent_operation", sf.ParentOperation, e.span.ParentOperation, e.span.ParentOperation != "" || e.span.IsRootSpan)
+	if sf.Duration != nil {
+		if e.span.DurationSecs == nil {
+			e.undetermined = append(e.undetermined, durationCondition("duration", sf.Duration))
+		} else if !durationMatches(sf.Duration, *e.span.DurationSecs) {
+			e.unmatched = append(e.unmatched, durationCondition("duration", sf.Duration))
+		}
+	}
+	if sf.Error != nil && sf.Error.Value != e.span.Error {
+		e.unmatched = append(e.unmatched, fmt.Sprintf("error = %t", sf.Error.Value))
+	}
+	if sf.IsRootSpan != nil && sf.IsRootSpan.Value != e.span.IsRootSpan {
+		e.unmatched = append(e.unmatched, fmt.Sprintf("is_root_span = %t", sf.IsRootSpan.Value))
+	}
+	for _, tag := range sf.Tags {
+		if tag == nil {
+			continue
+		}
+		value, ok := e.span.Attributes[tag.Key]
+		if tag.Value != nil {
+			if !ok {
+				e.unmatched = append(e.unmatched, fmt.Sprintf("tag %s (not set)", stringCondition(tag.Key, tag.Value)))
+			} else {
+				e.evaluateString("tag "+tag.Key, tag.Value, value, true)
+			}
+		}
+		if tag.NumericValue != nil {
+			condition := numericCondition("tag "+tag.Key, tag.NumericValue)
+			f, err := strconv.ParseFloat(value, 64)
+			switch {
+			case !ok:
+				e.unmatched = append(e.unmatched, condition+" (not set)")
+			case err != nil:
+				e.unmatched = append(e.unmatched, condition+" (not a number)")
+			case !numericMatches(tag.NumericValue, f):
+				e.unmatched = append(e.unmatched, condition)
+			}
+		}
+	}
+}
+
+// evaluateString evaluates a string filter on a field of the span. known is whether the field
+// was given.
+func (e *evaluator) evaluateString(field string, f *models.TraceSearchFilterStringFilter, value string, known bool) {
+	if f == nil {
+		return
+	}
+	condition := stringCondition(field, f)
+	if !known {
+		e.undetermined = append(e.undetermined, condition)
+		return
+	}
+	matches, err := stringMatches(f, value)
+	if err != nil {
+		e.undetermined = append(e.undetermined, fmt.Sprintf("%s (%s)", condition, err))
+		return
+	}
+	if !matches {
+		e.unmatched = append(e.unmatched, condition)
+	}
+}
+
+// stringMatches returns whether the value matches the string filter. Regular expressions must
+// match the whole value.
+func stringMatches(f *models.TraceSearchFilterStringFilter, value string) (bool, error) {
+	switch f.Match {
+	case models.StringFilterStringFilterMatchTypeREGEX, models.StringFilterStringFilterMatchTypeREGEXNEGATION:
+		re, err := regexp.Compile("^(?:" + f.Value + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid regex: %s", err)
+		}
+		return re.MatchString(value) == (f.Match == models.StringFilterStringFilterMatchTypeREGEX), nil
+	case models.StringFilterStringFilterMatchTypeEXACTNEGATION:
+		return value != f.Value, nil
+	case models.StringFilterStringFilterMatchTypeIN:
+		return slices.Contains(f.InValues, value), nil
+	case models.StringFilterStringFilterMatchTypeNOTIN:
+		return !slices.Contains(f.InValues, value), nil
+	}
+	return value == f.Value, nil
+}
+
+func numericMatches(f *models.TraceSearchFilterNumericFilter, value float64) bool {
+	switch f.Comparison {
+	case models.NumericFilterComparisonTypeNOTEQUAL:
+		return value != f.Value
+	case models.NumericFilterComparisonTypeGREATERTHAN:
+		return value > f.Value
+	case models.NumericFilterComparisonTypeGREATERTHANOREQUAL:
+		return value >= f.Value
+	case models.NumericFilterComparisonTypeLESSTHAN:
+		return value < f.Value
+	case models.NumericFilterComparisonTypeLESSTHANOREQUAL:
+		return value <= f.Value
+	}
+	return value == f.Value
+}
+
+func durationMatches(f *models.TraceSearchFilterDurationFilter, secs float64) bool {
+	return secs >= f.MinSecs && (f.MaxSecs == 0 || secs <= f.MaxSecs)
+}
+
+func stringCondition(field string, f *models.TraceSearchFilterStringFilter) string {
+	switch f.Match {
+	case models.StringFilterStringFilterMatchTypeREGEX:
+		return fmt.Sprintf("%s =~ %q", field, f.Value)
+	case models.StringFilterStringFilterMatchTypeREGEXNEGATION:
+		return fmt.Sprintf("%s !~ %q", field, f.Value)
+	case models.StringFilterStringFilterMatchTypeEXACTNEGATION:
+		return fmt.Sprintf("%s != %q", field, f.Value)
+	case models.StringFilterStringFilterMatchTypeIN:
+		return fmt.Sprintf("%s in %q", field, f.InValues)
+	case models.StringFilterStringFilterMatchTypeNOTIN:
+		return fmt.Sprintf("%s not in %q", field, f.InValues)
+	}
+	return fmt.Sprintf("%s = %q", field, f.Value)
+}
+
+func numericCondition(field string, f *models.TraceSearchFilterNumericFilter) string {
+	op := map[models.NumericFilterComparisonType]string{
+		models.NumericFilterComparisonTypeNOTEQUAL:           "!=",
+		models.NumericFilterComparisonTypeGREATERTHAN:        ">",
+		models.NumericFilterComparisonTypeGREATERTHANOREQUAL: ">=",
+		models.NumericFilterComparisonTypeLESSTHAN:           "<",
+		models.NumericFilterComparisonTypeLESSTHANOREQUAL:    "<=",
+	}[f.Comparison]
+	if op == "" {
+		op = "=="
+	}
+	return fmt.Sprintf("%s %s %g", field, op, f.Value)
+}
+
+func durationCondition(field string, f *models.TraceSearchFilterDurationFilter) string {
+	if f.MaxSecs == 0 {
+		return fmt.Sprintf("%s >= %gs", field, f.MinSecs)
+	}
+	return fmt.Sprintf("%gs <= %s <= %gs", f.MinSecs, field, f.MaxSecs)
+}
+
+func spanCountCondition(minCount, maxCount int32) string {
+	if maxCount == 0 {
+		return fmt.Sprintf("requires at least %d matching spans", minCount)
+	}
+	return fmt.Sprintf("requires %d to %d matching spans", minCount, maxCount)
+}
+
+// spanFilterConditions summarizes the conditions of a span filter.
+func spanFilterConditions(sf *models.TraceSearchFilterSpanFilter) string {
+	var conditions []string
+	for _, c := range []struct {
+		field  string
+		filter *models.TraceSearchFilterStringFilter
+	}{
+		{"service", sf.Service},
+		{"operation", sf.Operation},
+		{"parent_service", sf.ParentService},
+		{"parent_operation", sf.ParentOperation},
+	} {
+		if c.filter != nil {
+			conditions = append(conditions, stringCondition(c.field, c.filter))
+		}
+	}
+	if sf.Duration != nil {
+		conditions = append(conditions, durationCondition("duration", sf.Duration))
+	}
+	if sf.Error != nil {
+		conditions = append(conditions, fmt.Sprintf("error = %t", sf.Error.Value))
+	}
+	if sf.IsRootSpan != nil {
+		conditions = append(conditions, fmt.Sprintf("is_root_span = %t", sf.IsRootSpan.Value))
+	}
+	for _, tag := range sf.Tags {
+		if tag == nil {
+			continue
+		}
+		if tag.Value != nil {
+			conditions = append(conditions, stringCondition("tag "+tag.Key, tag.Value))
+		}
+		if tag.NumericValue != nil {
+			conditions = append(conditions, numericCondition("tag "+tag.Key, tag.NumericValue))
+		}
+	}
+	if len(conditions) == 0 {
+		return "any span"
+	}
+	return strings.Join(conditions, ", ")
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracefilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+)
+
+func TestEvaluateSpanCount(t *testing.T) {
+	filter := &models.Configv1TraceSearchFilter{Span: []*models.TraceSearchFilterSpanFilter{{
+		Service:   &models.TraceSearchFilterStringFilter{Value: "api"},
+		SpanCount: &models.TraceSearchFilterCountFilter{Min: 3},
+	}}}
+	result := Evaluate(filter, &Span{Service: "api"})
+	require.Equal(t, []string{"span filter 0: requires at least 3 matching spans, but only the given span is evaluated"}, result.Unmatched)
+
+	filter.Span[0].SpanCount = &models.TraceSearchFilterCountFilter{Min: 0, Max: 2}
+	result = Evaluate(filter, &Span{Service: "web"})
+	require.True(t, result.Matched())
+}
@@ -151,8 +151,70 @@ func addStyledLines(p *plot.Plot, pts []any) error {
 	return nil
 }
 
+// Threshold is a horizontal line drawn across a rendered graph, such as a monitor condition.
+type Threshold struct {
+	Name  string
+	Value float64
+	// Color of the line. Defaults to red.
+	Color color.Color
+}
+
+var defaultThresholdColor = color.RGBA{R: 231, G: 76, B: 60, A: 255}
+
+// addThresholdLines adds dashed horizontal lines for the thresholds spanning the series time range.
+func addThresholdLines(p *plot.Plot, series model.Matrix, thresholds []Threshold) error {
+	if len(thresholds) == 0 {
+		return nil
+	}
+	var minX, maxX float64
+	first := true
+	for _, s := range series {
+		for _, v := range s.Values {
+			x := float64(v.Timestamp.Unix())
+			if first || x < minX {
+				minX = x
+			}
+			if first || x > maxX {
+				maxX = x
+			}
+			first = false
+		}
+	}
+	if first {
+		return nil
+	}
+	for _, th := range thresholds {
+		line, err := plotter.NewLine(plotter.XYs{{X: minX, Y: th.Value}, {X: maxX, Y: th.Value}})
+		if err != nil {
+			return err
+		}
+		line.Color = th.Color
+		if line.Color == nil {
+			line.Color = defaultThresholdColor
+		}
+		line.Width = vg.Points(1.5)
+		line.Dashes = []vg.Length{vg.Points(6), vg.Points(4)}
+		p.Add(line)
+		if th.Name != "" {
+			p.Legend.Add(th.Name, line)
+		}
+	}
+	return nil
+}
+
 // RenderSeries renders a time series graph for the given series.
 func (r *Renderer) RenderSeries(w io.Writer, series model.Matrix, ws, hs int, legend bool) error {
+	return r.RenderSeriesWithThresholds(w, series, nil, ws, hs, legend)
+}
+
+// RenderSeriesWithThresholds renders a time series graph for the given series with horizontal threshold lines.
+func (r *Renderer) RenderSeriesWithThresholds(
+	w io.Writer,
+	series model.Matrix,
+	thresholds []Threshold,
+	ws, hs int,
+	legend bool,
+) error {
 	p := plot.New()
 	p.Legend.Top = true
 
@@ -165,6 +227,10 @@ func (r *Renderer) RenderSeries(w io.Writer, series model.Matrix, ws, hs int, le
 		return err
 	}
 
+	if err := addThresholdLines(p, series, thresholds); err != nil {
+		return err
+	}
+
 	// Configure X-axis with ISO 8601 timestamp formatter and label
 	p.X.Tick.Marker = &timeTickMarker{}
 	p.X.Label.Text = "Time (UTC)"
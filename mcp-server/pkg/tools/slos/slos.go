@@ -0,0 +1,107 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slos provides tools for evaluating the current status of SLOs.
+package slos
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/links"
+)
+
+var _ tools.MCPTools = (*Tools)(nil)
+
+// Tools provides MCP tools for SLO status.
+type Tools struct {
+	logger      *zap.Logger
+	configAPI   *configv1.ConfigV1API
+	promAPI     v1.API
+	linkBuilder *links.Builder
+}
+
+// NewTools creates new SLO tools.
+func NewTools(
+	configAPI *configv1.ConfigV1API,
+	promClient api.Client,
+	logger *zap.Logger,
+	linkBuilder *links.Builder,
+) (*Tools, error) {
+	logger.Info("slo tool configured")
+	return &Tools{
+		logger:      logger,
+		configAPI:   configAPI,
+		promAPI:     v1.NewAPI(promClient),
+		linkBuilder: linkBuilder,
+	}, nil
+}
+
+func (t *Tools) GroupName() string {
+	return "slos"
+}
+
+func (t *Tools) MCPTools() []tools.MCPTool {
+	return []tools.MCPTool{
+		{
+			Metadata: tools.NewMetadata("get_slo_status",
+				mcp.WithDescription(`Evaluates the current status of an SLO. Use this to find out whether an SLO is being met and how fast its error budget is being spent, unlike get_slo which only returns configuration.
+
+The good (or bad) and total queries are built from the SLI definition, with the additional PromQL filters applied, and evaluated through the Prometheus API. Only custom indicators are supported, since templated indicators are built from discovery metadata that is not available through the API. SLOs with a templated indicator are returned without a status and with a warning.
+
+Response fields:
+- objective: Target percentage of good events
+- attainment: Percentage of good events over the SLO time window
+- error_budget_remaining: Percentage of the error budget left over the SLO time window; negative once the budget is exhausted
+- burn_rates: Per window (1h, 6h, 3d), the error ratio and the burn rate, i.e. how many times faster than allowed the budget is being spent
+- groups: If the SLI has custom dimensions and include_groups is set, the same statistics per dimension value, ordered by budget remaining
+- queries: Error ratio query per window`),
+				mcp.WithString("slug",
+					mcp.Description("Slug of the SLO."),
+					mcp.Required(),
+				),
+				mcp.WithBoolean("include_groups",
+					mcp.Description("Whether to evaluate the status per custom dimension. Default is false."),
+				),
+			),
+			Handler: t.getSLOStatus,
+		},
+		{
+			Metadata: tools.NewMetadata("list_slo_status",
+				mcp.WithDescription(`Evaluates all SLOs of teams or collections and ranks them by error budget remaining, least first. Use this to find the SLOs most at risk.
+
+Each SLO is evaluated the same way as get_slo_status. SLOs that cannot be evaluated are listed with an error. SLOs with a templated indicator are not supported; they are listed without a status and named in warnings.
+
+Response fields:
+- slos: Per SLO, the objective, attainment, error budget remaining and burn rates, ordered by error budget remaining
+- warnings: SLOs that were not evaluated, such as SLOs with a templated indicator`),
+				params.WithStringArray("team_slugs",
+					mcp.Description("Teams whose SLOs are evaluated, including SLOs owned by the team's collections and services."),
+				),
+				params.WithStringArray("collection_slugs",
+					mcp.Description("Collections or services whose SLOs are evaluated."),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description("Maximum number of SLOs to return. Default is 50."),
+				),
+			),
+			Handler: t.listSLOStatus,
+		},
+	}
+}
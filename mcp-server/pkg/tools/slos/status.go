@@ -0,0 +1,505 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	prommodel "github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"go.uber.org/zap"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/s_l_o"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/service"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/configlist"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/promql"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+// burnRateWindows are the windows burn rates are reported for.
+var burnRateWindows = []string{"1h", "6h", "3d"}
+
+// errTemplatedIndicator is returned for SLIs with a templated indicator, whose queries are built
+// from discovery metadata that is not available through the API.
+var errTemplatedIndicator = errors.New("only custom indicators can be evaluated")
+
+// templatedIndicatorWarning is the warning of SLOs that are not evaluated since they have a
+// templated indicator.
+const templatedIndicatorWarning = "the SLI uses a templated indicator, which is not supported, so only the configuration is returned"
+
+// SLOStatus is the evaluated status of an SLO.
+type SLOStatus struct {
+	Slug                 string            `json:"slug"`
+	Name                 string            `json:"name,omitempty"`
+	CollectionType       string            `json:"collection_type,omitempty"`
+	CollectionSlug       string            `json:"collection_slug,omitempty"`
+	Objective            float64           `json:"objective"`
+	TimeWindow           string            `json:"time_window"`
+	Attainment           *float64          `json:"attainment"`
+	ErrorBudgetRemaining *float64          `json:"error_budget_remaining"`
+	BurnRates            []*BurnRate       `json:"burn_rates,omitempty"`
+	Groups               []*GroupStatus    `json:"groups,omitempty"`
+	Queries              map[string]string `json:"queries,omitempty"`
+	Error                string            `json:"error,omitempty"`
+	Warnings             []string          `json:"warnings,omitempty"`
+}
+
+// SLOStatusList is the result of list_slo_status.
+type SLOStatusList struct {
+	TotalSLOs int          `json:"total_slos"`
+	SLOs      []*SLOStatus `json:"slos"`
+	Warnings  []string     `json:"warnings,omitempty"`
+}
+
+// GroupStatus is the status of an SLO for one combination of custom dimension values.
+type GroupStatus struct {
+	Labels               map[string]string `json:"labels"`
+	Attainment           *float64          `json:"attainment"`
+	ErrorBudgetRemaining *float64          `json:"error_budget_remaining"`
+	BurnRates            []*BurnRate       `json:"burn_rates,omitempty"`
+}
+
+// BurnRate is how fast the error budget is spent over a window.
+type BurnRate struct {
+	Window     string   `json:"window"`
+	ErrorRatio *float64 `json:"error_ratio"`
+	BurnRate   *float64 `json:"burn_rate"`
+}
+
+// sliQueries builds the queries of a custom indicator SLI.
+type sliQueries struct {
+	good        string
+	bad         string
+	total       string
+	groupBy     []string
+	filters     []*labels.Matcher
+	lensService string
+}
+
+// templateData holds the variables available to custom indicator query templates.
+type templateData struct {
+	Window      string
+	GroupBy     string
+	LensService string
+}
+
+func (t *Tools) getSLOStatus(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	slug, err := params.String(request, "slug", true, "")
+	if err != nil {
+		return nil, err
+	}
+	includeGroups, err := params.Bool(request, "include_groups", false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.configAPI.Slo.ReadSLO(&s_l_o.ReadSLOParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SLO %s: %s", slug, err)
+	}
+	slo := resp.Payload.Slo
+	now := time.Now()
+	status, err := t.evaluateSLO(ctx, slo, now, includeGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &tools.Result{
+		JSONContent: status,
+	}
+	if q, ok := status.Queries[burnRateWindows[0]]; ok {
+		window, _ := prommodel.ParseDuration(status.TimeWindow)
+		result.ChronosphereLink = t.linkBuilder.MetricExplorer().
+			WithQuery(q).
+			WithTimeRange(now.Add(-time.Duration(window)), now).
+			String()
+	}
+	return result, nil
+}
+
+func (t *Tools) listSLOStatus(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	teamSlugs, err := params.StringArray(request, "team_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	collectionSlugs, err := params.StringArray(request, "collection_slugs", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := params.Int(request, "limit", false, 50)
+	if err != nil {
+		return nil, err
+	}
+	if len(teamSlugs) == 0 && len(collectionSlugs) == 0 {
+		return nil, fmt.Errorf("at least one of team_slugs or collection_slugs must be provided")
+	}
+
+	owners := make(map[string]bool)
+	for _, slug := range collectionSlugs {
+		owners[slug] = true
+	}
+	teams := make(map[string]bool)
+	if len(teamSlugs) > 0 {
+		for _, slug := range teamSlugs {
+			teams[slug] = true
+		}
+		collections, err := configlist.Collections(ctx, t.configAPI, configlist.Filter{TeamSlugs: teamSlugs})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range collections {
+			owners[c.Slug] = true
+		}
+	}
+
+	slos, err := configlist.SLOs(ctx, t.configAPI, configlist.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	serviceTeams := make(map[string]string)
+	now := time.Now()
+	var (
+		statuses  []*SLOStatus
+		templated []string
+	)
+	for _, slo := range slos {
+		if slo.CollectionRef == nil {
+			continue
+		}
+		owned := owners[slo.CollectionRef.Slug]
+		if !owned && len(teams) > 0 && slo.CollectionRef.Type == models.Configv1CollectionReferenceTypeSERVICE {
+			team, ok := serviceTeams[slo.CollectionRef.Slug]
+			if !ok {
+				team = t.serviceTeam(ctx, slo.CollectionRef.Slug)
+				serviceTeams[slo.CollectionRef.Slug] = team
+			}
+			owned = teams[team]
+		}
+		if !owned {
+			continue
+		}
+		status, err := t.evaluateSLO(ctx, slo, now, false)
+		if err != nil {
+			status = newSLOStatus(slo)
+			status.Error = err.Error()
+		}
+		if slices.Contains(status.Warnings, templatedIndicatorWarning) {
+			templated = append(templated, slo.Slug)
+		}
+		// Queries are omitted from the listing to keep it compact.
+		status.Queries = nil
+		statuses = append(statuses, status)
+	}
+	sortByBudgetRemaining(statuses)
+	total := len(statuses)
+	if limit > 0 && len(statuses) > limit {
+		statuses = statuses[:limit]
+	}
+
+	result := &SLOStatusList{
+		TotalSLOs: total,
+		SLOs:      statuses,
+	}
+	if len(templated) > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"%d SLOs use templated indicators, which are not supported, and are listed without a status: %s",
+			len(templated), strings.Join(templated, ", ")))
+	}
+	return &tools.Result{JSONContent: result}, nil
+}
+
+// serviceTeam returns the team owning a service. Failures are logged since the SLO is then
+// only skipped.
+func (t *Tools) serviceTeam(ctx context.Context, slug string) string {
+	resp, err := t.configAPI.Service.ReadService(&service.ReadServiceParams{
+		Context: ctx,
+		Slug:    slug,
+	})
+	if err != nil || resp.Payload.Service == nil {
+		t.logger.Warn("failed to read service", zap.String("slug", slug), zap.Error(err))
+		return ""
+	}
+	return resp.Payload.Service.TeamSlug
+}
+
+// evaluateSLO evaluates the error ratio of an SLO over its time window and each burn rate
+// window.
+func (t *Tools) evaluateSLO(
+	ctx context.Context,
+	slo *models.Configv1SLO,
+	now time.Time,
+	includeGroups bool,
+) (*SLOStatus, error) {
+	status := newSLOStatus(slo)
+	queries, err := newSLIQueries(slo)
+	if errors.Is(err, errTemplatedIndicator) {
+		status.Warnings = append(status.Warnings, templatedIndicatorWarning)
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status.Objective <= 0 || status.Objective >= 100 {
+		return nil, fmt.Errorf("SLO %s has an invalid objective %v", slo.Slug, status.Objective)
+	}
+	allowed := 1 - status.Objective/100
+
+	windows := append([]string{status.TimeWindow}, burnRateWindows...)
+	status.Queries = make(map[string]string, len(windows))
+	ratios := make(map[string]*float64, len(windows))
+	groupRatios := make(map[string]map[string]*float64)
+	groupLabels := make(map[string]map[string]string)
+	for _, window := range windows {
+		q, err := queries.errorRatio(window, nil)
+		if err != nil {
+			return nil, err
+		}
+		status.Queries[window] = q
+		vector, err := t.query(ctx, q, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(vector) > 0 {
+			ratios[window] = finite(float64(vector[0].Value))
+		}
+
+		if !includeGroups || len(queries.groupBy) == 0 {
+			continue
+		}
+		q, err = queries.errorRatio(window, queries.groupBy)
+		if err != nil {
+			return nil, err
+		}
+		vector, err = t.query(ctx, q, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range vector {
+			key := sample.Metric.String()
+			if _, ok := groupRatios[key]; !ok {
+				groupRatios[key] = make(map[string]*float64)
+				lbls := make(map[string]string, len(sample.Metric))
+				for k, v := range sample.Metric {
+					lbls[string(k)] = string(v)
+				}
+				groupLabels[key] = lbls
+			}
+			groupRatios[key][window] = finite(float64(sample.Value))
+		}
+	}
+	if includeGroups && len(queries.groupBy) == 0 {
+		status.Warnings = append(status.Warnings, "the SLI has no custom dimensions to group by")
+	}
+
+	status.Attainment, status.ErrorBudgetRemaining, status.BurnRates = budgetStatus(ratios, status.TimeWindow, allowed)
+	for key, r := range groupRatios {
+		g := &GroupStatus{Labels: groupLabels[key]}
+		g.Attainment, g.ErrorBudgetRemaining, g.BurnRates = budgetStatus(r, status.TimeWindow, allowed)
+		status.Groups = append(status.Groups, g)
+	}
+	sort.SliceStable(status.Groups, func(i, j int) bool {
+		return lessRemaining(status.Groups[i].ErrorBudgetRemaining, status.Groups[j].ErrorBudgetRemaining)
+	})
+	if status.Attainment == nil {
+		status.Warnings = append(status.Warnings, "the SLI queries returned no data over the time window")
+	}
+	return status, nil
+}
+
+func (t *Tools) query(ctx context.Context, q string, ts time.Time) (prommodel.Vector, error) {
+	resp, _, err := t.promAPI.Query(ctx, q, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %q: %s", q, err)
+	}
+	vector, ok := resp.(prommodel.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result from prometheus server for %q", q)
+	}
+	return vector, nil
+}
+
+func newSLOStatus(slo *models.Configv1SLO) *SLOStatus {
+	status := &SLOStatus{
+		Slug: slo.Slug,
+		Name: slo.Name,
+	}
+	if slo.CollectionRef != nil {
+		status.CollectionType = string(slo.CollectionRef.Type)
+		status.CollectionSlug = slo.CollectionRef.Slug
+	}
+	if slo.Definition != nil {
+		status.Objective = slo.Definition.Objective
+		if slo.Definition.TimeWindow != nil {
+			status.TimeWindow = slo.Definition.TimeWindow.Duration
+		}
+	}
+	if status.TimeWindow == "" {
+		status.TimeWindow = "28d"
+	}
+	return status
+}
+
+// budgetStatus derives the attainment, error budget remaining and burn rates from the error
+// ratio per window. allowed is the error ratio the objective allows.
+func budgetStatus(ratios map[string]*float64, timeWindow string, allowed float64) (*float64, *float64, []*BurnRate) {
+	var attainment, remaining *float64
+	if r := ratios[timeWindow]; r != nil {
+		attainment = ptr.To(100 * (1 - *r))
+		remaining = ptr.To(100 * (1 - *r/allowed))
+	}
+	burnRates := make([]*BurnRate, 0, len(burnRateWindows))
+	for _, window := range burnRateWindows {
+		br := &BurnRate{Window: window, ErrorRatio: ratios[window]}
+		if br.ErrorRatio != nil {
+			br.BurnRate = ptr.To(*br.ErrorRatio / allowed)
+		}
+		burnRates = append(burnRates, br)
+	}
+	return attainment, remaining, burnRates
+}
+
+func sortByBudgetRemaining(statuses []*SLOStatus) {
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return lessRemaining(statuses[i].ErrorBudgetRemaining, statuses[j].ErrorBudgetRemaining)
+	})
+}
+
+// lessRemaining orders by budget remaining ascending, with unknown budgets last.
+func lessRemaining(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	return *a < *b
+}
+
+func newSLIQueries(slo *models.Configv1SLO) (*sliQueries, error) {
+	sli := slo.Sli
+	if sli == nil {
+		return nil, fmt.Errorf("SLO %s has no SLI", slo.Slug)
+	}
+	if sli.CustomIndicator == nil {
+		return nil, fmt.Errorf("SLO %s uses a templated indicator, %w", slo.Slug, errTemplatedIndicator)
+	}
+	ci := sli.CustomIndicator
+	if ci.TotalQueryTemplate == "" || (ci.GoodQueryTemplate == "" && ci.BadQueryTemplate == "") {
+		return nil, fmt.Errorf("SLO %s must have a total query and a good or bad query", slo.Slug)
+	}
+	queries := &sliQueries{
+		good:    ci.GoodQueryTemplate,
+		bad:     ci.BadQueryTemplate,
+		total:   ci.TotalQueryTemplate,
+		groupBy: sli.CustomDimensionLabels,
+	}
+	if slo.CollectionRef != nil && slo.CollectionRef.Type == models.Configv1CollectionReferenceTypeSERVICE {
+		queries.lensService = slo.CollectionRef.Slug
+	}
+	for _, f := range sli.AdditionalPromqlFilters {
+		if f == nil {
+			continue
+		}
+		m, err := promqlMatcher(f)
+		if err != nil {
+			return nil, fmt.Errorf("SLO %s: %w", slo.Slug, err)
+		}
+		queries.filters = append(queries.filters, m)
+	}
+	return queries, nil
+}
+
+// errorRatio returns a query for the ratio of bad to total events over the window, aggregated
+// by the given labels.
+func (q *sliQueries) errorRatio(window string, by []string) (string, error) {
+	total, err := q.render(q.total, window)
+	if err != nil {
+		return "", err
+	}
+	agg := "sum"
+	if len(by) > 0 {
+		agg = fmt.Sprintf("sum by (%s) ", strings.Join(by, ", "))
+	}
+	if q.bad != "" {
+		bad, err := q.render(q.bad, window)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s) / %s(%s)", agg, bad, agg, total), nil
+	}
+	good, err := q.render(q.good, window)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("1 - %s(%s) / %s(%s)", agg, good, agg, total), nil
+}
+
+// render executes a query template for the window and applies the additional filters.
+func (q *sliQueries) render(queryTemplate, window string) (string, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(queryTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse query template %q: %w", queryTemplate, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, templateData{
+		Window:      window,
+		GroupBy:     strings.Join(q.groupBy, ", "),
+		LensService: q.lensService,
+	}); err != nil {
+		return "", fmt.Errorf("failed to render query template %q: %w", queryTemplate, err)
+	}
+	return promql.WithMatchers(sb.String(), q.filters)
+}
+
+func promqlMatcher(f *models.Configv1PromQLMatcher) (*labels.Matcher, error) {
+	var t labels.MatchType
+	switch f.Type {
+	case models.Configv1PromQLMatcherTypeMatchEqual:
+		t = labels.MatchEqual
+	case models.Configv1PromQLMatcherTypeMatchNotEqual:
+		t = labels.MatchNotEqual
+	case models.Configv1PromQLMatcherTypeMatchRegexp:
+		t = labels.MatchRegexp
+	case models.Configv1PromQLMatcherTypeMatchNotRegexp:
+		t = labels.MatchNotRegexp
+	default:
+		return nil, fmt.Errorf("unsupported matcher type %q for label %s", f.Type, f.Name)
+	}
+	m, err := labels.NewMatcher(t, f.Name, f.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid matcher for label %s: %w", f.Name, err)
+	}
+	return m, nil
+}
+
+// finite returns nil for NaN and infinite values, such as a ratio without any events.
+func finite(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+func TestErrorRatio(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sli     *models.Configv1SLI
+		by      []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "good query",
+			sli: &models.Configv1SLI{
+				CustomIndicator: &models.SLICustomIndicatorConfig{
+					GoodQueryTemplate:  `sum by ({{.GroupBy}}) (rate(http_requests_total{code!~"5.."}[{{.Window}}]))`,
+					TotalQueryTemplate: `sum by ({{.GroupBy}}) (rate(http_requests_total[{{.Window}}]))`,
+				},
+			},
+			want: `1 - sum(sum by () (rate(http_requests_total{code!~"5.."}[1h]))) / sum(sum by () (rate(http_requests_total[1h])))`,
+		},
+		{
+			name: "bad query with filters and dimensions",
+			sli: &models.Configv1SLI{
+				CustomIndicator: &models.SLICustomIndicatorConfig{
+					BadQueryTemplate:   `sum by ({{.GroupBy}}) (rate(errors_total[{{.Window}}]))`,
+					TotalQueryTemplate: `sum by ({{.GroupBy}}) (rate(requests_total[{{.Window}}]))`,
+				},
+				CustomDimensionLabels: []string{"endpoint"},
+				AdditionalPromqlFilters: []*models.Configv1PromQLMatcher{
+					{Name: "cluster", Type: models.Configv1PromQLMatcherTypeMatchNotRegexp, Value: "dev"},
+				},
+			},
+			by:   []string{"endpoint"},
+			want: `sum by (endpoint) (sum by (endpoint) (rate(errors_total{cluster!~"dev"}[1h]))) / sum by (endpoint) (sum by (endpoint) (rate(requests_total{cluster!~"dev"}[1h])))`,
+		},
+		{
+			name: "templated indicator",
+			sli: &models.Configv1SLI{
+				TemplatedIndicator: &models.SLITemplatedIndicatorConfig{DiscoveryJobSlug: "api"},
+			},
+			wantErr: "only custom indicators can be evaluated",
+		},
+		{
+			name: "missing total query",
+			sli: &models.Configv1SLI{
+				CustomIndicator: &models.SLICustomIndicatorConfig{GoodQueryTemplate: "up"},
+			},
+			wantErr: "must have a total query",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queries, err := newSLIQueries(&models.Configv1SLO{Slug: "api-availability", Sli: tc.sli})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got, err := queries.errorRatio("1h", tc.by)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	// A 99.9% objective allows an error ratio of 0.001.
+	allowed := 0.001
+	ratios := map[string]*float64{
+		"28d": ptr.To(0.0004),
+		"1h":  ptr.To(0.01),
+		"6h":  ptr.To(0.002),
+	}
+	attainment, remaining, burnRates := budgetStatus(ratios, "28d", allowed)
+	require.InDelta(t, 99.96, *attainment, 1e-9)
+	require.InDelta(t, 60.0, *remaining, 1e-9)
+	require.Len(t, burnRates, 3)
+	require.InDelta(t, 10.0, *burnRates[0].BurnRate, 1e-9)
+	require.InDelta(t, 2.0, *burnRates[1].BurnRate, 1e-9)
+	require.Equal(t, "3d", burnRates[2].Window)
+	require.Nil(t, burnRates[2].BurnRate)
+
+	attainment, remaining, _ = budgetStatus(map[string]*float64{}, "28d", allowed)
+	require.Nil(t, attainment)
+	require.Nil(t, remaining)
+}
+
+func TestSortByBudgetRemaining(t *testing.T) {
+	statuses := []*SLOStatus{
+		{Slug: "unknown"},
+		{Slug: "healthy", ErrorBudgetRemaining: ptr.To(80.0)},
+		{Slug: "exhausted", ErrorBudgetRemaining: ptr.To(-20.0)},
+	}
+	sortByBudgetRemaining(statuses)
+	var slugs []string
+	for _, s := range statuses {
+		slugs = append(slugs, s.Slug)
+	}
+	require.Equal(t, []string{"exhausted", "healthy", "unknown"}, slugs)
+}
+
+func TestEvaluateSLOTemplatedIndicator(t *testing.T) {
+	slo := &models.Configv1SLO{
+		Slug: "api-availability",
+		Sli: &models.Configv1SLI{
+			TemplatedIndicator: &models.SLITemplatedIndicatorConfig{DiscoveryJobSlug: "api"},
+		},
+	}
+	status, err := (&Tools{}).evaluateSLO(t.Context(), slo, time.Now(), false)
+	require.NoError(t, err)
+	require.Nil(t, status.Attainment)
+	require.Equal(t, []string{templatedIndicatorWarning}, status.Warnings)
+}
@@ -18,6 +18,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -25,6 +26,22 @@ import (
 type Config struct {
 	Disabled                []string `yaml:"disabled"`
 	EnableClassicDashboards bool     `yaml:"enableClassicDashboards"`
+	// EnableWrites allows tools to create config, such as generated dashboards. Tools only
+	// return the config they would create when disabled.
+	EnableWrites bool `yaml:"enableWrites"`
+	// Snapshots configures config snapshots used to detect config drift.
+	Snapshots SnapshotConfig `yaml:"snapshots"`
+}
+
+// SnapshotConfig configures where and how often config snapshots are taken.
+type SnapshotConfig struct {
+	// Directory stores snapshots. Snapshot tools are disabled if empty.
+	Directory string `yaml:"directory"`
+	// Interval between snapshots taken in the background. Snapshots are only taken on demand
+	// if zero.
+	Interval time.Duration `yaml:"interval"`
+	// Retain is the number of snapshots to keep. All snapshots are kept if zero.
+	Retain int `yaml:"retain"`
 }
 
 type Result struct {
@@ -33,6 +50,8 @@ type Result struct {
 	JSONContent  any
 	TextContent  string
 
+	// Optional. Images returned after JSONContent, such as charts rendered alongside a summary.
+	Images [][]byte
 	// Optional.
 	Meta map[string]any
 	// Optional. If set, an additional text content will be returned with this link in the response.
@@ -0,0 +1,162 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1/trace_tail_sampling_rules"
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/params"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/tracefilter"
+)
+
+// Sampling decisions.
+const (
+	decisionRule              = "rule"
+	decisionDefaultSampleRate = "default_sample_rate"
+	decisionSystemDefault     = "system_default"
+)
+
+// SamplingExplanation explains which tail sampling rule applies to a span.
+type SamplingExplanation struct {
+	// Decision is what the sample rate comes from: a rule, the configured default sample rate,
+	// or the system default when no default sample rate is configured.
+	Decision string `json:"decision"`
+	// SampleRate is the fraction of traces kept. It is unset for the system default.
+	SampleRate  *float64        `json:"sample_rate,omitempty"`
+	MatchedRule *RuleEvaluation `json:"matched_rule,omitempty"`
+	// Rules are the rules evaluated before the decision, in order.
+	Rules    []*RuleEvaluation `json:"rules"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// RuleEvaluation describes whether a tail sampling rule matched.
+type RuleEvaluation struct {
+	Index      int     `json:"index"`
+	Name       string  `json:"name,omitempty"`
+	SystemName string  `json:"system_name,omitempty"`
+	SampleRate float64 `json:"sample_rate"`
+	Matched    bool    `json:"matched"`
+	// UnmatchedConditions are the conditions of the rule the span does not satisfy.
+	UnmatchedConditions []string `json:"unmatched_conditions,omitempty"`
+	// UndeterminedConditions are conditions that depend on fields which were not given.
+	UndeterminedConditions []string `json:"undetermined_conditions,omitempty"`
+}
+
+func (t *Tools) explainTraceSampling(ctx context.Context, request mcp.CallToolRequest) (*tools.Result, error) {
+	span := &tracefilter.Span{}
+	var err error
+	if span.Service, err = params.String(request, "service", true, ""); err != nil {
+		return nil, err
+	}
+	if span.Operation, err = params.String(request, "operation", false, ""); err != nil {
+		return nil, err
+	}
+	if span.ParentService, err = params.String(request, "parent_service", false, ""); err != nil {
+		return nil, err
+	}
+	if span.ParentOperation, err = params.String(request, "parent_operation", false, ""); err != nil {
+		return nil, err
+	}
+	if span.Attributes, err = params.Object[map[string]string](request, "attributes", false, nil); err != nil {
+		return nil, err
+	}
+	if span.Error, err = params.Bool(request, "error", false, false); err != nil {
+		return nil, err
+	}
+	if span.IsRootSpan, err = params.Bool(request, "is_root_span", false, true); err != nil {
+		return nil, err
+	}
+	if _, ok := request.GetArguments()["duration_secs"]; ok {
+		duration, err := params.Float(request, "duration_secs", false, 0)
+		if err != nil {
+			return nil, err
+		}
+		span.DurationSecs = &duration
+	}
+
+	var rules *models.Configv1TraceTailSamplingRules
+	resp, err := t.configAPI.TraceTailSamplingRules.ReadTraceTailSamplingRules(&trace_tail_sampling_rules.ReadTraceTailSamplingRulesParams{
+		Context: ctx,
+	})
+	var notFound *trace_tail_sampling_rules.ReadTraceTailSamplingRulesNotFound
+	switch {
+	case errors.As(err, &notFound):
+		// No tail sampling rules are configured.
+	case err != nil:
+		return nil, fmt.Errorf("failed to read trace tail sampling rules: %s", err)
+	default:
+		rules = resp.Payload.TraceTailSamplingRules
+	}
+	return &tools.Result{JSONContent: explainSampling(rules, span)}, nil
+}
+
+// explainSampling evaluates the tail sampling rules in order until one matches the span, or
+// else applies the default sample rate.
+func explainSampling(rules *models.Configv1TraceTailSamplingRules, span *tracefilter.Span) *SamplingExplanation {
+	explanation := &SamplingExplanation{Rules: []*RuleEvaluation{}}
+	if rules == nil {
+		rules = &models.Configv1TraceTailSamplingRules{}
+	}
+	for i, rule := range rules.Rules {
+		if rule == nil {
+			continue
+		}
+		result := tracefilter.Evaluate(rule.Filter, span)
+		eval := &RuleEvaluation{
+			Index:                  i,
+			Name:                   rule.Name,
+			SystemName:             rule.SystemName,
+			SampleRate:             rule.SampleRate,
+			Matched:                result.Matched(),
+			UnmatchedConditions:    result.Unmatched,
+			UndeterminedConditions: result.Undetermined,
+		}
+		explanation.Rules = append(explanation.Rules, eval)
+		if result.MayMatch() {
+			explanation.Warnings = append(explanation.Warnings, fmt.Sprintf(
+				"rule %d (%s) may match depending on %s, in which case its sample rate %g applies",
+				i, ruleName(rule), strings.Join(result.Undetermined, ", "), rule.SampleRate))
+		}
+		if eval.Matched {
+			explanation.Decision = decisionRule
+			explanation.SampleRate = &eval.SampleRate
+			explanation.MatchedRule = eval
+			return explanation
+		}
+	}
+
+	if d := rules.DefaultSampleRate; d != nil && d.Enabled {
+		explanation.Decision = decisionDefaultSampleRate
+		explanation.SampleRate = &d.SampleRate
+	} else {
+		explanation.Decision = decisionSystemDefault
+	}
+	return explanation
+}
+
+func ruleName(rule *models.Configv1TraceTailSamplingRule) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return rule.SystemName
+}
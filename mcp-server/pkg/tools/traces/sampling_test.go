@@ -0,0 +1,167 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/models"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/pkg/tracefilter"
+	"github.com/chronosphereio/chronosphere-mcp/pkg/ptr"
+)
+
+func TestExplainSampling(t *testing.T) {
+	rules := &models.Configv1TraceTailSamplingRules{
+		DefaultSampleRate: &models.Configv1DefaultSampleRate{Enabled: true, SampleRate: 0.1},
+		Rules: []*models.Configv1TraceTailSamplingRule{
+			{
+				Name:       "keep errors",
+				SampleRate: 1,
+				Filter: &models.Configv1TraceSearchFilter{
+					Trace: &models.TraceSearchFilterTraceFilter{Error: &models.TraceSearchFilterBoolFilter{Value: true}},
+				},
+			},
+			{
+				Name:       "drop health checks",
+				SampleRate: 0,
+				Filter: &models.Configv1TraceSearchFilter{Span: []*models.TraceSearchFilterSpanFilter{{
+					Operation: &models.TraceSearchFilterStringFilter{Match: models.StringFilterStringFilterMatchTypeREGEX, Value: "GET /health.*"},
+				}}},
+			},
+			{
+				SystemName: "slow-checkout",
+				SampleRate: 0.5,
+				Filter: &models.Configv1TraceSearchFilter{
+					Span: []*models.TraceSearchFilterSpanFilter{{
+						Service: &models.TraceSearchFilterStringFilter{Match: models.StringFilterStringFilterMatchTypeIN, InValues: []string{"checkout", "cart"}},
+						Tags: []*models.TraceSearchFilterTagFilter{{
+							Key:          "http.status_code",
+							NumericValue: &models.TraceSearchFilterNumericFilter{Comparison: models.NumericFilterComparisonTypeLESSTHAN, Value: 500},
+						}},
+					}},
+					Trace: &models.TraceSearchFilterTraceFilter{Duration: &models.TraceSearchFilterDurationFilter{MinSecs: 2}},
+				},
+			},
+			{
+				Name:       "internal",
+				SampleRate: 0.01,
+				Filter: &models.Configv1TraceSearchFilter{Span: []*models.TraceSearchFilterSpanFilter{{
+					MatchType: models.SpanFilterSpanFilterMatchTypeEXCLUDE,
+					Service:   &models.TraceSearchFilterStringFilter{Value: "gateway"},
+				}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		rules       *models.Configv1TraceTailSamplingRules
+		span        *tracefilter.Span
+		decision    string
+		sampleRate  *float64
+		matchedRule string
+		unmatched   map[int][]string
+		warnings    []string
+	}{
+		{
+			name:        "error rule",
+			rules:       rules,
+			span:        &tracefilter.Span{Service: "api", Error: true},
+			decision:    decisionRule,
+			sampleRate:  ptr.To(1.0),
+			matchedRule: "keep errors",
+		},
+		{
+			name:        "regex on operation",
+			rules:       rules,
+			span:        &tracefilter.Span{Service: "api", Operation: "GET /healthz"},
+			decision:    decisionRule,
+			sampleRate:  ptr.To(0.0),
+			matchedRule: "drop health checks",
+			unmatched:   map[int][]string{0: {"trace error = true"}},
+		},
+		{
+			name:        "tags and duration",
+			rules:       rules,
+			span:        &tracefilter.Span{Service: "cart", Operation: "POST /cart", Attributes: map[string]string{"http.status_code": "200"}, DurationSecs: ptr.To(3.0)},
+			decision:    decisionRule,
+			sampleRate:  ptr.To(0.5),
+			matchedRule: "slow-checkout",
+			unmatched:   map[int][]string{0: {"trace error = true"}, 1: {`span filter 0: operation =~ "GET /health.*"`}},
+		},
+		{
+			name:        "undetermined conditions",
+			rules:       rules,
+			span:        &tracefilter.Span{Service: "checkout", Attributes: map[string]string{"http.status_code": "200"}},
+			decision:    decisionRule,
+			sampleRate:  ptr.To(0.01),
+			matchedRule: "internal",
+			unmatched:   map[int][]string{0: {"trace error = true"}},
+			warnings: []string{
+				`rule 1 (drop health checks) may match depending on span filter 0: operation =~ "GET /health.*", in which case its sample rate 0 applies`,
+				`rule 2 (slow-checkout) may match depending on trace duration >= 2s, in which case its sample rate 0.5 applies`,
+			},
+		},
+		{
+			name:       "default sample rate",
+			rules:      rules,
+			span:       &tracefilter.Span{Service: "gateway", Operation: "GET /", Attributes: map[string]string{"http.status_code": "oops"}, DurationSecs: ptr.To(3.0)},
+			decision:   decisionDefaultSampleRate,
+			sampleRate: ptr.To(0.1),
+			unmatched: map[int][]string{
+				0: {"trace error = true"},
+				1: {`span filter 0: operation =~ "GET /health.*"`},
+				2: {`span filter 0: tag http.status_code < 500 (not a number)`, `span filter 0: service in ["checkout" "cart"]`},
+				3: {`span filter 0: excludes traces with spans matching service = "gateway"`},
+			},
+		},
+		{
+			name:     "no rules",
+			span:     &tracefilter.Span{Service: "api"},
+			decision: decisionSystemDefault,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation := explainSampling(tt.rules, tt.span)
+			require.Equal(t, tt.decision, explanation.Decision)
+			require.Equal(t, tt.sampleRate, explanation.SampleRate)
+			if tt.matchedRule == "" {
+				require.Nil(t, explanation.MatchedRule)
+			} else {
+				require.NotNil(t, explanation.MatchedRule)
+				require.Equal(t, tt.matchedRule, ruleName(tt.rules.Rules[explanation.MatchedRule.Index]))
+			}
+			unmatched := make(map[int][]string)
+			for _, r := range explanation.Rules {
+				if len(r.UnmatchedConditions) > 0 {
+					unmatched[r.Index] = r.UnmatchedConditions
+				}
+			}
+			if tt.unmatched == nil {
+				tt.unmatched = map[int][]string{}
+			}
+			require.ElementsMatch(t, slices.Collect(maps.Keys(tt.unmatched)), slices.Collect(maps.Keys(unmatched)))
+			for i, conditions := range tt.unmatched {
+				require.ElementsMatch(t, conditions, unmatched[i], "rule %d", i)
+			}
+			require.Equal(t, tt.warnings, explanation.Warnings)
+		})
+	}
+}
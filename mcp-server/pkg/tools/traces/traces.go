@@ -12,7 +12,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package traces contains tools for querying Chronosphere traces.
+// Package traces contains tools for querying Chronosphere traces and explaining how they are
+// sampled.
 package traces
 
 import (
@@ -23,6 +24,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"go.uber.org/zap"
 
+	"github.com/chronosphereio/chronosphere-mcp/generated/configv1/configv1"
 	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1"
 	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/datav1/version1"
 	"github.com/chronosphereio/chronosphere-mcp/generated/datav1/models"
@@ -33,19 +35,22 @@ import (
 var _ tools.MCPTools = (*Tools)(nil)
 
 type Tools struct {
-	logger *zap.Logger
-	api    *datav1.DataV1API
+	logger    *zap.Logger
+	api       *datav1.DataV1API
+	configAPI *configv1.ConfigV1API
 }
 
 func NewTools(
 	api *datav1.DataV1API,
+	configAPI *configv1.ConfigV1API,
 	logger *zap.Logger,
 ) (*Tools, error) {
 	logger.Info("events tool configured")
 
 	return &Tools{
-		logger: logger,
-		api:    api,
+		logger:    logger,
+		api:       api,
+		configAPI: configAPI,
 	}, nil
 }
 
@@ -142,6 +147,47 @@ func (t *Tools) MCPTools() []tools.MCPTool {
 				}, nil
 			},
 		},
+		{
+			Metadata: tools.NewMetadata("explain_trace_sampling",
+				mcp.WithDescription(`Explains which trace tail sampling rule applies to a span and the resulting sample rate. Use this to answer questions like "why are traces of this service missing" or "how much of this operation is kept".
+
+The tail sampling rules are evaluated locally in order and the first matching rule applies, or else the default sample rate. The trace is assumed to consist of the given span only, so span count conditions are evaluated against that span. Conditions on fields that are not given, such as the operation or duration, are reported as undetermined rather than guessed. Attributes that are not given are treated as not set. Regular expressions must match the whole value.
+
+Response fields:
+- decision: What the sample rate comes from: rule, default_sample_rate, or system_default when no default sample rate is configured
+- sample_rate: Fraction of traces kept, between 0 and 1
+- matched_rule: The rule that applies, if any
+- rules: The rules evaluated before the decision, with their unmatched and undetermined conditions
+- warnings: Earlier rules that may match depending on the undetermined conditions`),
+				mcp.WithString("service",
+					mcp.Description("Service of the span."),
+					mcp.Required(),
+				),
+				mcp.WithString("operation",
+					mcp.Description("Optional. Operation of the span."),
+				),
+				mcp.WithObject("attributes",
+					mcp.Description(`Optional. Span attributes (tags), e.g. {"http.status_code": "500", "env": "prod"}.`),
+					mcp.AdditionalProperties(map[string]any{"type": "string"}),
+				),
+				mcp.WithNumber("duration_secs",
+					mcp.Description("Optional. Duration of the span, and thereby of the trace, in seconds."),
+				),
+				mcp.WithBoolean("error",
+					mcp.Description("Optional. Whether the span has an error. Defaults to false."),
+				),
+				mcp.WithBoolean("is_root_span",
+					mcp.Description("Optional. Whether the span is the root span of the trace. Defaults to true."),
+				),
+				mcp.WithString("parent_service",
+					mcp.Description("Optional. Service of the parent span, for spans that are not root spans."),
+				),
+				mcp.WithString("parent_operation",
+					mcp.Description("Optional. Operation of the parent span, for spans that are not root spans."),
+				),
+			),
+			Handler: t.explainTraceSampling,
+		},
 	}
 }
 
@@ -273,12 +273,12 @@ func TestListTracesQueryTypeFields(t *testing.T) {
 			client := datav1.NewHTTPClientWithConfig(nil, transport)
 			logger := zaptest.NewLogger(t)
 
-			tools, err := NewTools(client, logger)
+			tools, err := NewTools(client, nil, logger)
 			require.NoError(t, err)
 
 			// Get the list_traces tool
 			mcpTools := tools.MCPTools()
-			require.Len(t, mcpTools, 1)
+			require.Len(t, mcpTools, 2)
 			listTracesTool := mcpTools[0]
 
 			// Create the request
@@ -20,22 +20,34 @@ import (
 
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/configapi"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/configexport"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/configsearch"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/configsnapshot"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/dashboards"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/events"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/logs"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/metricrules"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/metricusage"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/monitors"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/prometheus"
+	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/slos"
 	"github.com/chronosphereio/chronosphere-mcp/mcp-server/pkg/tools/traces"
 )
 
 var Module = fx.Provide(
 	annotateAsTool(
 		configapi.NewTools,
+		configexport.NewTools,
+		configsearch.NewTools,
+		configsnapshot.NewTools,
+		dashboards.NewTools,
 		events.NewTools,
 		logs.NewTools,
+		metricrules.NewTools,
 		metricusage.NewTools,
 		monitors.NewTools,
 		prometheus.NewTools,
+		slos.NewTools,
 		traces.NewTools,
 	)...,
 )
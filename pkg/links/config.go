@@ -0,0 +1,39 @@
+// Copyright 2025 Chronosphere Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package links
+
+// configEntityPaths are the UI paths of config entities by entity type, e.g. monitor.
+var configEntityPaths = map[string]string{
+	"monitor":             "/monitors/",
+	"dashboard":           "/dashboards/",
+	"slo":                 "/slos/",
+	"recording_rule":      "/control/recording-rules/",
+	"drop_rule":           "/control/drop-rules/",
+	"rollup_rule":         "/control/aggregation-rules/",
+	"mapping_rule":        "/control/mapping-rules/",
+	"collection":          "/collections/",
+	"team":                "/teams/",
+	"notification_policy": "/alerts/notification-policies/",
+}
+
+// ConfigEntity returns a link to the config entity of the given type, such as monitor or
+// recording_rule, or an empty string for entity types without a page.
+func (b *Builder) ConfigEntity(entityType, slug string) string {
+	path, ok := configEntityPaths[entityType]
+	if !ok || slug == "" {
+		return ""
+	}
+	return b.Custom(path + slug).String()
+}
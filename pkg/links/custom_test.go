@@ -521,3 +521,12 @@ func TestCustomBuilder_ParameterTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigEntity(t *testing.T) {
+	builder := NewBuilder("https://custom.chronosphere.io")
+	assert.Equal(t, "https://custom.chronosphere.io/monitors/api-errors?", builder.ConfigEntity("monitor", "api-errors"))
+	assert.Equal(t, "https://custom.chronosphere.io/control/recording-rules/api-rate?", builder.ConfigEntity("recording_rule", "api-rate"))
+	assert.Equal(t, "https://custom.chronosphere.io/teams/payments?", builder.ConfigEntity("team", "payments"))
+	assert.Empty(t, builder.ConfigEntity("bucket", "payments"))
+	assert.Empty(t, builder.ConfigEntity("monitor", ""))
+}
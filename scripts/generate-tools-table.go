@@ -144,22 +144,13 @@ func extractToolsFromFile(node *ast.File, _ string) []Tool {
 func extractToolsFromMCPToolsMethod(funcDecl *ast.FuncDecl, groupName string) []Tool {
 	var tools []Tool
 
-	// Look for return statements with composite literals
+	// Look for tools.MCPTool composite literals, whether returned directly or appended
+	// conditionally, e.g. tools only served when enabled in the config.
 	ast.Inspect(funcDecl, func(n ast.Node) bool {
-		switch x := n.(type) {
-		case *ast.ReturnStmt:
-			for _, result := range x.Results {
-				if compLit, ok := result.(*ast.CompositeLit); ok {
-					// This should be []tools.MCPTool
-					for _, elt := range compLit.Elts {
-						if toolLit, ok := elt.(*ast.CompositeLit); ok {
-							tool := extractToolFromCompositeLiteral(toolLit, groupName)
-							if tool.Name != "" {
-								tools = append(tools, tool)
-							}
-						}
-					}
-				}
+		if toolLit, ok := n.(*ast.CompositeLit); ok {
+			tool := extractToolFromCompositeLiteral(toolLit, groupName)
+			if tool.Name != "" {
+				tools = append(tools, tool)
 			}
 		}
 		return true
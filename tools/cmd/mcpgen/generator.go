@@ -17,6 +17,7 @@ package main
 import (
 	"bytes"
 	_ "embed"
+	"go/token"
 	"log"
 	"regexp"
 	"strings"
@@ -60,6 +61,11 @@ type Entity struct {
 	Name      string
 	PkgName   string
 	ToolSpecs []ToolSpec
+	// UsesPtr is whether any tool needs the ptr package, which is not the case for entities without
+	// a list command.
+	UsesPtr bool
+	// UsesParams is whether any tool has parameters, which is not the case for singletons.
+	UsesParams bool
 }
 
 type entitySpec struct {
@@ -97,17 +103,38 @@ func generate(pkgName string, spec *clispec.Spec) ([]generatedFile, error) {
 	return files, nil
 }
 
+// pluralEntities are entities whose name is plural even though a single entity is read, e.g.
+// the trace tail sampling rules singleton holds all rules.
+var pluralEntities = map[string]bool{
+	"resource-pools":            true,
+	"trace-tail-sampling-rules": true,
+}
+
 func convertEntity(entity *clispec.Entity) Entity {
+	singular := inflect.Singularize(entity.Name)
+	if pluralEntities[entity.Name] {
+		singular = entity.Name
+	}
 	ent := Entity{
-		Name:    camelCase(inflect.Singularize(entity.Name)),
-		PkgName: entityPkgMap(inflect.Underscore(inflect.Singularize(entity.Name))),
+		Name:    camelCase(singular),
+		PkgName: entityPkgMap(inflect.Underscore(singular)),
 	}
 
-	ent.ToolSpecs = append(ent.ToolSpecs, convertToolSpec(inflect.Singularize(entity.Name), "Read", entity.Get))
+	ent.ToolSpecs = append(ent.ToolSpecs, convertToolSpec(singular, "Read", entity.Get))
 
 	if entity.IsNotSingleton() {
 		ent.ToolSpecs = append(ent.ToolSpecs, convertToolSpec(entity.Name, "List", entity.List))
 	}
+	for _, spec := range ent.ToolSpecs {
+		if len(spec.Parameters) > 0 {
+			ent.UsesParams = true
+		}
+		for _, param := range spec.Parameters {
+			if param.GoName == "pageMaxSize" || (!param.Required && param.IsScalar && param.GoName != "pageToken") {
+				ent.UsesPtr = true
+			}
+		}
+	}
 	return ent
 }
 
@@ -130,9 +157,14 @@ func convertToolSpec(entityName string, action string, command *clispec.Command)
 		APIParam:    action + acronymReplace(camelCase(entityName)),
 	}
 	for _, param := range command.Parameters {
+		goName := uncapitalize(camelCase(param.Name))
+		if token.IsKeyword(goName) {
+			// Parameters such as type would otherwise be invalid variable names.
+			goName += "Param"
+		}
 		spec.Parameters = append(spec.Parameters, ParameterSpec{
 			Name:               inflect.Underscore(strings.Replace(param.Name, ".", "_", -1)),
-			GoName:             uncapitalize(camelCase(param.Name)),
+			GoName:             goName,
 			SwaggerGoFieldName: acronymReplace(camelCase(param.Name)),
 			Description:        param.Description,
 			MCPParamFunc:       goTypeToMCPParamFunc(param.GoType),